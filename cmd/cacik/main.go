@@ -2,15 +2,88 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 
-	"github.com/denizgursoy/cacik/internal/comment_parser"
-	"github.com/denizgursoy/cacik/internal/generator"
+	"github.com/denizgursoy/cacik/pkg/cacikgen"
+	"github.com/denizgursoy/cacik/pkg/linter"
+)
+
+const (
+	lintCommand   = "lint"
+	reportCommand = "report"
+	replCommand   = "repl"
+	doctorCommand = "doctor"
 )
 
 func main() {
-	err := generator.StartGenerator(context.Background(), comment_parser.NewGoSourceFileParser())
+	if len(os.Args) > 1 && os.Args[1] == lintCommand {
+		os.Exit(lint(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == reportCommand {
+		os.Exit(report(os.Args[2:], os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == replCommand {
+		os.Exit(repl(os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == doctorCommand {
+		os.Exit(doctor(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
+	err := cacikgen.Generate(context.Background())
 	if err != nil {
 		os.Exit(1)
 	}
 }
+
+// lint prints the issues of the feature files in the directories, or the working directory, and returns the exit
+// code of the command which is 1 if any issue is an error
+func lint(directories []string) int {
+	if len(directories) == 0 {
+		directories = []string{"."}
+	}
+
+	issues, err := linter.LintDirectories(directories)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		return 1
+	}
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+	if linter.HasErrors(issues) {
+		return 1
+	}
+
+	return 0
+}
+
+// doctor prints the diagnoses of the environment of the working directory and returns the exit code of the command
+// which is 1 if any check failed
+func doctor(arguments []string, out, errorOut io.Writer) int {
+	flags := flag.NewFlagSet(doctorCommand, flag.ContinueOnError)
+	flags.SetOutput(errorOut)
+	if err := flags.Parse(arguments); err != nil {
+		return 2
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintln(errorOut, "doctor checks the working directory and takes no arguments, got",
+			strings.Join(flags.Args(), ", "))
+
+		return 2
+	}
+
+	diagnoses := cacikgen.Diagnose(context.Background())
+	for _, diagnosis := range diagnoses {
+		fmt.Fprintln(out, diagnosis)
+	}
+	if cacikgen.Failed(diagnoses) {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/denizgursoy/cacik/pkg/cacikgen"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoctor(t *testing.T) {
+	t.Run("should print the diagnoses of a module with an up to date generated file", func(t *testing.T) {
+		directory := t.TempDir()
+		require.Nil(t, os.WriteFile(filepath.Join(directory, "go.mod"), []byte("module orchard\n\ngo 1.22\n"), 0o644))
+		require.Nil(t, os.WriteFile(filepath.Join(directory, "steps.go"), []byte("package main\n\n"+
+			"// @cacik `^I have {int} apples$`\nfunc IHaveApples(count int) {}\n"), 0o644))
+		workingDirectory, err := os.Getwd()
+		require.Nil(t, err)
+		require.Nil(t, os.Chdir(directory))
+		arguments := os.Args
+		t.Cleanup(func() {
+			os.Args = arguments
+			require.Nil(t, os.Chdir(workingDirectory))
+		})
+		os.Args = []string{"cacik"}
+		require.Nil(t, cacikgen.Generate(context.Background()))
+		out, errorOut := &bytes.Buffer{}, &bytes.Buffer{}
+
+		code := doctor(nil, out, errorOut)
+
+		require.Equal(t, 0, code, out.String())
+		require.Contains(t, out.String(), "ok   patterns: 1 step definitions compile")
+		require.Contains(t, out.String(), "ok   generated: main.go is up to date")
+		require.Empty(t, errorOut.String())
+	})
+
+	t.Run("should return 2 if arguments are given", func(t *testing.T) {
+		out, errorOut := &bytes.Buffer{}, &bytes.Buffer{}
+
+		code := doctor([]string{"./e2e"}, out, errorOut)
+
+		require.Equal(t, 2, code)
+		require.Empty(t, out.String())
+		require.Equal(t, "doctor checks the working directory and takes no arguments, got ./e2e\n", errorOut.String())
+	})
+
+	t.Run("should return 2 for an unknown flag", func(t *testing.T) {
+		errorOut := &bytes.Buffer{}
+
+		require.Equal(t, 2, doctor([]string{"-verbose"}, &bytes.Buffer{}, errorOut))
+		require.Contains(t, errorOut.String(), "flag provided but not defined: -verbose")
+	})
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/denizgursoy/cacik/pkg/runner"
+)
+
+// repl runs the generated main file of the package in the directory, or the working directory, with the REPL of
+// the runner started instead of the run, so the steps registered by it can be typed interactively. It returns the
+// exit code of the command.
+func repl(arguments []string, in io.Reader, out, errorOut io.Writer) int {
+	flags := flag.NewFlagSet(replCommand, flag.ContinueOnError)
+	flags.SetOutput(errorOut)
+	if err := flags.Parse(arguments); err != nil {
+		return 2
+	}
+	if flags.NArg() > 1 {
+		fmt.Fprintln(errorOut, "the REPL runs the main file of one directory, got", strings.Join(flags.Args(), ", "))
+
+		return 2
+	}
+	directory := "."
+	if flags.NArg() == 1 {
+		directory = flags.Arg(0)
+	}
+
+	command := exec.Command("go", "run", ".")
+	command.Dir = directory
+	command.Env = append(os.Environ(), runner.ReplVariable+"=1")
+	command.Stdin, command.Stdout, command.Stderr = in, out, errorOut
+	if err := command.Run(); err != nil {
+		fmt.Fprintf(errorOut, "could not run the REPL in %s, error=%v\n", directory, err)
+
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepl(t *testing.T) {
+	t.Run("should run the main file of the directory with the REPL started", func(t *testing.T) {
+		directory := t.TempDir()
+		require.Nil(t, os.WriteFile(filepath.Join(directory, "go.mod"), []byte("module orchard\n\ngo 1.22\n"), 0o644))
+		require.Nil(t, os.WriteFile(filepath.Join(directory, "main.go"), []byte(`package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+)
+
+func main() {
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		fmt.Printf("%s %s\n", os.Getenv("CACIK_REPL"), scanner.Text())
+	}
+}
+`), 0o644))
+		out, errorOut := &bytes.Buffer{}, &bytes.Buffer{}
+
+		code := repl([]string{directory}, strings.NewReader("Given I have 3 apples\n"), out, errorOut)
+
+		require.Equal(t, 0, code, errorOut.String())
+		require.Equal(t, "1 Given I have 3 apples\n", out.String())
+	})
+
+	t.Run("should return 2 if more than one directory is given", func(t *testing.T) {
+		errorOut := &bytes.Buffer{}
+
+		code := repl([]string{"./e2e", "./smoke"}, strings.NewReader(""), &bytes.Buffer{}, errorOut)
+
+		require.Equal(t, 2, code)
+		require.Equal(t, "the REPL runs the main file of one directory, got ./e2e, ./smoke\n", errorOut.String())
+	})
+
+	t.Run("should return 1 if the directory has no main file", func(t *testing.T) {
+		errorOut := &bytes.Buffer{}
+
+		code := repl([]string{t.TempDir()}, strings.NewReader(""), &bytes.Buffer{}, errorOut)
+
+		require.Equal(t, 1, code)
+		require.Contains(t, errorOut.String(), "could not run the REPL in")
+	})
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/denizgursoy/cacik/pkg/reporter"
+)
+
+// report writes the reports selected by the flags from a run result saved with the result file option, so reports
+// can be created again after the run without executing the scenarios. It returns the exit code of the command.
+func report(arguments []string, errorOut io.Writer) int {
+	flags := flag.NewFlagSet(reportCommand, flag.ContinueOnError)
+	flags.SetOutput(errorOut)
+	from := flags.String("from", "", "run result saved by a run, such as result.json")
+	junit := flags.String("junit", "", "file to write the JUnit XML report to")
+	markdown := flags.String("markdown", "", "file to write the markdown report to")
+	html := flags.String("html", "", "file to write the HTML report to")
+	cucumber := flags.String("cucumber", "", "file to write the Cucumber JSON report to")
+	history := flags.String("history", "", "history directory whose trends the HTML report shows")
+	badge := flags.String("badge", "", "file to write the SVG badge to")
+	if err := flags.Parse(arguments); err != nil {
+		return 2
+	}
+
+	if err := writeReports(*from, *junit, *markdown, *html, *cucumber, *history, *badge); err != nil {
+		fmt.Fprintln(errorOut, err)
+
+		return 1
+	}
+
+	return 0
+}
+
+func writeReports(from, junit, markdown, html, cucumber, history, badge string) error {
+	if len(from) == 0 {
+		return errors.New("the run result to create reports from must be given with -from")
+	}
+	if len(junit) == 0 && len(markdown) == 0 && len(html) == 0 && len(cucumber) == 0 && len(badge) == 0 {
+		return errors.New("no report is selected, select reports with -junit, -markdown, -html, -cucumber or -badge")
+	}
+
+	result, err := models.LoadRunResult(from)
+	if err != nil {
+		return err
+	}
+	if len(junit) > 0 {
+		if err := reporter.GenerateJUnitReport(junit, result); err != nil {
+			return err
+		}
+	}
+	if len(markdown) > 0 {
+		if err := reporter.GenerateMarkdownReport(markdown, result); err != nil {
+			return err
+		}
+	}
+	if len(html) > 0 {
+		options := reporter.HTMLReportOptions{}
+		if len(history) > 0 {
+			if options.History, err = models.LoadHistory(history); err != nil {
+				return err
+			}
+		}
+		if err := reporter.GenerateHTMLReport(html, result, options); err != nil {
+			return err
+		}
+	}
+	if len(cucumber) > 0 {
+		if err := reporter.GenerateCucumberJSONReport(cucumber, result); err != nil {
+			return err
+		}
+	}
+	if len(badge) > 0 {
+		if err := reporter.GenerateBadge(badge, result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
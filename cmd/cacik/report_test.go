@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport(t *testing.T) {
+	t.Run("should write the selected reports from the run result", func(t *testing.T) {
+		directory := t.TempDir()
+		from := filepath.Join(directory, "result.json")
+		result := &models.RunResult{StartedAt: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), Duration: time.Second,
+			Scenarios: []*models.ScenarioResult{{Name: "Eat apples", Status: models.StatusPassed}}}
+		require.Nil(t, result.Save(from))
+		markdown, badge := filepath.Join(directory, "report.md"), filepath.Join(directory, "badge.svg")
+		cucumber := filepath.Join(directory, "cucumber.json")
+		errorOut := &bytes.Buffer{}
+
+		code := report([]string{"-from", from, "-markdown", markdown, "-cucumber", cucumber, "-badge", badge}, errorOut)
+
+		require.Equal(t, 0, code, errorOut.String())
+		require.FileExists(t, markdown)
+		require.FileExists(t, cucumber)
+		require.FileExists(t, badge)
+		require.NoFileExists(t, filepath.Join(directory, "report.html"))
+	})
+
+	t.Run("should return 1 without the run result", func(t *testing.T) {
+		errorOut := &bytes.Buffer{}
+
+		require.Equal(t, 1, report([]string{"-markdown", "report.md"}, errorOut))
+		require.Equal(t, "the run result to create reports from must be given with -from\n", errorOut.String())
+	})
+
+	t.Run("should return 1 without a selected report", func(t *testing.T) {
+		errorOut := &bytes.Buffer{}
+
+		require.Equal(t, 1, report([]string{"-from", "result.json"}, errorOut))
+		require.Equal(t, "no report is selected, select reports with -junit, -markdown, -html, -cucumber or -badge\n",
+			errorOut.String())
+	})
+
+	t.Run("should return 2 for an unknown flag", func(t *testing.T) {
+		errorOut := &bytes.Buffer{}
+
+		require.Equal(t, 2, report([]string{"-pdf", "report.pdf"}, errorOut))
+		require.Contains(t, errorOut.String(), "flag provided but not defined: -pdf")
+	})
+}
@@ -6,91 +6,248 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
-	"os"
-	"os/exec"
+	"go/types"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/denizgursoy/cacik/internal/generator"
+	"golang.org/x/tools/go/packages"
 )
 
 const (
 	StepPrefix   = "@cacik"
+	ReadsPrefix  = "@cacik-reads"
+	WritesPrefix = "@cacik-writes"
 	SpaceAndTick = " `"
+	// SpaceAndQuote starts a definition written as a double-quoted Go string, such as a definition with a backtick
+	SpaceAndQuote = ` "`
+	configType    = "github.com/denizgursoy/cacik/pkg/models.Config"
 )
 
 type GoSourceFileParser struct {
+	options generator.SourceOptions
 }
 
 func NewGoSourceFileParser() *GoSourceFileParser {
 	return &GoSourceFileParser{}
 }
 
+// SetSourceOptions sets the options selecting the go files searched for step functions
+func (g *GoSourceFileParser) SetSourceOptions(options generator.SourceOptions) {
+	g.options = options
+}
+
+// ParseFunctionCommentsOfGoFilesInDirectoryRecursively finds the step functions and the config function in the
+// packages of the directory and its sub directories. Packages are loaded with their type information, so import
+// paths are exact and the parameters of every step function are checked against the arguments its step captures.
 func (g *GoSourceFileParser) ParseFunctionCommentsOfGoFilesInDirectoryRecursively(ctx context.Context, parentDirectory string) (
 	*generator.Output, error) {
-	directories := getAllSubDirectories(parentDirectory)
-	directories = append(directories, parentDirectory)
-
 	output := &generator.Output{
 		ConfigFunction: nil,
 		StepFunctions:  make([]*generator.StepFunctionLocator, 0),
 	}
 
-	allPackages := make(map[string]*ast.Package)
-	for _, dir := range directories {
-		packagesInTheDirectory, err := parser.ParseDir(token.NewFileSet(), dir, nil, parser.ParseComments)
+	files, err := sourceFiles(parentDirectory, g.options)
+	if err != nil {
+		return nil, err
+	}
+	selected := make(map[string]bool, len(files))
+	for _, file := range files {
+		absolute, err := filepath.Abs(file)
 		if err != nil {
 			return nil, err
 		}
-		mergePackages(allPackages, packagesInTheDirectory)
+		selected[absolute] = true
+	}
+	if len(g.options.Output) > 0 {
+		output, err := filepath.Abs(g.options.Output)
+		if err != nil {
+			return nil, err
+		}
+		delete(selected, output)
+	}
+
+	loaded, err := loadPackages(ctx, parentDirectory, g.options, selected)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, packageData := range allPackages {
-		for filePath, node := range packageData.Files {
+	diagnostics := make([]string, 0)
+	suites := make(map[*types.TypeName]*generator.SuiteLocator)
+	for _, pkg := range loaded {
+		for _, node := range pkg.Syntax {
+			if !selected[pkg.Fset.Position(node.Pos()).Filename] {
+				continue
+			}
 			for _, dec := range node.Decls {
 				decl, ok := dec.(*ast.FuncDecl)
-				if ok {
-					importPathOfFuncDecl, err := getImportPathOfFuncDecl(filePath)
-					if err != nil {
-						return nil, err
+				if !ok {
+					continue
+				}
+				function, ok := pkg.TypesInfo.Defs[decl.Name].(*types.Func)
+				if !ok {
+					continue
+				}
+
+				step, isStepFunction := IsStepFunction(decl)
+				if isConfigType(function.Type().(*types.Signature)) {
+					output.ConfigFunction = &generator.FunctionLocator{
+						FullPackageName: pkg.PkgPath,
+						FunctionName:    decl.Name.Name,
 					}
+					output.HookWrites = stepDoc(decl, function.Type().(*types.Signature)).Writes
+				} else if isStepFunction {
+					if err := validateStepSignature(*step, function.Type().(*types.Signature)); err != nil {
+						diagnostics = append(diagnostics, fmt.Sprintf("%s: %s.%s `%s`: %s",
+							pkg.Fset.Position(decl.Pos()), pkg.PkgPath, decl.Name.Name, *step, err))
 
-					step, isStepFunction := IsStepFunction(decl)
-					if IsConfigFunction(decl, node.Imports) {
-						output.ConfigFunction = &generator.FunctionLocator{
-							FullPackageName: importPathOfFuncDecl,
-							FunctionName:    decl.Name.Name,
+						continue
+					}
+					locator := &generator.FunctionLocator{
+						FullPackageName: pkg.PkgPath,
+						FunctionName:    decl.Name.Name,
+					}
+					if receiver := function.Type().(*types.Signature).Recv(); receiver != nil {
+						suite, err := suiteOf(pkg.Types, receiver, suites)
+						if err != nil {
+							diagnostics = append(diagnostics, fmt.Sprintf("%s: %s.%s `%s`: %s",
+								pkg.Fset.Position(decl.Pos()), pkg.PkgPath, decl.Name.Name, *step, err))
+
+							continue
 						}
-					} else if isStepFunction {
-						output.StepFunctions = append(output.StepFunctions, &generator.StepFunctionLocator{
-							StepName: *step,
-							FunctionLocator: &generator.FunctionLocator{
-								FullPackageName: importPathOfFuncDecl,
-								FunctionName:    decl.Name.Name,
-							},
-						})
+						locator.Receiver = suite
 					}
+					output.StepFunctions = append(output.StepFunctions, &generator.StepFunctionLocator{
+						StepName:        *step,
+						FunctionLocator: locator,
+						Doc:             stepDoc(decl, function.Type().(*types.Signature)),
+					})
 				}
 			}
 		}
-
+	}
+	if len(diagnostics) > 0 {
+		return nil, fmt.Errorf("step functions do not match their steps:\n%s", strings.Join(diagnostics, "\n"))
 	}
 
 	return output, nil
 }
 
-func IsConfigFunction(fnDecl *ast.FuncDecl, imports []*ast.ImportSpec) bool {
-	if fnDecl.Type.Results == nil {
-		return false
+// suiteOf returns the suite of the method receiver, sharing one suite between the methods of the same type. The
+// suite is created with the New<Type> function of the package if it takes no parameters and returns the type.
+func suiteOf(pkg *types.Package, receiver *types.Var, suites map[*types.TypeName]*generator.SuiteLocator) (
+	*generator.SuiteLocator, error) {
+	receiverType := receiver.Type()
+	if pointer, ok := receiverType.(*types.Pointer); ok {
+		receiverType = pointer.Elem()
+	}
+	named, ok := receiverType.(*types.Named)
+	if !ok || named.TypeParams().Len() > 0 {
+		return nil, fmt.Errorf("methods of generic types can not be steps")
+	}
+	if !named.Obj().Exported() {
+		return nil, fmt.Errorf("suite type %s is not exported, so the generated file can not create it",
+			named.Obj().Name())
+	}
+	if suite, ok := suites[named.Obj()]; ok {
+		return suite, nil
+	}
+
+	suite := &generator.SuiteLocator{
+		FullPackageName: pkg.Path(),
+		TypeName:        named.Obj().Name(),
+	}
+	if constructor, ok := pkg.Scope().Lookup("New" + suite.TypeName).(*types.Func); ok {
+		signature := constructor.Type().(*types.Signature)
+		if signature.Params().Len() == 0 && signature.Results().Len() == 1 {
+			result := signature.Results().At(0).Type()
+			if pointer, ok := result.(*types.Pointer); ok {
+				result = pointer.Elem()
+			}
+			if types.Identical(result, named) {
+				suite.Constructor = constructor.Name()
+			}
+		}
+	}
+	suites[named.Obj()] = suite
+
+	return suite, nil
+}
+
+// loadPackages loads the packages in the directory and its sub directories with their syntax and types sorted
+// by their import path. The generated file of the options is loaded without its declarations, and only the errors
+// of the packages with selected files fail the loading, so that packages which do not compile, such as the one of
+// a generated file calling removed step functions, do not prevent generating it again.
+func loadPackages(ctx context.Context, directory string, options generator.SourceOptions,
+	selected map[string]bool) ([]*packages.Package, error) {
+	config := &packages.Config{
+		Context: ctx,
+		Dir:     directory,
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes |
+			packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+	}
+	if len(options.BuildTags) > 0 {
+		config.BuildFlags = []string{"-tags=" + strings.Join(options.BuildTags, ",")}
+	}
+	if len(options.Output) > 0 {
+		overlay, err := withoutDeclarations(options.Output)
+		if err != nil {
+			return nil, err
+		}
+		config.Overlay = overlay
 	}
-	returnedTypes := fnDecl.Type.Results.List
-	if len(returnedTypes) != 1 {
+
+	loaded, err := packages.Load(config, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("could not load packages in %s, error=%w", directory, err)
+	}
+
+	problems := make([]string, 0)
+	for _, pkg := range loaded {
+		if !slices.ContainsFunc(pkg.GoFiles, func(file string) bool { return selected[file] }) {
+			continue
+		}
+		for _, packageError := range pkg.Errors {
+			problems = append(problems, packageError.Error())
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("could not load packages in %s:\n%s", directory, strings.Join(problems, "\n"))
+	}
+
+	sort.Slice(loaded, func(i, j int) bool {
+		return loaded[i].PkgPath < loaded[j].PkgPath
+	})
+
+	return loaded, nil
+}
+
+// withoutDeclarations returns the overlay replacing the go file with its package clause. It is empty if the file
+// does not exist or has no valid package clause.
+func withoutDeclarations(path string) (map[string][]byte, error) {
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), absolute, nil, parser.PackageClauseOnly)
+	if err != nil {
+		return map[string][]byte{}, nil
+	}
+
+	return map[string][]byte{absolute: []byte("package " + file.Name.Name + "\n")}, nil
+}
+
+// isConfigType reports whether the function returns only a *models.Config
+func isConfigType(signature *types.Signature) bool {
+	if signature.Recv() != nil || signature.Results().Len() != 1 {
 		return false
 	}
-	fmt.Printf("Analyzing function %s:\n", fnDecl.Name.Name)
-	e := returnedTypes[0].Type
-	path := analyzeExpr(e, imports)
-	return strings.HasSuffix(path, "Config")
+
+	return types.TypeString(signature.Results().At(0).Type(), nil) == "*"+configType
 }
 
 func IsStepFunction(decl *ast.FuncDecl) (*string, bool) {
@@ -106,7 +263,8 @@ func GetCommentLineStartingWith(keyword string, fnDecl *ast.FuncDecl) *string {
 		for _, comment := range fnDecl.Doc.List {
 			text := comment.Text
 			prefix := fmt.Sprintf("// %s", keyword)
-			if strings.HasPrefix(text, prefix) {
+			// annotations such as @cacik-reads start with the keyword too
+			if strings.HasPrefix(text, prefix+SpaceAndTick) {
 				// include empty space and `
 				startIndex := len(prefix) + len(SpaceAndTick)
 				if len(text)-startIndex > 2 {
@@ -114,82 +272,55 @@ func GetCommentLineStartingWith(keyword string, fnDecl *ast.FuncDecl) *string {
 					return &stepDefinition
 				}
 			}
+			if strings.HasPrefix(text, prefix+SpaceAndQuote) {
+				if stepDefinition, err := strconv.Unquote(text[len(prefix)+1:]); err == nil && len(stepDefinition) > 0 {
+					return &stepDefinition
+				}
+			}
 		}
 	}
 	return nil
 }
 
-func analyzeExpr(expr ast.Expr, imports []*ast.ImportSpec) string {
-	switch expr := expr.(type) {
-	case *ast.Ident:
-		return expr.Name
-	case *ast.SelectorExpr:
-		return fmt.Sprintf("%s.%s", analyzeExpr(expr.X, imports), expr.Sel.Name)
-	case *ast.StarExpr:
-		return "*" + analyzeExpr(expr.X, imports)
-	case *ast.ParenExpr:
-		return "(" + analyzeExpr(expr.X, imports) + ")"
-	case *ast.ArrayType:
-		return "[]" + analyzeExpr(expr.Elt, imports)
-	case *ast.MapType:
-		return "map[" + analyzeExpr(expr.Key, imports) + "]" + analyzeExpr(expr.Value, imports)
-	case *ast.StructType:
-		return "struct{}"
-	case *ast.InterfaceType:
-		return "interface{}"
-	case *ast.FuncType:
-		return "func()"
-	case *ast.ChanType:
-		dir := "chan "
-		if expr.Dir == ast.RECV {
-			dir = "<-chan "
-		} else if expr.Dir == ast.SEND {
-			dir = "chan<- "
+// stepDoc returns the contract of the step function from its comment and its signature. The keys of the scenario
+// data are listed after the annotations separated by commas or spaces, such as // @cacik-reads basket, user.
+func stepDoc(decl *ast.FuncDecl, signature *types.Signature) *generator.StepDoc {
+	doc := &generator.StepDoc{
+		Parameters: make([]*generator.ParameterDoc, 0, signature.Params().Len()),
+	}
+	description := make([]string, 0)
+	if decl.Doc != nil {
+		for _, comment := range decl.Doc.List {
+			text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+			switch {
+			case strings.HasPrefix(text, ReadsPrefix+" "):
+				doc.Reads = append(doc.Reads, annotationKeys(text, ReadsPrefix)...)
+			case strings.HasPrefix(text, WritesPrefix+" "):
+				doc.Writes = append(doc.Writes, annotationKeys(text, WritesPrefix)...)
+			case !strings.HasPrefix(text, StepPrefix):
+				description = append(description, text)
+			}
 		}
-		return dir + analyzeExpr(expr.Value, imports)
-	case *ast.CompositeLit:
-		return analyzeExpr(expr.Type, imports)
-	default:
-		return "unknown"
 	}
-}
+	doc.Description = strings.TrimSpace(strings.Join(description, "\n"))
 
-func getImportPathOfFuncDecl(filename string) (string, error) {
-	// RunWithTags "go list" command to get module path.
-	cmd := exec.Command("go", "list")
-	cmd.Dir = filepath.Dir(filename)
-	modulePathBytes, err := cmd.Output()
-	if err != nil {
-		return "", err
+	qualifier := func(pkg *types.Package) string {
+		return pkg.Name()
 	}
-	return strings.TrimSpace(string(modulePathBytes)), nil // FuncDecl not found in the file.
-}
-
-func getAllSubDirectories(dirPath string) []string {
-	var subdirectories []string
-
-	// Walk the directory.
-	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			fmt.Println(err)
-			return err
-		}
-		// Check if it's a directory (excluding the root directory).
-		if info.IsDir() && path != dirPath {
-			subdirectories = append(subdirectories, path)
-		}
-		return nil
-	})
-
-	if err != nil {
-		fmt.Println(err)
+	for i := 0; i < signature.Params().Len(); i++ {
+		parameter := signature.Params().At(i)
+		doc.Parameters = append(doc.Parameters, &generator.ParameterDoc{
+			Name: parameter.Name(),
+			Type: types.TypeString(parameter.Type(), qualifier),
+		})
 	}
 
-	return subdirectories
+	return doc
 }
 
-func mergePackages(m1 map[string]*ast.Package, m2 map[string]*ast.Package) {
-	for k, v := range m2 {
-		m1[k] = v
-	}
+// annotationKeys returns the keys listed in the annotation comment
+func annotationKeys(text, prefix string) []string {
+	return strings.FieldsFunc(strings.TrimPrefix(text, prefix), func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
 }
@@ -2,6 +2,9 @@ package comment_parser
 
 import (
 	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
 	"testing"
@@ -13,25 +16,28 @@ import (
 var (
 	expectedOutput = &generator.Output{
 		ConfigFunction: &generator.FunctionLocator{
-			FullPackageName: "github.com/denizgursoy/cacik/internal/parser/testdata",
+			FullPackageName: "github.com/denizgursoy/cacik/internal/comment_parser/testdata",
 			FunctionName:    "Method1",
 		},
 		StepFunctions: []*generator.StepFunctionLocator{
 			{
 				StepName: "^step 1$",
 				FunctionLocator: &generator.FunctionLocator{
-					FullPackageName: "github.com/denizgursoy/cacik/internal/parser/testdata/step-one",
+					FullPackageName: "github.com/denizgursoy/cacik/internal/comment_parser/testdata/step-one",
 					FunctionName:    "Step1",
 				},
+				Doc: &generator.StepDoc{Description: "Step1", Parameters: []*generator.ParameterDoc{}},
 			},
 			{
 				StepName: "^step 2$",
 				FunctionLocator: &generator.FunctionLocator{
-					FullPackageName: "github.com/denizgursoy/cacik/internal/parser/testdata/step-two",
+					FullPackageName: "github.com/denizgursoy/cacik/internal/comment_parser/testdata/step-two",
 					FunctionName:    "Step2",
 				},
+				Doc: &generator.StepDoc{Description: "Step2", Parameters: []*generator.ParameterDoc{}},
 			},
 		},
+		HookWrites: []string{"user"},
 	}
 )
 
@@ -47,3 +53,208 @@ func TestGetComments(t *testing.T) {
 		require.Equal(t, expectedOutput, recursively)
 	})
 }
+
+func TestGetCommentLineStartingWith(t *testing.T) {
+	definition := func(t *testing.T, comment string) *string {
+		file, err := parser.ParseFile(token.NewFileSet(), "steps.go", "package steps\n\n"+comment+
+			"\nfunc Step() {}\n", parser.ParseComments)
+		require.Nil(t, err)
+
+		return GetCommentLineStartingWith(StepPrefix, file.Decls[0].(*ast.FuncDecl))
+	}
+
+	t.Run("should read a definition between backticks", func(t *testing.T) {
+		require.Equal(t, `^I have (\d+) apples$`, *definition(t, "// @cacik `^I have (\\d+) apples$`"))
+	})
+	t.Run("should read a definition written as a double-quoted string", func(t *testing.T) {
+		require.Equal(t, "^I run `go test`$", *definition(t, `// @cacik "^I run `+"`go test`"+`$"`))
+	})
+	t.Run("should not read other annotations", func(t *testing.T) {
+		require.Nil(t, definition(t, "// @cacik-reads basket"))
+		require.Nil(t, definition(t, `// @cacik "unterminated`))
+	})
+}
+
+func TestGoSourceFileParser_SetSourceOptions(t *testing.T) {
+	stepNames := func(t *testing.T, options generator.SourceOptions) []string {
+		dir, err := os.Getwd()
+		require.Nil(t, err)
+
+		parser := NewGoSourceFileParser()
+		parser.SetSourceOptions(options)
+		output, err := parser.ParseFunctionCommentsOfGoFilesInDirectoryRecursively(context.Background(), filepath.Join(dir, "testdata"))
+		require.Nil(t, err)
+
+		names := make([]string, 0)
+		for _, function := range output.StepFunctions {
+			names = append(names, function.StepName)
+		}
+
+		return names
+	}
+
+	t.Run("should skip vendor, hidden directories and files excluded by build constraints", func(t *testing.T) {
+		require.Equal(t, []string{"^step 1$", "^step 2$"}, stepNames(t, generator.SourceOptions{}))
+	})
+	t.Run("should parse files matching the build tags", func(t *testing.T) {
+		require.Equal(t, []string{"^step 1$", "^integration$", "^step 2$"},
+			stepNames(t, generator.SourceOptions{BuildTags: []string{"integration"}}))
+	})
+	t.Run("should parse only included files which are not excluded", func(t *testing.T) {
+		require.Equal(t, []string{"^step 1$"}, stepNames(t, generator.SourceOptions{Include: []string{"**/test.go"},
+			Exclude: []string{"step-two"}}))
+	})
+	t.Run("should fail if step functions do not match the arguments of their steps", func(t *testing.T) {
+		dir, err := os.Getwd()
+		require.Nil(t, err)
+
+		parser := NewGoSourceFileParser()
+		parser.SetSourceOptions(generator.SourceOptions{BuildTags: []string{"signatures"}})
+		_, err = parser.ParseFunctionCommentsOfGoFilesInDirectoryRecursively(context.Background(), filepath.Join(dir, "testdata"))
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "step-signatures/signatures.go:25:1: github.com/denizgursoy/cacik/internal/"+
+			"comment_parser/testdata/step-signatures.Pears `^I have {int} pears$`: step captures 1 arguments but "+
+			"the function has 0 parameters for them")
+		require.Contains(t, err.Error(), "Price `^the price is {float}$`: argument 1 captured by {float} can not be "+
+			"converted to int")
+		require.Contains(t, err.Error(), "Delivery `^the delivery takes {int}$`: argument 1 captured by {int} can "+
+			"not be converted to time.Duration")
+		require.Contains(t, err.Error(), "Release `^the release is {int}$`: argument 1 captured by {int} can not "+
+			"be converted to github.com/denizgursoy/cacik/pkg/converter.Version")
+		require.Contains(t, err.Error(), "Tax `^the tax is {percent}$`: argument 1 captured by {percent} can not be "+
+			"converted to int")
+		require.NotContains(t, err.Error(), "Apples")
+		require.NotContains(t, err.Error(), "Order")
+		require.NotContains(t, err.Error(), "Requests")
+		require.NotContains(t, err.Error(), "Pay")
+		require.NotContains(t, err.Error(), "EnableFeatures")
+		require.NotContains(t, err.Error(), "Server")
+		require.NotContains(t, err.Error(), "Submit")
+		require.NotContains(t, err.Error(), "Discount")
+		require.NotContains(t, err.Error(), "Move")
+		require.NotContains(t, err.Error(), "OpenBasket")
+		require.NotContains(t, err.Error(), "Note")
+		require.NotContains(t, err.Error(), "Mail")
+		require.Contains(t, err.Error(), "CountBasket `^I count the basket$`: step function can only return "+
+			"context.Context and error, got int")
+	})
+}
+
+func TestGoSourceFileParser_Suites(t *testing.T) {
+	t.Run("should find methods of suites with their constructors", func(t *testing.T) {
+		dir, err := os.Getwd()
+		require.Nil(t, err)
+
+		parser := NewGoSourceFileParser()
+		parser.SetSourceOptions(generator.SourceOptions{Include: []string{"step-suite/*"}, BuildTags: []string{"suite"}})
+		output, err := parser.ParseFunctionCommentsOfGoFilesInDirectoryRecursively(context.Background(), filepath.Join(dir, "testdata"))
+
+		require.Nil(t, err)
+		packageName := "github.com/denizgursoy/cacik/internal/comment_parser/testdata/step-suite"
+		require.Equal(t, []*generator.StepFunctionLocator{
+			{
+				StepName: "^I add {int} items$",
+				FunctionLocator: &generator.FunctionLocator{
+					FullPackageName: packageName,
+					FunctionName:    "IAddItems",
+					Receiver: &generator.SuiteLocator{
+						FullPackageName: packageName,
+						TypeName:        "CheckoutSuite",
+						Constructor:     "NewCheckoutSuite",
+					},
+				},
+				Doc: &generator.StepDoc{
+					Description: "IAddItems adds items to the basket",
+					Parameters: []*generator.ParameterDoc{
+						{Name: "ctx", Type: "context.Context"},
+						{Name: "count", Type: "int"},
+					},
+					Reads:  []string{"basket"},
+					Writes: []string{"basket", "total"},
+				},
+			},
+			{
+				StepName: "^the cart is empty$",
+				FunctionLocator: &generator.FunctionLocator{
+					FullPackageName: packageName,
+					FunctionName:    "CartIsEmpty",
+					Receiver: &generator.SuiteLocator{
+						FullPackageName: packageName,
+						TypeName:        "CartSuite",
+					},
+				},
+				Doc: &generator.StepDoc{Parameters: []*generator.ParameterDoc{}},
+			},
+		}, output.StepFunctions)
+	})
+}
+
+func TestGoSourceFileParser_UnexportedSuites(t *testing.T) {
+	t.Run("should return error for methods of unexported types", func(t *testing.T) {
+		dir, err := os.Getwd()
+		require.Nil(t, err)
+
+		parser := NewGoSourceFileParser()
+		parser.SetSourceOptions(generator.SourceOptions{Include: []string{"step-suite/*"}, BuildTags: []string{"unexported"}})
+		_, err = parser.ParseFunctionCommentsOfGoFilesInDirectoryRecursively(context.Background(), filepath.Join(dir, "testdata"))
+
+		require.ErrorContains(t, err, "BasketIsEmpty `^the basket is empty$`: suite type basketSuite is not exported, "+
+			"so the generated file can not create it")
+	})
+}
+
+func TestLoadPackages(t *testing.T) {
+	// module writes the files of a module in a temporary directory and returns the directory
+	module := func(t *testing.T, files map[string]string) string {
+		directory := t.TempDir()
+		files["go.mod"] = "module example.com/shop\n\ngo 1.22\n"
+		for name, content := range files {
+			path := filepath.Join(directory, name)
+			require.Nil(t, os.MkdirAll(filepath.Dir(path), 0o755))
+			require.Nil(t, os.WriteFile(path, []byte(content), 0o644))
+		}
+
+		return directory
+	}
+	steps := "package steps\n\n// Pay\n// @cacik `^I pay$`\nfunc Pay() {}\n"
+
+	t.Run("should load the generated file without its declarations", func(t *testing.T) {
+		directory := module(t, map[string]string{
+			"steps/steps.go": steps,
+			"main.go":        "package main\n\nimport \"example.com/shop/steps\"\n\nfunc main() { steps.Removed() }\n",
+		})
+
+		parser := NewGoSourceFileParser()
+		parser.SetSourceOptions(generator.SourceOptions{Output: filepath.Join(directory, "main.go")})
+		output, err := parser.ParseFunctionCommentsOfGoFilesInDirectoryRecursively(context.Background(), directory)
+
+		require.Nil(t, err)
+		require.Len(t, output.StepFunctions, 1)
+		require.Equal(t, "^I pay$", output.StepFunctions[0].StepName)
+	})
+	t.Run("should ignore the errors of packages without selected files", func(t *testing.T) {
+		directory := module(t, map[string]string{
+			"steps/steps.go":   steps,
+			"broken/broken.go": "package broken\n\nvar count int = \"one\"\n",
+		})
+
+		parser := NewGoSourceFileParser()
+		parser.SetSourceOptions(generator.SourceOptions{Include: []string{"steps/*"}})
+		output, err := parser.ParseFunctionCommentsOfGoFilesInDirectoryRecursively(context.Background(), directory)
+
+		require.Nil(t, err)
+		require.Len(t, output.StepFunctions, 1)
+	})
+	t.Run("should return the errors of the packages with selected files", func(t *testing.T) {
+		directory := module(t, map[string]string{
+			"steps/steps.go": steps + "\nvar count int = \"one\"\n",
+		})
+
+		_, err := NewGoSourceFileParser().ParseFunctionCommentsOfGoFilesInDirectoryRecursively(context.Background(),
+			directory)
+
+		require.ErrorContains(t, err, "could not load packages in "+directory)
+		require.ErrorContains(t, err, "steps.go:7:17")
+	})
+}
@@ -0,0 +1,158 @@
+package comment_parser
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/denizgursoy/cacik/pkg/pattern"
+)
+
+const (
+	contextTypeName  = "context.Context"
+	durationTypeName = "time.Duration"
+	timeTypeName     = "time.Time"
+	tableTypeName    = "github.com/denizgursoy/cacik/pkg/models.Table"
+	argsTypeName     = "github.com/denizgursoy/cacik/pkg/models.Args"
+	uuidTypeName     = "github.com/google/uuid.UUID"
+	ipTypeName       = "net.IP"
+	versionTypeName  = "github.com/denizgursoy/cacik/pkg/converter.Version"
+)
+
+// valueTypes are the types converted by the parameter type with the name, which can also be captured as text
+var valueTypes = map[string]string{
+	timeTypeName:     "date",
+	durationTypeName: "duration",
+	uuidTypeName:     "uuid",
+	ipTypeName:       "ip",
+	versionTypeName:  "semver",
+}
+
+// tableTypeNames are the types a data table can be passed as after the captured arguments
+var tableTypeNames = map[string]bool{
+	tableTypeName:         true,
+	"map[string]string":   true,
+	"[]map[string]string": true,
+}
+
+// validateStepSignature checks that the step function can be called with the arguments captured by the step
+// definition the same way the executor calls it: an optional context.Context, a parameter for every capture
+// group and an optional data table or doc string parameter. Parameters of other types are left to providers.
+func validateStepSignature(definition string, signature *types.Signature) error {
+	// parameter types registered at runtime are not known here, they are matched as any text
+	custom := pattern.AnyTextTypes(definition)
+	captures, err := pattern.CapturesWithTypes(definition, custom)
+	if err != nil {
+		return fmt.Errorf("step is not a valid regular expression, error=%w", err)
+	}
+	unmarshalled := false
+	for _, capture := range captures {
+		unmarshalled = unmarshalled || (capture.Type == "json" && !capture.Regexp)
+	}
+	if len(custom) > 0 || unmarshalled {
+		// the parameters converted by custom parameter types and unmarshalled from JSON can not be told apart
+		// from provided parameters
+		return nil
+	}
+	for i := 0; i < signature.Results().Len(); i++ {
+		if result := typeName(signature.Results().At(i).Type()); result != contextTypeName && result != "error" {
+			return fmt.Errorf("step function can only return context.Context and error, got %s", result)
+		}
+	}
+	for i := 0; i < signature.Params().Len(); i++ {
+		if typeName(signature.Params().At(i).Type()) == argsTypeName {
+			// the arguments are passed by their names and converted when they are read
+			return nil
+		}
+	}
+	if signature.Variadic() {
+		return fmt.Errorf("step function can not be variadic")
+	}
+
+	// parameters of other types are passed the values of providers registered at runtime
+	parameters := make([]types.Type, 0, signature.Params().Len())
+	for i := 0; i < signature.Params().Len(); i++ {
+		parameter := signature.Params().At(i).Type()
+		if isArgumentType(parameter) || tableTypeNames[typeName(parameter)] ||
+			(i == 0 && typeName(parameter) == contextTypeName) {
+			parameters = append(parameters, parameter)
+		}
+	}
+	if len(parameters) > 0 && typeName(parameters[0]) == contextTypeName {
+		parameters = parameters[1:]
+	}
+	if len(parameters) == len(captures)+1 {
+		// the last parameter is passed the data table or the doc string
+		parameters = parameters[:len(parameters)-1]
+	}
+	if len(parameters) != len(captures) {
+		return fmt.Errorf("step captures %d arguments but the function has %d parameters for them",
+			len(captures), len(parameters))
+	}
+
+	for i, capture := range captures {
+		if !accepts(capture, parameters[i]) {
+			return fmt.Errorf("argument %d captured by %s can not be converted to %s", i+1, captureName(capture),
+				typeName(parameters[i]))
+		}
+	}
+
+	return nil
+}
+
+// accepts reports whether the value of the capture group can be converted to the parameter type
+func accepts(capture pattern.Capture, parameter types.Type) bool {
+	name := typeName(parameter)
+	if slice, ok := parameter.(*types.Slice); ok {
+		// lists are split from the captured text, the items are checked when they are converted
+		return isArgumentType(slice.Elem()) && (capture.Regexp || capture.Type == "" || capture.Type == "word" ||
+			capture.Type == "string")
+	}
+	if parameterType, ok := valueTypes[name]; ok {
+		return capture.Regexp || capture.Type == parameterType || capture.Type == "" || capture.Type == "word" ||
+			capture.Type == "string"
+	}
+	basic, isBasic := parameter.Underlying().(*types.Basic)
+	if !isBasic || basic.Kind() == types.Uintptr {
+		return false
+	}
+	if basic.Info()&types.IsString != 0 {
+		return true
+	}
+
+	switch capture.Type {
+	case "int", "ordinal":
+		return basic.Info()&(types.IsInteger|types.IsFloat) != 0
+	case "float", "number", "percent":
+		return basic.Info()&types.IsFloat != 0
+	case "duration", "date", "uuid", "ip", "semver", "json":
+		return false
+	}
+
+	return basic.Info()&(types.IsInteger|types.IsFloat|types.IsBoolean) != 0
+}
+
+// isArgumentType reports whether captured arguments can be converted to the type
+func isArgumentType(parameter types.Type) bool {
+	if _, ok := valueTypes[typeName(parameter)]; ok {
+		return true
+	}
+	if slice, ok := parameter.(*types.Slice); ok {
+		return isArgumentType(slice.Elem())
+	}
+	basic, ok := parameter.Underlying().(*types.Basic)
+
+	return ok && basic.Info()&(types.IsString|types.IsInteger|types.IsFloat|types.IsBoolean) != 0 &&
+		basic.Kind() != types.Uintptr
+}
+
+func captureName(capture pattern.Capture) string {
+	if capture.Regexp {
+		return "a regular expression group"
+	}
+
+	return "{" + capture.Type + "}"
+}
+
+func typeName(t types.Type) string {
+	return types.TypeString(t, nil)
+}
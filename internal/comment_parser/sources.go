@@ -0,0 +1,125 @@
+package comment_parser
+
+import (
+	"go/build"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/denizgursoy/cacik/internal/generator"
+)
+
+var (
+	// skippedDirectories are never searched for step functions
+	skippedDirectories = []string{"vendor", "testdata"}
+)
+
+// sourceFiles returns the go files in the directory and its sub directories matching the options. Directories
+// named vendor or testdata, hidden directories, test files and files excluded by their build constraints, such
+// as //go:build ignore, are skipped.
+func sourceFiles(directory string, options generator.SourceOptions) ([]string, error) {
+	include, err := compileGlobs(options.Include)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := compileGlobs(options.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	context := build.Default
+	context.BuildTags = options.BuildTags
+
+	files := make([]string, 0)
+	err = filepath.WalkDir(directory, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relative, err := filepath.Rel(directory, path)
+		if err != nil {
+			return err
+		}
+		relative = filepath.ToSlash(relative)
+
+		if entry.IsDir() {
+			if path != directory && (isSkippedDirectory(entry.Name()) || matchesAny(exclude, relative)) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			return nil
+		}
+		if (len(include) > 0 && !matchesAny(include, relative)) || matchesAny(exclude, relative) {
+			return nil
+		}
+		if match, err := context.MatchFile(filepath.Dir(path), entry.Name()); err != nil || !match {
+			return err
+		}
+		files = append(files, path)
+
+		return nil
+	})
+
+	return files, err
+}
+
+func isSkippedDirectory(name string) bool {
+	if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+		return true
+	}
+	for _, skipped := range skippedDirectories {
+		if name == skipped {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compileGlobs converts the glob patterns to regular expressions. * matches within a path element and **
+// matches any number of path elements.
+func compileGlobs(globs []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(globs))
+	for _, glob := range globs {
+		var builder strings.Builder
+		builder.WriteString("^")
+		for i := 0; i < len(glob); i++ {
+			switch {
+			case strings.HasPrefix(glob[i:], "**/"):
+				builder.WriteString("(?:.*/)?")
+				i += 2
+			case strings.HasPrefix(glob[i:], "**"):
+				builder.WriteString(".*")
+				i++
+			case glob[i] == '*':
+				builder.WriteString("[^/]*")
+			case glob[i] == '?':
+				builder.WriteString("[^/]")
+			default:
+				builder.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+		}
+		builder.WriteString("$")
+
+		expression, err := regexp.Compile(builder.String())
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, expression)
+	}
+
+	return compiled, nil
+}
+
+func matchesAny(globs []*regexp.Regexp, path string) bool {
+	for _, glob := range globs {
+		if glob.MatchString(path) {
+			return true
+		}
+	}
+
+	return false
+}
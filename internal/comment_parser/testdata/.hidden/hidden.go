@@ -0,0 +1,5 @@
+package hidden
+
+// @cacik `^hidden$`
+func Hidden() {
+}
@@ -0,0 +1,88 @@
+//go:build signatures
+
+package step_signatures
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/converter"
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+// @cacik `^I have {int} apples named {string}$`
+func Apples(ctx context.Context, count int, name string) error {
+	return nil
+}
+
+// @cacik `^the order is due in {duration} on {date}$`
+func Order(due time.Duration, on time.Time, items models.Table) {
+}
+
+// @cacik `^I have {int} pears$`
+func Pears() {
+}
+
+// @cacik `^the price is {float}$`
+func Price(price int) {
+}
+
+// @cacik `^the delivery takes {int}$`
+func Delivery(delivery time.Duration) {
+}
+
+// @cacik `^the client sends {int} requests$`
+func Requests(client *http.Client, count int) {
+}
+
+// @cacik `^I pay {money}$`
+func Pay(amount string, client *http.Client) {
+}
+
+// @cacik `^I enable features (.+)$`
+func EnableFeatures(features []string) {
+}
+
+// @cacik `^the server {ip} runs {semver}$`
+func Server(address net.IP, version converter.Version) {
+}
+
+// @cacik `^the release is {int}$`
+func Release(version converter.Version) {
+}
+
+// @cacik `^the order is {json}$`
+func Submit(order map[string]any) {
+}
+
+// @cacik `^the {ordinal} item costs {number} with {percent} off$`
+func Discount(item int, price float64, discount float64) {
+}
+
+// @cacik `^the tax is {percent}$`
+func Tax(tax int) {
+}
+
+// @cacik `^I move (?P<count>\d+) boxes to (?P<room>\w+)$`
+func Move(ctx context.Context, args models.Args) {
+}
+
+// @cacik `^the note says$`
+func Note(note string) {
+}
+
+// @cacik `^I send a mail to {string} saying$`
+func Mail(ctx context.Context, to string, body string) {
+}
+
+// @cacik `^I open the basket$`
+func OpenBasket(ctx context.Context) (context.Context, error) {
+	return ctx, nil
+}
+
+// @cacik `^I count the basket$`
+func CountBasket() int {
+	return 0
+}
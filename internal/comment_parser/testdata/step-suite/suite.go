@@ -0,0 +1,30 @@
+//go:build suite
+
+package step_suite
+
+import "context"
+
+type (
+	CheckoutSuite struct {
+		items int
+	}
+
+	CartSuite struct{}
+)
+
+func NewCheckoutSuite() *CheckoutSuite {
+	return &CheckoutSuite{}
+}
+
+// IAddItems adds items to the basket
+//
+// @cacik-reads basket
+// @cacik `^I add {int} items$`
+// @cacik-writes basket, total
+func (s *CheckoutSuite) IAddItems(ctx context.Context, count int) {
+	s.items += count
+}
+
+// @cacik `^the cart is empty$`
+func (c CartSuite) CartIsEmpty() {
+}
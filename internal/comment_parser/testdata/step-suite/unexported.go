@@ -0,0 +1,8 @@
+//go:build unexported
+
+package step_suite
+
+type basketSuite struct{}
+
+// @cacik `^the basket is empty$`
+func (s *basketSuite) BasketIsEmpty() {}
@@ -0,0 +1,7 @@
+//go:build ignore
+
+package step_two
+
+// @cacik `^ignored$`
+func Ignored() {
+}
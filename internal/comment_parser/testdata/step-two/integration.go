@@ -0,0 +1,7 @@
+//go:build integration
+
+package step_two
+
+// @cacik `^integration$`
+func Integration() {
+}
@@ -3,6 +3,8 @@ package testdata
 import "github.com/denizgursoy/cacik/pkg/models"
 
 // Hello
+//
+// @cacik-writes user
 func Method1() *models.Config {
 	return &models.Config{}
 }
@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GenerateDocs writes the markdown documentation of the step functions with their descriptions, parameters and
+// the keys of the scenario data they read and write
+func (o *Output) GenerateDocs(writer io.Writer) error {
+	builder := &strings.Builder{}
+	builder.WriteString("# Steps\n")
+
+	for _, function := range o.StepFunctions {
+		name := function.FunctionName
+		if function.Receiver != nil {
+			name = fmt.Sprintf("(*%s).%s", function.Receiver.TypeName, function.FunctionName)
+		}
+		builder.WriteString(fmt.Sprintf("\n## `%s`\n\n`%s.%s`\n", function.StepName, function.FullPackageName, name))
+
+		doc := function.Doc
+		if doc == nil {
+			continue
+		}
+		if len(doc.Description) > 0 {
+			builder.WriteString(fmt.Sprintf("\n%s\n", doc.Description))
+		}
+		if len(doc.Parameters) > 0 {
+			builder.WriteString("\n| Parameter | Type |\n|---|---|\n")
+			for _, parameter := range doc.Parameters {
+				builder.WriteString(fmt.Sprintf("| %s | `%s` |\n", parameter.Name, parameter.Type))
+			}
+		}
+		if len(doc.Reads) > 0 {
+			builder.WriteString(fmt.Sprintf("\nReads: %s\n", codeList(doc.Reads)))
+		}
+		if len(doc.Writes) > 0 {
+			builder.WriteString(fmt.Sprintf("\nWrites: %s\n", codeList(doc.Writes)))
+		}
+	}
+
+	_, err := writer.Write([]byte(builder.String()))
+
+	return err
+}
+
+func codeList(values []string) string {
+	return "`" + strings.Join(values, "`, `") + "`"
+}
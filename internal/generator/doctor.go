@@ -0,0 +1,267 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/version"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/config_file"
+	"github.com/denizgursoy/cacik/pkg/pattern"
+)
+
+const (
+	DiagnosisPassed  = "ok"
+	DiagnosisWarning = "warn"
+	DiagnosisFailed  = "FAIL"
+
+	// doctorTimeZone is loaded to check whether the time zone database is available
+	doctorTimeZone = "America/New_York"
+)
+
+var (
+	// gherkinModules are the modules parsing feature files, whose versions must be the ones cacik is built with
+	gherkinModules = []string{"github.com/cucumber/gherkin/go/v26", "github.com/cucumber/messages/go/v21"}
+
+	goDirective = regexp.MustCompile(`(?m)^go\s+(\S+)`)
+)
+
+type (
+	// Diagnosis is the result of a check of the environment
+	Diagnosis struct {
+		Check   string
+		Status  string
+		Message string
+	}
+
+	// Doctor checks that the environment can generate and run the scenarios of the module in the working
+	// directory, so "it does not run on my machine" can be debugged with one command
+	Doctor struct {
+		codeParser GoCodeParser
+		// goCommand runs the go command with the arguments and returns its output
+		goCommand func(ctx context.Context, arguments ...string) (string, error)
+		// buildInfo returns the build information of the cacik binary
+		buildInfo func() (*debug.BuildInfo, bool)
+	}
+)
+
+func NewDoctor(codeParser GoCodeParser) *Doctor {
+	return &Doctor{
+		codeParser: codeParser,
+		goCommand:  runGoCommand,
+		buildInfo:  debug.ReadBuildInfo,
+	}
+}
+
+func (d *Diagnosis) String() string {
+	return fmt.Sprintf("%-4s %s: %s", d.Status, d.Check, d.Message)
+}
+
+// Failed reports whether any diagnosis failed
+func Failed(diagnoses []*Diagnosis) bool {
+	for _, diagnosis := range diagnoses {
+		if diagnosis.Status == DiagnosisFailed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Diagnose checks the go version required by the module, the versions of the gherkin modules, the time zone
+// database, the feature directories and the step functions of the cacik.yaml of the module, and whether the
+// generated file is up to date
+func (d *Doctor) Diagnose(ctx context.Context) []*Diagnosis {
+	settings := config_file.Generator{}
+	features := []string{"."}
+	file, err := config_file.FindAndLoad()
+	if err != nil {
+		return []*Diagnosis{failed("config", err.Error())}
+	}
+	if file != nil {
+		settings = file.Generator
+		if len(file.Features) > 0 {
+			features = file.Features
+		}
+	}
+
+	diagnoses := []*Diagnosis{
+		d.checkGoVersion(ctx),
+		d.checkGherkinVersions(ctx),
+		checkTimeZoneData(settings),
+		checkFeatures(features),
+	}
+
+	sources := settings.Code
+	if len(sources) == 0 {
+		sources = []string{"."}
+	}
+	generated := settings.Output
+	if len(generated) == 0 {
+		generated = Options{Test: settings.Test}.defaultFileName()
+	}
+	if configurer, ok := d.codeParser.(SourceConfigurer); ok {
+		configurer.SetSourceOptions(SourceOptions{Include: settings.Include, Exclude: settings.Exclude,
+			BuildTags: settings.Tags, Output: generated})
+	}
+	output, err := parseSources(ctx, d.codeParser, sources)
+	if err != nil {
+		return append(diagnoses, failed("steps", err.Error()))
+	}
+
+	return append(diagnoses, checkPatterns(output), checkGenerated(output, settings))
+}
+
+// checkGoVersion checks that the installed go version is at least the version required by the go.mod
+func (d *Doctor) checkGoVersion(ctx context.Context) *Diagnosis {
+	installed, err := d.goCommand(ctx, "env", "GOVERSION")
+	if err != nil {
+		return failed("go", fmt.Sprintf("could not run go, error=%s", err))
+	}
+	goMod, err := d.goCommand(ctx, "env", "GOMOD")
+	if err != nil || len(goMod) == 0 || goMod == os.DevNull {
+		return failed("go", "the working directory is not in a go module, run go mod init")
+	}
+	content, err := os.ReadFile(goMod)
+	if err != nil {
+		return failed("go", fmt.Sprintf("could not read %s, error=%s", goMod, err))
+	}
+
+	match := goDirective.FindSubmatch(content)
+	if match == nil {
+		return passed("go", fmt.Sprintf("%s, %s has no go directive", installed, goMod))
+	}
+	required := "go" + string(match[1])
+	if version.Compare(installed, required) < 0 {
+		return failed("go", fmt.Sprintf("%s is older than %s required by %s", installed, required, goMod))
+	}
+
+	return passed("go", fmt.Sprintf("%s satisfies %s of %s", installed, required, goMod))
+}
+
+// checkGherkinVersions checks that the module uses the versions of the gherkin modules cacik is built with
+func (d *Doctor) checkGherkinVersions(ctx context.Context) *Diagnosis {
+	info, ok := d.buildInfo()
+	if !ok {
+		return warning("gherkin", "cacik is built without module information, versions are not compared")
+	}
+	built := make(map[string]string)
+	for _, dependency := range info.Deps {
+		built[dependency.Path] = dependency.Version
+	}
+
+	problems := make([]string, 0)
+	versions := make([]string, 0)
+	for _, module := range gherkinModules {
+		used, err := d.goCommand(ctx, "list", "-m", "-f", "{{.Version}}", module)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s is not required by the module, run go get %s", module, module))
+
+			continue
+		}
+		if expected, ok := built[module]; ok && expected != used {
+			problems = append(problems, fmt.Sprintf("module uses %s %s but cacik is built with %s", module, used,
+				expected))
+
+			continue
+		}
+		versions = append(versions, module+" "+used)
+	}
+	if len(problems) > 0 {
+		return warning("gherkin", strings.Join(problems, "; "))
+	}
+
+	return passed("gherkin", strings.Join(versions, ", "))
+}
+
+// checkTimeZoneData checks that time zones can be loaded, from the system or from the embedded database
+func checkTimeZoneData(settings config_file.Generator) *Diagnosis {
+	if settings.TimeZoneData {
+		return passed("tzdata", "the time zone database is embedded in the generated file")
+	}
+	if _, err := time.LoadLocation(doctorTimeZone); err != nil {
+		return warning("tzdata", fmt.Sprintf("time zones can not be loaded, set tzdata: true in the generator "+
+			"settings or generate with -tzdata, error=%s", err))
+	}
+
+	return passed("tzdata", "time zones are loaded from the system")
+}
+
+// checkFeatures checks that the feature directories and files exist
+func checkFeatures(features []string) *Diagnosis {
+	missing := make([]string, 0)
+	for _, feature := range features {
+		if _, err := os.Stat(feature); err != nil {
+			missing = append(missing, feature)
+		}
+	}
+	if len(missing) > 0 {
+		return failed("features", "not found: "+strings.Join(missing, ", "))
+	}
+
+	return passed("features", strings.Join(features, ", "))
+}
+
+// checkPatterns checks that the definition of every step function compiles. Parameter types registered at runtime
+// are compiled as any text.
+func checkPatterns(output *Output) *Diagnosis {
+	problems := make([]string, 0)
+	for _, function := range output.StepFunctions {
+		custom := pattern.AnyTextTypes(function.StepName)
+		if _, err := regexp.Compile(pattern.TransformWithTypes(function.StepName, custom)); err != nil {
+			problems = append(problems, fmt.Sprintf("%s of %s: %s", function.StepName, function.FunctionName, err))
+		}
+	}
+	if len(problems) > 0 {
+		return failed("patterns", strings.Join(problems, "; "))
+	}
+
+	return passed("patterns", fmt.Sprintf("%d step definitions compile", len(output.StepFunctions)))
+}
+
+// checkGenerated checks that the generated file is the one the generator would write for the step functions
+func checkGenerated(output *Output, settings config_file.Generator) *Diagnosis {
+	options := Options{Package: settings.Package, Test: settings.Test, TimeZoneData: settings.TimeZoneData}
+	path := settings.Output
+	if len(path) == 0 {
+		path = options.defaultFileName()
+	}
+
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return failed("generated", fmt.Sprintf("%s does not exist, run cacik", path))
+	}
+	expected := &bytes.Buffer{}
+	if err := output.GenerateWithOptions(expected, options); err != nil {
+		return failed("generated", fmt.Sprintf("could not generate %s, error=%s", path, err))
+	}
+	if !bytes.Equal(existing, expected.Bytes()) {
+		return failed("generated", fmt.Sprintf("%s is out of date, run cacik", path))
+	}
+
+	return passed("generated", path+" is up to date")
+}
+
+func runGoCommand(ctx context.Context, arguments ...string) (string, error) {
+	output, err := exec.CommandContext(ctx, "go", arguments...).Output()
+
+	return strings.TrimSpace(string(output)), err
+}
+
+func passed(check, message string) *Diagnosis {
+	return &Diagnosis{Check: check, Status: DiagnosisPassed, Message: message}
+}
+
+func warning(check, message string) *Diagnosis {
+	return &Diagnosis{Check: check, Status: DiagnosisWarning, Message: message}
+}
+
+func failed(check, message string) *Diagnosis {
+	return &Diagnosis{Check: check, Status: DiagnosisFailed, Message: message}
+}
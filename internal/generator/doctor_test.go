@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+
+	"github.com/denizgursoy/cacik/pkg/config_file"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGo returns a go command answering with the outputs by the joined arguments
+func fakeGo(outputs map[string]string) func(context.Context, ...string) (string, error) {
+	return func(_ context.Context, arguments ...string) (string, error) {
+		key := ""
+		for _, argument := range arguments {
+			key += argument + " "
+		}
+		output, ok := outputs[key]
+		if !ok {
+			return "", errors.New("exit status 1")
+		}
+
+		return output, nil
+	}
+}
+
+func TestDoctor_checkGoVersion(t *testing.T) {
+	goMod := filepath.Join(t.TempDir(), "go.mod")
+	require.Nil(t, os.WriteFile(goMod, []byte("module example.com/shop\n\ngo 1.22.0\n"), 0o644))
+
+	t.Run("should pass if the installed go version satisfies the go.mod", func(t *testing.T) {
+		doctor := &Doctor{goCommand: fakeGo(map[string]string{"env GOVERSION ": "go1.23.1", "env GOMOD ": goMod})}
+
+		diagnosis := doctor.checkGoVersion(context.Background())
+
+		require.Equal(t, DiagnosisPassed, diagnosis.Status)
+		require.Equal(t, "go1.23.1 satisfies go1.22.0 of "+goMod, diagnosis.Message)
+	})
+	t.Run("should fail if the installed go version is older", func(t *testing.T) {
+		doctor := &Doctor{goCommand: fakeGo(map[string]string{"env GOVERSION ": "go1.21.5", "env GOMOD ": goMod})}
+
+		diagnosis := doctor.checkGoVersion(context.Background())
+
+		require.Equal(t, "FAIL go: go1.21.5 is older than go1.22.0 required by "+goMod, diagnosis.String())
+	})
+}
+
+func TestDoctor_checkGherkinVersions(t *testing.T) {
+	t.Run("should warn about versions other than the ones cacik is built with", func(t *testing.T) {
+		doctor := &Doctor{
+			goCommand: fakeGo(map[string]string{
+				"list -m -f {{.Version}} github.com/cucumber/gherkin/go/v26 ":  "v26.2.0",
+				"list -m -f {{.Version}} github.com/cucumber/messages/go/v21 ": "v21.0.1",
+			}),
+			buildInfo: func() (*debug.BuildInfo, bool) {
+				return &debug.BuildInfo{Deps: []*debug.Module{
+					{Path: "github.com/cucumber/gherkin/go/v26", Version: "v26.2.0"},
+					{Path: "github.com/cucumber/messages/go/v21", Version: "v21.0.2"},
+				}}, true
+			},
+		}
+
+		diagnosis := doctor.checkGherkinVersions(context.Background())
+
+		require.Equal(t, DiagnosisWarning, diagnosis.Status)
+		require.Equal(t, "module uses github.com/cucumber/messages/go/v21 v21.0.1 but cacik is built with v21.0.2",
+			diagnosis.Message)
+	})
+}
+
+func TestDoctor_checkPatterns(t *testing.T) {
+	t.Run("should fail for definitions which do not compile", func(t *testing.T) {
+		output := &Output{StepFunctions: []*StepFunctionLocator{
+			{StepName: "^I pay {money}$", FunctionLocator: &FunctionLocator{FunctionName: "Pay"}},
+			{StepName: "^I have (\\d+ apples$", FunctionLocator: &FunctionLocator{FunctionName: "Apples"}},
+		}}
+
+		diagnosis := checkPatterns(output)
+
+		require.Equal(t, DiagnosisFailed, diagnosis.Status)
+		require.Equal(t, "^I have (\\d+ apples$ of Apples: error parsing regexp: missing closing ): "+
+			"`^I have (\\d+ apples$`", diagnosis.Message)
+	})
+}
+
+func TestDoctor_checkGenerated(t *testing.T) {
+	output := &Output{StepFunctions: []*StepFunctionLocator{{
+		StepName:        "^I pay$",
+		FunctionLocator: &FunctionLocator{FullPackageName: "example.com/shop", FunctionName: "Pay"},
+	}}}
+	path := filepath.Join(t.TempDir(), "main.go")
+	settings := config_file.Generator{Output: path}
+
+	t.Run("should fail if the generated file does not exist", func(t *testing.T) {
+		require.Equal(t, DiagnosisFailed, checkGenerated(output, settings).Status)
+	})
+	t.Run("should pass if the generated file is up to date", func(t *testing.T) {
+		content := &bytes.Buffer{}
+		require.Nil(t, output.GenerateWithOptions(content, Options{}))
+		require.Nil(t, os.WriteFile(path, content.Bytes(), 0o644))
+
+		require.Equal(t, DiagnosisPassed, checkGenerated(output, settings).Status)
+	})
+	t.Run("should fail if a step function was added after generating", func(t *testing.T) {
+		changed := &Output{StepFunctions: append(output.StepFunctions, &StepFunctionLocator{
+			StepName:        "^I refund$",
+			FunctionLocator: &FunctionLocator{FullPackageName: "example.com/shop", FunctionName: "Refund"},
+		})}
+
+		diagnosis := checkGenerated(changed, settings)
+
+		require.Equal(t, path+" is out of date, run cacik", diagnosis.Message)
+	})
+}
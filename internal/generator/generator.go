@@ -3,20 +3,74 @@ package generator
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"strings"
+
+	"github.com/denizgursoy/cacik/pkg/config_file"
+	"github.com/denizgursoy/cacik/pkg/linter"
 )
 
 const (
 	Separator = ","
 )
 
+// StartGenerator parses the step functions of the directories given with the -code flag, or the working
+// directory, and writes the file registering them. The -output, -package, -test and -tzdata flags configure
+// the file, and -docs writes the documentation of the steps to a markdown file too. The scenarios of the -features
+// directories are checked for steps reading data which no earlier step or hook writes. Flags default to the
+// generator settings and the features of the cacik.yaml in the root of the module.
 func StartGenerator(ctx context.Context, codeParser GoCodeParser) error {
 	funcSources := make([]string, 0)
 
-	codeFlag := flag.String("code", "", "directories to search for functions seperated by comma")
-	flag.Parse()
+	settings := config_file.Generator{}
+	features := make([]string, 0)
+	if file, err := config_file.FindAndLoad(); err != nil {
+		return err
+	} else if file != nil {
+		settings = file.Generator
+		features = file.Features
+	}
+
+	flags := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	codeFlag := flags.String("code", strings.Join(settings.Code, Separator),
+		"directories to search for functions seperated by comma")
+	outputFlag := flags.String("output", settings.Output,
+		"file to generate, main.go or cacik_test.go with -test by default")
+	packageFlag := flags.String("package", settings.Package, "package of the generated file, main by default")
+	testFlag := flags.Bool("test", settings.Test, "generate a TestCacik test function instead of a main function")
+	includeFlag := flags.String("include", strings.Join(settings.Include, Separator),
+		"glob patterns of the go files to search seperated by comma")
+	excludeFlag := flags.String("exclude", strings.Join(settings.Exclude, Separator),
+		"glob patterns of the go files and directories not to search seperated by comma")
+	tagsFlag := flags.String("tags", strings.Join(settings.Tags, Separator),
+		"build tags the go files are matched with seperated by comma")
+	tzdataFlag := flags.Bool("tzdata", settings.TimeZoneData,
+		"embed the time zone database in the generated file with time/tzdata")
+	featuresFlag := flags.String("features", strings.Join(features, Separator),
+		"feature directories whose scenarios are checked for data read before it is written seperated by comma")
+	docsFlag := flags.String("docs", settings.Docs, "markdown file to write the documentation of the steps to")
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		return err
+	}
+	options := Options{
+		Package:      *packageFlag,
+		Test:         *testFlag,
+		TimeZoneData: *tzdataFlag,
+	}
+	outputFile := *outputFlag
+	if len(outputFile) == 0 {
+		outputFile = options.defaultFileName()
+	}
+	if configurer, ok := codeParser.(SourceConfigurer); ok {
+		configurer.SetSourceOptions(SourceOptions{
+			Include:   splitFlag(*includeFlag),
+			Exclude:   splitFlag(*excludeFlag),
+			BuildTags: splitFlag(*tagsFlag),
+			Output:    outputFile,
+		})
+	}
 
 	if len(strings.TrimSpace(*codeFlag)) == 0 {
 		directory, err := os.Getwd()
@@ -29,23 +83,100 @@ func StartGenerator(ctx context.Context, codeParser GoCodeParser) error {
 		funcSources = append(funcSources, strings.Split(*codeFlag, Separator)...)
 	}
 
-	for _, source := range funcSources {
-		recursively, err := codeParser.ParseFunctionCommentsOfGoFilesInDirectoryRecursively(ctx, source)
-		if err != nil {
+	output, err := parseSources(ctx, codeParser, funcSources)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+	if directories := splitFlag(*featuresFlag); len(directories) > 0 {
+		if err := checkDataFlow(directories, output); err != nil {
 			log.Println(err.Error())
 			return err
 		}
-		create, err := os.Create("main.go")
+	}
+
+	create, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("could not create %s, error=%w", outputFile, err)
+	}
+	defer create.Close()
+
+	if err := output.GenerateWithOptions(create, options); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	if len(*docsFlag) > 0 {
+		return writeDocs(*docsFlag, output)
+	}
+
+	return nil
+}
+
+// parseSources parses the step functions, the hooks and the config function of the go files in the directories
+func parseSources(ctx context.Context, codeParser GoCodeParser, directories []string) (*Output, error) {
+	output := &Output{StepFunctions: make([]*StepFunctionLocator, 0)}
+	for _, directory := range directories {
+		recursively, err := codeParser.ParseFunctionCommentsOfGoFilesInDirectoryRecursively(ctx, directory)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		err = recursively.Generate(create)
+		if recursively.ConfigFunction != nil {
+			output.ConfigFunction = recursively.ConfigFunction
+		}
+		output.StepFunctions = append(output.StepFunctions, recursively.StepFunctions...)
+		output.HookWrites = append(output.HookWrites, recursively.HookWrites...)
+	}
 
-		if err != nil {
-			log.Println(err.Error())
-			return err
+	return output, nil
+}
+
+// checkDataFlow returns an error listing the steps of the scenarios in the directories which read data that no
+// earlier step or hook writes
+func checkDataFlow(directories []string, output *Output) error {
+	contracts := make([]*linter.StepContract, 0, len(output.StepFunctions))
+	for _, function := range output.StepFunctions {
+		contract := &linter.StepContract{Definition: function.StepName}
+		if function.Doc != nil {
+			contract.Reads, contract.Writes = function.Doc.Reads, function.Doc.Writes
 		}
+		contracts = append(contracts, contract)
+	}
+
+	issues, err := linter.LintDataFlowDirectories(directories, contracts, output.HookWrites)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		return nil
+	}
+	problems := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		problems = append(problems, issue.String())
+	}
+
+	return fmt.Errorf("scenarios read data before it is written:\n%s", strings.Join(problems, "\n"))
+}
+
+// writeDocs writes the documentation of the steps of the output to the markdown file at path
+func writeDocs(path string, output *Output) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create %s, error=%w", path, err)
+	}
+	defer file.Close()
+
+	if err := output.GenerateDocs(file); err != nil {
+		return fmt.Errorf("could not write step documentation %s, error=%w", path, err)
 	}
 
 	return nil
 }
+
+func splitFlag(value string) []string {
+	if len(strings.TrimSpace(value)) == 0 {
+		return nil
+	}
+
+	return strings.Split(value, Separator)
+}
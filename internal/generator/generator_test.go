@@ -3,6 +3,7 @@ package generator
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -14,16 +15,19 @@ func TestStartApplication(t *testing.T) {
 	t.Run("should call code parser with the working directory", func(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockGoCodeParser := NewMockGoCodeParser(controller)
+		output := filepath.Join(t.TempDir(), "main.go")
+		os.Args = []string{"x", "--output", output}
 
 		dir, _ := os.Getwd()
 		mockGoCodeParser.
 			EXPECT().
 			ParseFunctionCommentsOfGoFilesInDirectoryRecursively(gomock.Any(), dir).
-			Return([]FunctionLocator{}, nil).
+			Return(&Output{}, nil).
 			Times(1)
 
 		err := StartGenerator(context.Background(), mockGoCodeParser)
 		require.Nil(t, err)
+		require.FileExists(t, output)
 	})
 
 	t.Run("should get directories from flags", func(t *testing.T) {
@@ -31,17 +35,102 @@ func TestStartApplication(t *testing.T) {
 		mockGoCodeParser := NewMockGoCodeParser(controller)
 
 		expectedPath := "/etc,/home"
-		os.Args = []string{"x", "--code", expectedPath}
+		output := filepath.Join(t.TempDir(), "main.go")
+		os.Args = []string{"x", "--code", expectedPath, "--output", output}
 
 		for _, s := range strings.Split(expectedPath, Separator) {
 			mockGoCodeParser.
 				EXPECT().
 				ParseFunctionCommentsOfGoFilesInDirectoryRecursively(gomock.Any(), s).
-				Return([]FunctionLocator{}, nil).
+				Return(&Output{}, nil).
 				Times(1)
 		}
 
 		err := StartGenerator(context.Background(), mockGoCodeParser)
 		require.Nil(t, err)
 	})
+
+	t.Run("should merge the step functions of all directories into a test file", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockGoCodeParser := NewMockGoCodeParser(controller)
+		output := filepath.Join(t.TempDir(), "cacik_test.go")
+		os.Args = []string{"x", "--code", "a,b", "--output", output, "--package", "checkout", "--test"}
+
+		for _, s := range []string{"a", "b"} {
+			mockGoCodeParser.
+				EXPECT().
+				ParseFunctionCommentsOfGoFilesInDirectoryRecursively(gomock.Any(), s).
+				Return(&Output{StepFunctions: []*StepFunctionLocator{{
+					StepName:        "^step " + s + "$",
+					FunctionLocator: &FunctionLocator{FullPackageName: s, FunctionName: "Step"},
+				}}}, nil).
+				Times(1)
+		}
+
+		err := StartGenerator(context.Background(), mockGoCodeParser)
+		require.Nil(t, err)
+
+		content, err := os.ReadFile(output)
+		require.Nil(t, err)
+		require.Contains(t, string(content), "package checkout")
+		require.Contains(t, string(content), "func TestCacik(t *testing.T)")
+		require.Contains(t, string(content), `RegisterStep("^step a$", a.Step)`)
+		require.Contains(t, string(content), `RegisterStep("^step b$", b.Step)`)
+	})
+
+	t.Run("should write the documentation of the steps", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockGoCodeParser := NewMockGoCodeParser(controller)
+		directory := t.TempDir()
+		docs := filepath.Join(directory, "steps.md")
+		os.Args = []string{"x", "--code", "a", "--output", filepath.Join(directory, "main.go"), "--docs", docs}
+
+		mockGoCodeParser.
+			EXPECT().
+			ParseFunctionCommentsOfGoFilesInDirectoryRecursively(gomock.Any(), "a").
+			Return(&Output{StepFunctions: []*StepFunctionLocator{{
+				StepName:        "^step a$",
+				FunctionLocator: &FunctionLocator{FullPackageName: "a", FunctionName: "Step"},
+				Doc:             &StepDoc{Reads: []string{"basket"}},
+			}}}, nil).
+			Times(1)
+
+		err := StartGenerator(context.Background(), mockGoCodeParser)
+		require.Nil(t, err)
+
+		content, err := os.ReadFile(docs)
+		require.Nil(t, err)
+		require.Equal(t, "# Steps\n\n## `^step a$`\n\n`a.Step`\n\nReads: `basket`\n", string(content))
+	})
+
+	t.Run("should fail if scenarios read data before it is written", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockGoCodeParser := NewMockGoCodeParser(controller)
+		directory := t.TempDir()
+		require.Nil(t, os.WriteFile(filepath.Join(directory, "basket.feature"), []byte(`Feature: Basket
+
+  Scenario: Pay
+    Given I log in
+    Then I pay
+`), 0o644))
+		os.Args = []string{"x", "--code", "a", "--output", filepath.Join(directory, "main.go"), "--features", directory}
+
+		mockGoCodeParser.
+			EXPECT().
+			ParseFunctionCommentsOfGoFilesInDirectoryRecursively(gomock.Any(), "a").
+			Return(&Output{
+				StepFunctions: []*StepFunctionLocator{{
+					StepName:        "^I pay$",
+					FunctionLocator: &FunctionLocator{FullPackageName: "a", FunctionName: "Pay"},
+					Doc:             &StepDoc{Reads: []string{"user", "basket"}},
+				}},
+				HookWrites: []string{"user"},
+			}, nil).
+			Times(1)
+
+		err := StartGenerator(context.Background(), mockGoCodeParser)
+
+		require.EqualError(t, err, "scenarios read data before it is written:\n"+filepath.Join(directory, "basket.feature")+
+			`:5: error: step "I pay" of scenario "Pay" reads data "basket" which no earlier step writes`)
+	})
 }
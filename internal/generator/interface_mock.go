@@ -1,6 +1,10 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: interfaces.go
-
+//
+// Generated by this command:
+//
+//	mockgen -source=interfaces.go -destination=interface_mock.go -package=generator
+//
 // Package generator is a generated GoMock package.
 package generator
 
@@ -44,11 +48,46 @@ func (m *MockGoCodeParser) ParseFunctionCommentsOfGoFilesInDirectoryRecursively(
 }
 
 // ParseFunctionCommentsOfGoFilesInDirectoryRecursively indicates an expected call of ParseFunctionCommentsOfGoFilesInDirectoryRecursively.
-func (mr *MockGoCodeParserMockRecorder) ParseFunctionCommentsOfGoFilesInDirectoryRecursively(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockGoCodeParserMockRecorder) ParseFunctionCommentsOfGoFilesInDirectoryRecursively(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseFunctionCommentsOfGoFilesInDirectoryRecursively", reflect.TypeOf((*MockGoCodeParser)(nil).ParseFunctionCommentsOfGoFilesInDirectoryRecursively), arg0, arg1)
 }
 
+// MockSourceConfigurer is a mock of SourceConfigurer interface.
+type MockSourceConfigurer struct {
+	ctrl     *gomock.Controller
+	recorder *MockSourceConfigurerMockRecorder
+}
+
+// MockSourceConfigurerMockRecorder is the mock recorder for MockSourceConfigurer.
+type MockSourceConfigurerMockRecorder struct {
+	mock *MockSourceConfigurer
+}
+
+// NewMockSourceConfigurer creates a new mock instance.
+func NewMockSourceConfigurer(ctrl *gomock.Controller) *MockSourceConfigurer {
+	mock := &MockSourceConfigurer{ctrl: ctrl}
+	mock.recorder = &MockSourceConfigurerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSourceConfigurer) EXPECT() *MockSourceConfigurerMockRecorder {
+	return m.recorder
+}
+
+// SetSourceOptions mocks base method.
+func (m *MockSourceConfigurer) SetSourceOptions(options SourceOptions) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSourceOptions", options)
+}
+
+// SetSourceOptions indicates an expected call of SetSourceOptions.
+func (mr *MockSourceConfigurerMockRecorder) SetSourceOptions(options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSourceOptions", reflect.TypeOf((*MockSourceConfigurer)(nil).SetSourceOptions), options)
+}
+
 // MockGherkinParser is a mock of GherkinParser interface.
 type MockGherkinParser struct {
 	ctrl     *gomock.Controller
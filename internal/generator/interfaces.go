@@ -7,6 +7,10 @@ type (
 	GoCodeParser interface {
 		ParseFunctionCommentsOfGoFilesInDirectoryRecursively(context.Context, string) (*Output, error)
 	}
+	// SourceConfigurer is implemented by code parsers which can select the go files they search
+	SourceConfigurer interface {
+		SetSourceOptions(options SourceOptions)
+	}
 	GherkinParser interface {
 	}
 )
@@ -1,48 +1,251 @@
 package generator
 
 import (
+	"fmt"
+	"go/token"
 	"io"
+	"path"
+	"strings"
 
 	"github.com/dave/jennifer/jen"
 )
 
+const (
+	DefaultPackage   = "main"
+	DefaultMainFile  = "main.go"
+	DefaultTestFile  = "cacik_test.go"
+	TestFunctionName = "TestCacik"
+	runnerPackage    = "github.com/denizgursoy/cacik/pkg/runner"
+)
+
 type (
 	FunctionLocator struct {
 		FullPackageName string
 		FunctionName    string
+		// Receiver is the suite the function is a method of, nil for functions
+		Receiver *SuiteLocator
+	}
+
+	// SuiteLocator is a type whose methods are step functions. One value of the type is created for the run and
+	// its methods are registered bound to it.
+	SuiteLocator struct {
+		FullPackageName string
+		TypeName        string
+		// Constructor is the function of the package creating the suite, such as NewCheckoutSuite. The suite is
+		// created as a pointer to the zero value if empty.
+		Constructor string
 	}
 
 	StepFunctionLocator struct {
 		StepName string
 		*FunctionLocator
+		// Doc describes the contract of the step function, nil if it is not known
+		Doc *StepDoc
+	}
+
+	// StepDoc is the input and output contract of a step function written to the step documentation
+	StepDoc struct {
+		// Description is the comment of the function without its annotations
+		Description string
+		// Parameters are the parameters of the function in order, the context included
+		Parameters []*ParameterDoc
+		// Reads and Writes are the keys of the scenario data the step reads and writes, declared with
+		// @cacik-reads and @cacik-writes comments
+		Reads  []string
+		Writes []string
+	}
+
+	ParameterDoc struct {
+		Name string
+		Type string
 	}
 
 	Output struct {
 		ConfigFunction *FunctionLocator
 		StepFunctions  []*StepFunctionLocator
+		// HookWrites are the keys of the scenario data written by the hooks before every scenario, declared with
+		// @cacik-writes comments of the config function
+		HookWrites []string
+	}
+
+	// SourceOptions select the go files searched for step functions
+	SourceOptions struct {
+		// Include are the glob patterns of the files to search relative to the searched directory, such as
+		// steps/**. All files are searched if empty.
+		Include []string
+		// Exclude are the glob patterns of the files and directories not to search
+		Exclude []string
+		// BuildTags are the build tags files are matched with, so that step functions behind build constraints
+		// are found only for matching builds
+		BuildTags []string
+		// Output is the generated file, which is loaded without its declarations, so that a file generated for
+		// step functions which changed since does not fail the generation
+		Output string
+	}
+
+	// Options configures the generated file. The zero value generates the main function of package main.
+	Options struct {
+		// Package is the package of the generated file, main if empty
+		Package string
+		// Test generates a TestCacik(t *testing.T) function instead of a main function, so the scenarios can be
+		// executed with go test in any package
+		Test bool
+		// TimeZoneData imports time/tzdata, so time zones can be loaded where the system has no zoneinfo files
+		TimeZoneData bool
 	}
 )
 
+func (o Options) defaultFileName() string {
+	if o.Test {
+		return DefaultTestFile
+	}
+
+	return DefaultMainFile
+}
+
+// Generate writes the main function of package main registering the step functions
 func (o *Output) Generate(writer io.Writer) error {
-	mainFile := jen.NewFile("main")
+	return o.GenerateWithOptions(writer, Options{})
+}
 
-	functionBody := jen.Id("err").Op(":=").Qual("github.com/denizgursoy/cacik/pkg/runner", "NewCucumberRunner").Call().Id(".").Line()
+// GenerateWithOptions writes the file registering the step functions with the options
+func (o *Output) GenerateWithOptions(writer io.Writer, options Options) error {
+	packageName := options.Package
+	if len(packageName) == 0 {
+		packageName = DefaultPackage
+	}
+	file := jen.NewFile(packageName)
+	if options.TimeZoneData {
+		file.Anon("time/tzdata")
+	}
+
+	statements, suites, err := o.suiteStatements(o.importAliases(file))
+	if err != nil {
+		return err
+	}
+	functionBody := jen.Id("err").Op(":=").Qual(runnerPackage, "NewCucumberRunner").Call(jen.Nil()).Id(".").Line()
 
 	if o.ConfigFunction != nil {
 		functionBody.Id("WithConfigFunc").Call(jen.Qual(o.ConfigFunction.FullPackageName, o.ConfigFunction.FunctionName)).Id(".").Line()
 	}
 
 	for _, function := range o.StepFunctions {
-		functionBody.Id("RegisterStep").Call(jen.Lit(function.StepName), jen.Qual(function.FullPackageName, function.FunctionName)).Id(".").Line()
+		stepFunction := jen.Qual(function.FullPackageName, function.FunctionName)
+		if function.Receiver != nil {
+			stepFunction = jen.Id(suites[function.Receiver]).Dot(function.FunctionName)
+		}
+		functionBody.Id("RegisterStep").Call(jen.Lit(function.StepName), stepFunction).Id(".").Line()
 	}
 	functionBody.Id("RunWithTags").Call().Line().Line()
-	functionBody.If(jen.Id("err").Op("!=").Nil()).Block(
-		jen.Qual("log", "Fatal").Call(jen.Id("err")),
-	)
 
-	mainFile.Func().Id("main").Params().Block(functionBody)
+	if options.Test {
+		functionBody.If(jen.Id("err").Op("!=").Nil()).Block(
+			jen.Id("t").Dot("Fatal").Call(jen.Id("err")),
+		)
+		file.Func().Id(TestFunctionName).Params(jen.Id("t").Op("*").Qual("testing", "T")).Block(
+			append(statements, functionBody)...)
+	} else {
+		functionBody.If(jen.Id("err").Op("!=").Nil()).Block(
+			jen.Qual("log", "Fatal").Call(jen.Id("err")),
+		)
+		file.Func().Id("main").Params().Block(append(statements, functionBody)...)
+	}
 
-	_, err := writer.Write([]byte(mainFile.GoString()))
+	_, err = writer.Write([]byte(file.GoString()))
 
 	return err
 }
+
+// importAliases sets the aliases the packages of the step functions, the config function and the suites are
+// imported with in the file and returns every name taken by an import, so that the variables of the suites do not
+// shadow them. Aliases are named after the last element of the import path like jen names them, and a number is
+// added to names which are taken or reserved.
+func (o *Output) importAliases(file *jen.File) map[string]bool {
+	taken := map[string]bool{"runner": true, "log": true, "testing": true}
+	paths := make([]string, 0)
+	if o.ConfigFunction != nil {
+		paths = append(paths, o.ConfigFunction.FullPackageName)
+	}
+	for _, function := range o.StepFunctions {
+		paths = append(paths, function.FullPackageName)
+		if function.Receiver != nil {
+			paths = append(paths, function.Receiver.FullPackageName)
+		}
+	}
+
+	aliases := make(map[string]string)
+	for _, importPath := range paths {
+		if _, ok := aliases[importPath]; ok || importPath == runnerPackage {
+			continue
+		}
+		base := packageAlias(importPath)
+		alias := base
+		for i := 2; taken[alias] || jen.IsReservedWord(alias); i++ {
+			alias = fmt.Sprintf("%s%d", base, i)
+		}
+		taken[alias] = true
+		aliases[importPath] = alias
+		file.ImportAlias(importPath, alias)
+	}
+
+	return taken
+}
+
+// packageAlias returns the last element of the import path in lower case without the characters which can not be
+// in an identifier, such as steps for example.com/shop/steps
+func packageAlias(importPath string) string {
+	alias := strings.ToLower(path.Base(importPath))
+	alias = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+
+		return -1
+	}, alias)
+	alias = strings.TrimLeft(alias, "0123456789")
+	if len(alias) == 0 {
+		return "pkg"
+	}
+
+	return alias
+}
+
+// suiteStatements returns the statements creating the suites of the step functions and the variable names of the
+// suites. Variables are named after the type of the suite, such as checkoutSuite, unless the name is taken by an
+// import or a variable of the generated function. Suites of unexported types can not be created by the generated
+// file, so they are rejected.
+func (o *Output) suiteStatements(imports map[string]bool) ([]jen.Code, map[*SuiteLocator]string, error) {
+	taken := map[string]bool{"err": true, "t": true}
+	for name := range imports {
+		taken[name] = true
+	}
+
+	statements := make([]jen.Code, 0)
+	suites := make(map[*SuiteLocator]string)
+	for _, function := range o.StepFunctions {
+		suite := function.Receiver
+		if suite == nil || len(suites[suite]) > 0 {
+			continue
+		}
+		if !token.IsExported(suite.TypeName) {
+			return nil, nil, fmt.Errorf("suite type %s.%s of step %s is not exported, so the generated file can "+
+				"not create it", suite.FullPackageName, suite.TypeName, function.StepName)
+		}
+
+		base := strings.ToLower(suite.TypeName[:1]) + suite.TypeName[1:]
+		name := base
+		for i := 2; taken[name]; i++ {
+			name = fmt.Sprintf("%s%d", base, i)
+		}
+		taken[name] = true
+		suites[suite] = name
+
+		if len(suite.Constructor) > 0 {
+			statements = append(statements, jen.Id(name).Op(":=").Qual(suite.FullPackageName, suite.Constructor).Call())
+		} else {
+			statements = append(statements, jen.Id(name).Op(":=").Op("&").Qual(suite.FullPackageName, suite.TypeName).Values())
+		}
+	}
+
+	return statements, suites, nil
+}
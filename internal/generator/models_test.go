@@ -15,14 +15,14 @@ var (
 		},
 		StepFunctions: []*StepFunctionLocator{
 			{
-				StepName: `"^step 1$"`,
+				StepName: "^step 1$",
 				FunctionLocator: &FunctionLocator{
 					FullPackageName: "package1",
 					FunctionName:    "Step1Function",
 				},
 			},
 			{
-				StepName: `"^step 2$"`,
+				StepName: "^step 2$",
 				FunctionLocator: &FunctionLocator{
 					FullPackageName: "package2",
 					FunctionName:    "Step2Function",
@@ -37,19 +37,44 @@ import (
 	a "a"
 	runner "github.com/denizgursoy/cacik/pkg/runner"
 	"log"
+	package1 "package1"
+	package2 "package2"
 )
 
 func main() {
-	err := runner.NewCucumberRunner().
-		SetConfig(a.ConfigFunction).
-		RegisterStep("^step 1$", Step1Function).
-		RegisterStep("^step 2$", Step2Function).
+	err := runner.NewCucumberRunner(nil).
+		WithConfigFunc(a.ConfigFunction).
+		RegisterStep("^step 1$", package1.Step1Function).
+		RegisterStep("^step 2$", package2.Step2Function).
 		RunWithTags()
 
 	if err != nil {
 		log.Fatal(err)
 	}
 }
+`
+
+	expectedTest = `package checkout
+
+import (
+	a "a"
+	runner "github.com/denizgursoy/cacik/pkg/runner"
+	package1 "package1"
+	package2 "package2"
+	"testing"
+)
+
+func TestCacik(t *testing.T) {
+	err := runner.NewCucumberRunner(nil).
+		WithConfigFunc(a.ConfigFunction).
+		RegisterStep("^step 1$", package1.Step1Function).
+		RegisterStep("^step 2$", package2.Step2Function).
+		RunWithTags()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
 `
 )
 
@@ -61,4 +86,113 @@ func TestOutput_Generate(t *testing.T) {
 		require.Nil(t, err)
 		require.EqualValues(t, expected, builder.String())
 	})
+	t.Run("should generate a test function in the package", func(t *testing.T) {
+		builder := &strings.Builder{}
+		err := data.GenerateWithOptions(builder, Options{Package: "checkout", Test: true})
+
+		require.Nil(t, err)
+		require.EqualValues(t, expectedTest, builder.String())
+	})
+	t.Run("should embed the time zone database", func(t *testing.T) {
+		builder := &strings.Builder{}
+		err := data.GenerateWithOptions(builder, Options{TimeZoneData: true})
+
+		require.Nil(t, err)
+		require.Contains(t, builder.String(), "_ \"time/tzdata\"")
+	})
+	t.Run("should register methods bound to their suites", func(t *testing.T) {
+		checkout := &SuiteLocator{FullPackageName: "steps", TypeName: "CheckoutSuite", Constructor: "NewCheckoutSuite"}
+		cart := &SuiteLocator{FullPackageName: "steps", TypeName: "Steps"}
+		output := Output{
+			StepFunctions: []*StepFunctionLocator{
+				{
+					StepName:        "^I add an item$",
+					FunctionLocator: &FunctionLocator{FullPackageName: "steps", FunctionName: "IAddItem", Receiver: checkout},
+				},
+				{
+					StepName:        "^I pay$",
+					FunctionLocator: &FunctionLocator{FullPackageName: "steps", FunctionName: "IPay", Receiver: checkout},
+				},
+				{
+					StepName:        "^the cart is empty$",
+					FunctionLocator: &FunctionLocator{FullPackageName: "steps", FunctionName: "CartIsEmpty", Receiver: cart},
+				},
+			},
+		}
+		builder := &strings.Builder{}
+		err := output.Generate(builder)
+
+		require.Nil(t, err)
+		require.Contains(t, builder.String(), `	checkoutSuite := steps.NewCheckoutSuite()
+	steps2 := &steps.Steps{}
+	err := runner.NewCucumberRunner(nil).
+		RegisterStep("^I add an item$", checkoutSuite.IAddItem).
+		RegisterStep("^I pay$", checkoutSuite.IPay).
+		RegisterStep("^the cart is empty$", steps2.CartIsEmpty).
+`)
+	})
+	t.Run("should not name suites after the aliases of the imports", func(t *testing.T) {
+		suite := &SuiteLocator{FullPackageName: "example.com/shop/check-out", TypeName: "Checkout"}
+		output := Output{StepFunctions: []*StepFunctionLocator{{
+			StepName:        "^I pay$",
+			FunctionLocator: &FunctionLocator{FullPackageName: suite.FullPackageName, FunctionName: "IPay", Receiver: suite},
+		}}}
+		builder := &strings.Builder{}
+		err := output.Generate(builder)
+
+		require.Nil(t, err)
+		require.Contains(t, builder.String(), `checkout "example.com/shop/check-out"`)
+		require.Contains(t, builder.String(), `	checkout2 := &checkout.Checkout{}
+	err := runner.NewCucumberRunner(nil).
+		RegisterStep("^I pay$", checkout2.IPay).
+`)
+	})
+	t.Run("should return error for suites of unexported types", func(t *testing.T) {
+		suite := &SuiteLocator{FullPackageName: "steps", TypeName: "cart"}
+		output := Output{StepFunctions: []*StepFunctionLocator{{
+			StepName:        "^the cart is empty$",
+			FunctionLocator: &FunctionLocator{FullPackageName: "steps", FunctionName: "CartIsEmpty", Receiver: suite},
+		}}}
+
+		err := output.Generate(&strings.Builder{})
+
+		require.EqualError(t, err, "suite type steps.cart of step ^the cart is empty$ is not exported, so the "+
+			"generated file can not create it")
+	})
+}
+
+func TestOutput_GenerateDocs(t *testing.T) {
+	t.Run("should document the parameters and the data keys of every step", func(t *testing.T) {
+		output := Output{StepFunctions: []*StepFunctionLocator{
+			{
+				StepName: "^I add {int} items$",
+				FunctionLocator: &FunctionLocator{
+					FullPackageName: "steps",
+					FunctionName:    "IAddItems",
+					Receiver:        &SuiteLocator{FullPackageName: "steps", TypeName: "CheckoutSuite"},
+				},
+				Doc: &StepDoc{
+					Description: "IAddItems adds items to the basket",
+					Parameters:  []*ParameterDoc{{Name: "ctx", Type: "context.Context"}, {Name: "count", Type: "int"}},
+					Reads:       []string{"basket"},
+					Writes:      []string{"basket", "total"},
+				},
+			},
+			{
+				StepName:        "^I pay$",
+				FunctionLocator: &FunctionLocator{FullPackageName: "steps", FunctionName: "IPay"},
+			},
+		}}
+		builder := &strings.Builder{}
+
+		err := output.GenerateDocs(builder)
+
+		require.Nil(t, err)
+		require.Equal(t, "# Steps\n\n"+
+			"## `^I add {int} items$`\n\n`steps.(*CheckoutSuite).IAddItems`\n\n"+
+			"IAddItems adds items to the basket\n\n"+
+			"| Parameter | Type |\n|---|---|\n| ctx | `context.Context` |\n| count | `int` |\n\n"+
+			"Reads: `basket`\n\nWrites: `basket`, `total`\n\n"+
+			"## `^I pay$`\n\n`steps.IPay`\n", builder.String())
+	})
 }
@@ -0,0 +1,95 @@
+// Package assert checks the conditions of steps. Assertions return an error describing the failure, which the step
+// returns to fail.
+package assert
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/steps"
+)
+
+type (
+	// Assertions check conditions in the scenario the context belongs to. They wait with the clock of the
+	// scenario, see steps.UseClock, and stop waiting when the context is done.
+	Assertions struct {
+		ctx context.Context
+	}
+)
+
+// Assert returns the assertions of the scenario the context belongs to
+func Assert(ctx context.Context) *Assertions {
+	return &Assertions{ctx: ctx}
+}
+
+// Eventually checks the condition every interval until it is true and returns an error if it is still false after
+// the timeout. The message describes the condition, and is formatted with fmt.Sprintf if it has arguments. The
+// timeout and the interval must be positive.
+func (a *Assertions) Eventually(condition func() bool, timeout, interval time.Duration, message ...any) error {
+	if err := checkPolling("timeout", timeout, interval); err != nil {
+		return err
+	}
+	clock := steps.ClockFrom(a.ctx)
+	start := clock.Now()
+	for {
+		if condition() {
+			return nil
+		}
+		elapsed := clock.Now().Sub(start)
+		if elapsed >= timeout {
+			return fmt.Errorf("condition was not met within %s%s", timeout, describe(message))
+		}
+		if err := clock.Sleep(a.ctx, min(interval, timeout-elapsed)); err != nil {
+			return fmt.Errorf("stopped waiting for condition%s, error=%w", describe(message), err)
+		}
+	}
+}
+
+// Consistently checks the condition every interval for the duration and returns an error as soon as it is false.
+// The message describes the condition, and is formatted with fmt.Sprintf if it has arguments. The duration and the
+// interval must be positive.
+func (a *Assertions) Consistently(condition func() bool, duration, interval time.Duration, message ...any) error {
+	if err := checkPolling("duration", duration, interval); err != nil {
+		return err
+	}
+	clock := steps.ClockFrom(a.ctx)
+	start := clock.Now()
+	for {
+		elapsed := clock.Now().Sub(start)
+		if !condition() {
+			return fmt.Errorf("condition was not met after %s%s", elapsed, describe(message))
+		}
+		if elapsed >= duration {
+			return nil
+		}
+		if err := clock.Sleep(a.ctx, min(interval, duration-elapsed)); err != nil {
+			return fmt.Errorf("stopped checking condition%s, error=%w", describe(message), err)
+		}
+	}
+}
+
+// checkPolling returns an error if the period, named by its kind, or the interval of polling is not positive, as
+// the condition would be polled without waiting
+func checkPolling(kind string, period, interval time.Duration) error {
+	if period <= 0 {
+		return fmt.Errorf("%s %s must be positive", kind, period)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("interval %s must be positive", interval)
+	}
+
+	return nil
+}
+
+// describe formats the message of an assertion to be appended to its error
+func describe(message []any) string {
+	if len(message) == 0 {
+		return ""
+	}
+	if format, ok := message[0].(string); ok && len(message) > 1 {
+		return ": " + fmt.Sprintf(format, message[1:]...)
+	}
+
+	return ": " + fmt.Sprint(message...)
+}
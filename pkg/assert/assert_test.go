@@ -0,0 +1,103 @@
+package assert
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/denizgursoy/cacik/pkg/steps"
+	"github.com/denizgursoy/cacik/pkg/steptest"
+	"github.com/stretchr/testify/require"
+)
+
+// scenario returns the context of a scenario with a clock which does not sleep
+func scenario() (context.Context, *steptest.Clock) {
+	ctx := models.ContextWithData(context.Background(), models.NewData())
+	clock := steptest.NewClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	steps.UseClock(ctx, clock)
+
+	return ctx, clock
+}
+
+func TestAssertions_Eventually(t *testing.T) {
+	t.Run("should poll until the condition is met", func(t *testing.T) {
+		ctx, clock := scenario()
+		start := clock.Now()
+		polls := 0
+
+		err := Assert(ctx).Eventually(func() bool {
+			polls++
+			return polls == 3
+		}, time.Second, 100*time.Millisecond)
+
+		require.Nil(t, err)
+		require.Equal(t, 200*time.Millisecond, clock.Now().Sub(start))
+	})
+	t.Run("should return error with the message after the timeout", func(t *testing.T) {
+		ctx, clock := scenario()
+		start := clock.Now()
+
+		err := Assert(ctx).Eventually(func() bool {
+			return false
+		}, time.Second, 300*time.Millisecond, "queue %s is empty", "orders")
+
+		require.EqualError(t, err, "condition was not met within 1s: queue orders is empty")
+		require.Equal(t, time.Second, clock.Now().Sub(start))
+	})
+	t.Run("should stop waiting when the context is done", func(t *testing.T) {
+		ctx, _ := scenario()
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		err := Assert(ctx).Eventually(func() bool {
+			return false
+		}, time.Second, 100*time.Millisecond)
+
+		require.ErrorIs(t, err, context.Canceled)
+	})
+	t.Run("should return error for a timeout or an interval which is not positive", func(t *testing.T) {
+		ctx, _ := scenario()
+		condition := func() bool { return false }
+
+		require.EqualError(t, Assert(ctx).Eventually(condition, time.Second, 0), "interval 0s must be positive")
+		require.EqualError(t, Assert(ctx).Eventually(condition, -time.Second, time.Millisecond),
+			"timeout -1s must be positive")
+	})
+}
+
+func TestAssertions_Consistently(t *testing.T) {
+	t.Run("should check the condition for the duration", func(t *testing.T) {
+		ctx, clock := scenario()
+		start := clock.Now()
+		polls := 0
+
+		err := Assert(ctx).Consistently(func() bool {
+			polls++
+			return true
+		}, time.Second, 250*time.Millisecond)
+
+		require.Nil(t, err)
+		require.Equal(t, 5, polls)
+		require.Equal(t, time.Second, clock.Now().Sub(start))
+	})
+	t.Run("should return error as soon as the condition is not met", func(t *testing.T) {
+		ctx, _ := scenario()
+		polls := 0
+
+		err := Assert(ctx).Consistently(func() bool {
+			polls++
+			return polls < 3
+		}, time.Second, 250*time.Millisecond, "balance stays positive")
+
+		require.EqualError(t, err, "condition was not met after 500ms: balance stays positive")
+	})
+	t.Run("should return error for a duration or an interval which is not positive", func(t *testing.T) {
+		ctx, _ := scenario()
+		condition := func() bool { return true }
+
+		require.EqualError(t, Assert(ctx).Consistently(condition, time.Second, -time.Millisecond),
+			"interval -1ms must be positive")
+		require.EqualError(t, Assert(ctx).Consistently(condition, 0, time.Millisecond), "duration 0s must be positive")
+	})
+}
@@ -0,0 +1,284 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// ErrorAs returns an error if no error in the chain of err can be assigned to the target with errors.As. The target
+// must be a non-nil pointer to a type implementing error or to an interface, and is set to the error found.
+func (a *Assertions) ErrorAs(err error, target any, message ...any) error {
+	targetValue := reflect.ValueOf(target)
+	if target == nil || targetValue.Kind() != reflect.Pointer || targetValue.IsNil() {
+		return fmt.Errorf("target of ErrorAs must be a non-nil pointer, got %T%s", target, describe(message))
+	}
+	if elem := targetValue.Type().Elem(); elem.Kind() != reflect.Interface && !elem.Implements(errorType) {
+		return fmt.Errorf("target of ErrorAs must point to an interface or a type implementing error, got %T%s",
+			target, describe(message))
+	}
+	if errors.As(err, target) {
+		return nil
+	}
+
+	return fmt.Errorf("no error in the chain of %s is %s%s", errorChain(err), targetValue.Type().Elem(),
+		describe(message))
+}
+
+// ErrorIs returns an error if no error in the chain of err matches the target with errors.Is, which follows errors
+// wrapped with %w, including errors wrapping several such as the ones of errors.Join
+func (a *Assertions) ErrorIs(err, target error, message ...any) error {
+	if errors.Is(err, target) {
+		return nil
+	}
+
+	return fmt.Errorf("no error in the chain of %s is %s%s", errorChain(err), errorChain(target), describe(message))
+}
+
+// ErrorChainContains returns an error listing the targets which no error in the chain of err matches with
+// errors.Is, so an error wrapped several times can be checked for every error it must wrap
+func (a *Assertions) ErrorChainContains(err error, targets []error, message ...any) error {
+	missing := make([]string, 0)
+	for _, target := range targets {
+		if !errors.Is(err, target) {
+			missing = append(missing, errorChain(target))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("chain of %s does not contain %s%s", errorChain(err), strings.Join(missing, ", "),
+		describe(message))
+}
+
+// Regexp returns an error if the value does not match the pattern, which is a string or a *regexp.Regexp. A value
+// which is not a string is formatted with fmt.Sprint.
+func (a *Assertions) Regexp(pattern, value any, message ...any) error {
+	expression, text, err := compileMatch(pattern, value)
+	if err != nil {
+		return fmt.Errorf("%w%s", err, describe(message))
+	}
+	if !expression.MatchString(text) {
+		return fmt.Errorf("%q does not match %q%s", text, expression, describe(message))
+	}
+
+	return nil
+}
+
+// NotRegexp returns an error if the value matches the pattern, see Regexp
+func (a *Assertions) NotRegexp(pattern, value any, message ...any) error {
+	expression, text, err := compileMatch(pattern, value)
+	if err != nil {
+		return fmt.Errorf("%w%s", err, describe(message))
+	}
+	if expression.MatchString(text) {
+		return fmt.Errorf("%q matches %q%s", text, expression, describe(message))
+	}
+
+	return nil
+}
+
+// InDelta returns an error if the numbers differ by more than the delta
+func (a *Assertions) InDelta(expected, actual any, delta float64, message ...any) error {
+	expectedNumber, actualNumber, err := toFloats(expected, actual)
+	if err != nil {
+		return fmt.Errorf("%w%s", err, describe(message))
+	}
+	if difference := math.Abs(expectedNumber - actualNumber); !(difference <= delta) {
+		return fmt.Errorf("%v and %v differ by %v, more than %v%s", expected, actual, difference, delta,
+			describe(message))
+	}
+
+	return nil
+}
+
+// InEpsilon returns an error if the relative error of the actual number, its difference from the expected number
+// divided by the expected number, is more than the epsilon. The expected number can not be zero.
+func (a *Assertions) InEpsilon(expected, actual any, epsilon float64, message ...any) error {
+	expectedNumber, actualNumber, err := toFloats(expected, actual)
+	if err != nil {
+		return fmt.Errorf("%w%s", err, describe(message))
+	}
+	if expectedNumber == 0 {
+		return fmt.Errorf("relative error to the expected number 0 is not defined, use InDelta%s", describe(message))
+	}
+	if relative := math.Abs(expectedNumber-actualNumber) / math.Abs(expectedNumber); !(relative <= epsilon) {
+		return fmt.Errorf("relative error of %v to %v is %v, more than %v%s", actual, expected, relative, epsilon,
+			describe(message))
+	}
+
+	return nil
+}
+
+// ElementsMatch returns an error if the slices or arrays do not have the same elements the same number of times,
+// ignoring their order
+func (a *Assertions) ElementsMatch(expected, actual any, message ...any) error {
+	expectedValue, actualValue := reflect.ValueOf(expected), reflect.ValueOf(actual)
+	if !isList(expectedValue) || !isList(actualValue) {
+		return fmt.Errorf("ElementsMatch compares slices and arrays, got %T and %T%s", expected, actual,
+			describe(message))
+	}
+
+	missing, extra := listDifference(expectedValue, actualValue)
+	if len(missing) == 0 && len(extra) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(missing)+len(extra))
+	for _, element := range missing {
+		lines = append(lines, fmt.Sprintf("-%#v", element))
+	}
+	for _, element := range extra {
+		lines = append(lines, fmt.Sprintf("+%#v", element))
+	}
+
+	return fmt.Errorf("elements do not match%s\n%s", describe(message), strings.Join(lines, "\n"))
+}
+
+// Subset returns an error if the list does not contain every element of the subset, or the map does not contain
+// every key of the subset with the same value
+func (a *Assertions) Subset(list, subset any, message ...any) error {
+	listValue, subsetValue := reflect.ValueOf(list), reflect.ValueOf(subset)
+	if listValue.Kind() == reflect.Map && subsetValue.Kind() == reflect.Map {
+		if !subsetValue.Type().Key().AssignableTo(listValue.Type().Key()) {
+			return fmt.Errorf("Subset compares maps with keys of the same type, got %T and %T%s", list, subset,
+				describe(message))
+		}
+		lines := make([]string, 0)
+		for _, key := range subsetValue.MapKeys() {
+			value := listValue.MapIndex(key)
+			if !value.IsValid() || !reflect.DeepEqual(value.Interface(), subsetValue.MapIndex(key).Interface()) {
+				lines = append(lines, fmt.Sprintf("-[%#v]: %#v", key, subsetValue.MapIndex(key)))
+			}
+		}
+		if len(lines) == 0 {
+			return nil
+		}
+
+		return fmt.Errorf("map does not contain the subset%s\n%s", describe(message), strings.Join(lines, "\n"))
+	}
+	if !isList(listValue) || !isList(subsetValue) {
+		return fmt.Errorf("Subset compares slices, arrays or maps, got %T and %T%s", list, subset, describe(message))
+	}
+
+	missing, _ := listDifference(subsetValue, listValue)
+	if len(missing) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(missing))
+	for _, element := range missing {
+		lines = append(lines, fmt.Sprintf("-%#v", element))
+	}
+
+	return fmt.Errorf("list does not contain the subset%s\n%s", describe(message), strings.Join(lines, "\n"))
+}
+
+// compileMatch returns the regular expression of the pattern and the text of the value
+func compileMatch(pattern, value any) (*regexp.Regexp, string, error) {
+	expression, ok := pattern.(*regexp.Regexp)
+	if !ok {
+		compiled, err := regexp.Compile(fmt.Sprint(pattern))
+		if err != nil {
+			return nil, "", fmt.Errorf("could not compile pattern %v, error=%w", pattern, err)
+		}
+		expression = compiled
+	}
+	text, ok := value.(string)
+	if !ok {
+		text = fmt.Sprint(value)
+	}
+
+	return expression, text, nil
+}
+
+// toFloats converts the numbers of any integer or float type to float64
+func toFloats(expected, actual any) (float64, float64, error) {
+	expectedNumber, ok := toFloat(expected)
+	if !ok {
+		return 0, 0, fmt.Errorf("expected %v is %T, not a number", expected, expected)
+	}
+	actualNumber, ok := toFloat(actual)
+	if !ok {
+		return 0, 0, fmt.Errorf("actual %v is %T, not a number", actual, actual)
+	}
+
+	return expectedNumber, actualNumber, nil
+}
+
+func toFloat(number any) (float64, bool) {
+	value := reflect.ValueOf(number)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func isList(value reflect.Value) bool {
+	return value.Kind() == reflect.Slice || value.Kind() == reflect.Array
+}
+
+// listDifference returns the elements of the expected list the actual list does not have, and the elements of the
+// actual list the expected list does not have. Every element is matched once.
+func listDifference(expected, actual reflect.Value) ([]any, []any) {
+	matched := make([]bool, actual.Len())
+	missing := make([]any, 0)
+	for i := 0; i < expected.Len(); i++ {
+		element := expected.Index(i).Interface()
+		found := false
+		for j := 0; j < actual.Len(); j++ {
+			if !matched[j] && reflect.DeepEqual(element, actual.Index(j).Interface()) {
+				matched[j], found = true, true
+
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, element)
+		}
+	}
+
+	extra := make([]any, 0)
+	for j, ok := range matched {
+		if !ok {
+			extra = append(extra, actual.Index(j).Interface())
+		}
+	}
+
+	return missing, extra
+}
+
+// errorChain returns the messages of the error and the errors it wraps, such as "a" -> "b", or nil. The errors
+// wrapped by an error wrapping several, such as the ones of errors.Join, are listed in brackets.
+func errorChain(err error) string {
+	if err == nil {
+		return "nil"
+	}
+	chain := fmt.Sprintf("%q (%T)", err.Error(), err)
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		if wrapped := wrapper.Unwrap(); wrapped != nil {
+			chain += " -> " + errorChain(wrapped)
+		}
+	case interface{ Unwrap() []error }:
+		branches := make([]string, 0)
+		for _, wrapped := range wrapper.Unwrap() {
+			if wrapped != nil {
+				branches = append(branches, errorChain(wrapped))
+			}
+		}
+		chain += " -> [" + strings.Join(branches, ", ") + "]"
+	}
+
+	return chain
+}
@@ -0,0 +1,139 @@
+package assert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertions_ErrorAs(t *testing.T) {
+	t.Run("should set the target to the error in the chain", func(t *testing.T) {
+		var pathError *fs.PathError
+		err := fmt.Errorf("open config, error=%w", &fs.PathError{Op: "open", Path: "a.yaml", Err: fs.ErrNotExist})
+
+		require.Nil(t, Assert(context.Background()).ErrorAs(err, &pathError))
+		require.Equal(t, "a.yaml", pathError.Path)
+	})
+	t.Run("should return the chain if no error can be assigned to the target", func(t *testing.T) {
+		var pathError *fs.PathError
+		err := fmt.Errorf("open config, error=%w", errors.New("denied"))
+
+		require.EqualError(t, Assert(context.Background()).ErrorAs(err, &pathError),
+			`no error in the chain of "open config, error=denied" (*fmt.wrapError) -> "denied" (*errors.errorString) `+
+				`is *fs.PathError`)
+	})
+	t.Run("should return error for targets which are not pointers to errors", func(t *testing.T) {
+		var count int
+
+		require.EqualError(t, Assert(context.Background()).ErrorAs(errors.New("denied"), &count),
+			"target of ErrorAs must point to an interface or a type implementing error, got *int")
+		require.EqualError(t, Assert(context.Background()).ErrorAs(errors.New("denied"), nil),
+			"target of ErrorAs must be a non-nil pointer, got <nil>")
+	})
+}
+
+func TestAssertions_ErrorIs(t *testing.T) {
+	errNotFound := errors.New("not found")
+	errTimeout := errors.New("timeout")
+	errDenied := errors.New("denied")
+
+	t.Run("should find errors wrapped several times and joined", func(t *testing.T) {
+		err := fmt.Errorf("load order, error=%w", errors.Join(fmt.Errorf("read, error=%w", errNotFound), errTimeout))
+		assertions := Assert(context.Background())
+
+		require.Nil(t, assertions.ErrorIs(err, errNotFound))
+		require.Nil(t, assertions.ErrorChainContains(err, []error{errNotFound, errTimeout}))
+	})
+	t.Run("should return the chain with the targets it does not contain", func(t *testing.T) {
+		err := fmt.Errorf("load order, error=%w", errors.Join(errNotFound, errTimeout))
+
+		require.EqualError(t, Assert(context.Background()).ErrorChainContains(err, []error{errTimeout, errDenied},
+			"load order %d", 7),
+			`chain of "load order, error=not found\ntimeout" (*fmt.wrapError) -> `+
+				`"not found\ntimeout" (*errors.joinError) -> ["not found" (*errors.errorString), `+
+				`"timeout" (*errors.errorString)] does not contain "denied" (*errors.errorString): load order 7`)
+		require.EqualError(t, Assert(context.Background()).ErrorIs(errDenied, errTimeout, "login"),
+			`no error in the chain of "denied" (*errors.errorString) is "timeout" (*errors.errorString): login`)
+	})
+	t.Run("should not match errors by their message", func(t *testing.T) {
+		require.NotNil(t, Assert(context.Background()).ErrorIs(errors.New("timeout"), errTimeout))
+	})
+}
+
+func TestAssertions_Regexp(t *testing.T) {
+	t.Run("should match strings and formatted values", func(t *testing.T) {
+		assertions := Assert(context.Background())
+
+		require.Nil(t, assertions.Regexp(`^order-\d+$`, "order-42"))
+		require.Nil(t, assertions.Regexp(regexp.MustCompile(`^4\d$`), 42))
+		require.Nil(t, assertions.NotRegexp(`^order-\d+$`, "invoice-42"))
+	})
+	t.Run("should return error with the message if the value does not match", func(t *testing.T) {
+		assertions := Assert(context.Background())
+
+		require.EqualError(t, assertions.Regexp(`^order-\d+$`, "invoice-42", "order id"),
+			`"invoice-42" does not match "^order-\\d+$": order id`)
+		require.EqualError(t, assertions.NotRegexp(`^order`, "order-42"), `"order-42" matches "^order"`)
+		require.ErrorContains(t, assertions.Regexp(`(`, "order-42"), "could not compile pattern (")
+	})
+}
+
+func TestAssertions_InDelta(t *testing.T) {
+	t.Run("should compare numbers of any type within the delta", func(t *testing.T) {
+		assertions := Assert(context.Background())
+
+		require.Nil(t, assertions.InDelta(10, 10.4, 0.5))
+		require.Nil(t, assertions.InDelta(uint8(3), int64(4), 1))
+		require.EqualError(t, assertions.InDelta(10, 11.5, 0.5), "10 and 11.5 differ by 1.5, more than 0.5")
+		require.EqualError(t, assertions.InDelta("10", 10, 0.5), "expected 10 is string, not a number")
+	})
+	t.Run("should compare the relative error of numbers within the epsilon", func(t *testing.T) {
+		assertions := Assert(context.Background())
+
+		require.Nil(t, assertions.InEpsilon(200, 202, 0.01))
+		require.EqualError(t, assertions.InEpsilon(200, 210, 0.01),
+			"relative error of 210 to 200 is 0.05, more than 0.01")
+		require.EqualError(t, assertions.InEpsilon(0, 0.1, 0.01),
+			"relative error to the expected number 0 is not defined, use InDelta")
+	})
+}
+
+func TestAssertions_ElementsMatch(t *testing.T) {
+	t.Run("should ignore the order of the elements", func(t *testing.T) {
+		require.Nil(t, Assert(context.Background()).ElementsMatch([]int{1, 2, 2, 3}, [4]int{2, 3, 1, 2}))
+	})
+	t.Run("should return the missing and extra elements", func(t *testing.T) {
+		err := Assert(context.Background()).ElementsMatch([]string{"a", "b", "b"}, []string{"b", "c"}, "tags")
+
+		require.EqualError(t, err, "elements do not match: tags\n-\"a\"\n-\"b\"\n+\"c\"")
+	})
+}
+
+func TestAssertions_Subset(t *testing.T) {
+	t.Run("should check the elements of lists and the entries of maps", func(t *testing.T) {
+		assertions := Assert(context.Background())
+
+		require.Nil(t, assertions.Subset([]string{"a", "b", "c"}, []string{"c", "a"}))
+		require.Nil(t, assertions.Subset(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2}))
+	})
+	t.Run("should return the elements and entries the list or map does not contain", func(t *testing.T) {
+		assertions := Assert(context.Background())
+
+		require.EqualError(t, assertions.Subset([]string{"a", "b"}, []string{"b", "d"}),
+			"list does not contain the subset\n-\"d\"")
+		require.EqualError(t, assertions.Subset(map[string]int{"a": 1}, map[string]int{"a": 2}),
+			"map does not contain the subset\n-[\"a\"]: 2")
+		require.EqualError(t, assertions.Subset("ab", "a"), "Subset compares slices, arrays or maps, got string and string")
+	})
+	t.Run("should return error if the keys of the maps have different types", func(t *testing.T) {
+		assertions := Assert(context.Background())
+
+		require.EqualError(t, assertions.Subset(map[string]int{"a": 1}, map[int]int{1: 1}),
+			"Subset compares maps with keys of the same type, got map[string]int and map[int]int")
+	})
+}
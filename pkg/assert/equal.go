@@ -0,0 +1,258 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// diffContext is the number of equal lines shown around the changed lines of a string diff
+const diffContext = 3
+
+type (
+	// comparisonError is the error of Equal. It is a models.Comparison, so the compared values are copied to the
+	// failure of the step returning it.
+	comparisonError struct {
+		message          string
+		expected, actual any
+	}
+)
+
+// Equal returns an error if the actual value is not deeply equal to the expected value. The error describes how they
+// differ: a unified diff of the lines of multi-line strings, the paths of the differing fields, keys and elements of
+// structs, maps and slices, or both values otherwise. The message describes the values, and is formatted with
+// fmt.Sprintf if it has arguments.
+func (a *Assertions) Equal(expected, actual any, message ...any) error {
+	if reflect.DeepEqual(expected, actual) {
+		return nil
+	}
+
+	return &comparisonError{
+		message:  fmt.Sprintf("values are not equal%s\n%s", describe(message), Diff(expected, actual)),
+		expected: expected,
+		actual:   actual,
+	}
+}
+
+func (c *comparisonError) Error() string {
+	return c.message
+}
+
+// Expected returns the expected value, strings as they are and other values in Go syntax
+func (c *comparisonError) Expected() string {
+	return formatCompared(c.expected)
+}
+
+// Actual returns the actual value, see Expected
+func (c *comparisonError) Actual() string {
+	return formatCompared(c.actual)
+}
+
+func formatCompared(value any) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+
+	return fmt.Sprintf("%#v", value)
+}
+
+// Diff describes how the actual value differs from the expected value, see Equal
+func Diff(expected, actual any) string {
+	expectedText, expectedIsText := expected.(string)
+	actualText, actualIsText := actual.(string)
+	if expectedIsText && actualIsText && (strings.Contains(expectedText, "\n") || strings.Contains(actualText, "\n")) {
+		return diffLines(strings.Split(expectedText, "\n"), strings.Split(actualText, "\n"))
+	}
+
+	expectedValue, actualValue := reflect.ValueOf(expected), reflect.ValueOf(actual)
+	if expectedValue.IsValid() && actualValue.IsValid() && expectedValue.Type() == actualValue.Type() &&
+		isComposite(expectedValue) {
+		lines := make([]string, 0)
+		diffValues("", expectedValue, actualValue, &lines)
+		// values differing only in what diffValues does not compare, such as a nil and an empty slice, show both
+		if len(lines) > 0 {
+			return strings.Join(lines, "\n")
+		}
+	}
+
+	return fmt.Sprintf("expected: %#v\nactual:   %#v", expected, actual)
+}
+
+// diffLines returns the unified diff of the lines, where the lines removed from the expected lines start with - and
+// the lines added to them start with +
+func diffLines(expected, actual []string) string {
+	// lengths[i][j] is the length of the longest common subsequence of expected[i:] and actual[j:]
+	lengths := make([][]int, len(expected)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(actual)+1)
+	}
+	for i := len(expected) - 1; i >= 0; i-- {
+		for j := len(actual) - 1; j >= 0; j-- {
+			if expected[i] == actual[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	type edit struct {
+		mark byte
+		line string
+	}
+	edits := make([]edit, 0, len(expected)+len(actual))
+	i, j := 0, 0
+	for i < len(expected) || j < len(actual) {
+		switch {
+		case i < len(expected) && j < len(actual) && expected[i] == actual[j]:
+			edits = append(edits, edit{' ', expected[i]})
+			i++
+			j++
+		// removed lines are written before the lines added in their place
+		case i < len(expected) && (j == len(actual) || lengths[i+1][j] >= lengths[i][j+1]):
+			edits = append(edits, edit{'-', expected[i]})
+			i++
+		default:
+			edits = append(edits, edit{'+', actual[j]})
+			j++
+		}
+	}
+
+	builder := &strings.Builder{}
+	builder.WriteString("--- expected\n+++ actual")
+	expectedLine, actualLine := 1, 1
+	for start := 0; start < len(edits); {
+		if edits[start].mark == ' ' {
+			start++
+			expectedLine++
+			actualLine++
+
+			continue
+		}
+		// a hunk ends when more than twice the context of equal lines follow its last change
+		end, equal := start, 0
+		for index := start; index < len(edits) && equal <= 2*diffContext; index++ {
+			if edits[index].mark == ' ' {
+				equal++
+			} else {
+				equal, end = 0, index+1
+			}
+		}
+		first := max(0, start-diffContext)
+		last := min(len(edits), end+diffContext)
+
+		hunkExpected, hunkActual := expectedLine-(start-first), actualLine-(start-first)
+		removed, added := 0, 0
+		for _, e := range edits[first:last] {
+			if e.mark != '+' {
+				removed++
+			}
+			if e.mark != '-' {
+				added++
+			}
+		}
+		fmt.Fprintf(builder, "\n@@ -%d,%d +%d,%d @@", hunkExpected, removed, hunkActual, added)
+		for _, e := range edits[first:last] {
+			fmt.Fprintf(builder, "\n%c%s", e.mark, e.line)
+		}
+
+		for _, e := range edits[start:last] {
+			if e.mark != '+' {
+				expectedLine++
+			}
+			if e.mark != '-' {
+				actualLine++
+			}
+		}
+		start = last
+	}
+
+	return builder.String()
+}
+
+// isComposite reports whether the value is compared by its fields, keys or elements
+func isComposite(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return true
+	case reflect.Pointer:
+		return !value.IsNil() && isComposite(value.Elem())
+	default:
+		return false
+	}
+}
+
+// diffValues appends a line for every field, key and element of the values at the path which differs, where the
+// expected value starts with - and the actual value with +
+func diffValues(path string, expected, actual reflect.Value, lines *[]string) {
+	if !expected.IsValid() || !actual.IsValid() || expected.Type() != actual.Type() {
+		*lines = append(*lines, difference(path, expected, actual)...)
+
+		return
+	}
+
+	switch expected.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if expected.IsNil() || actual.IsNil() {
+			if expected.IsNil() != actual.IsNil() {
+				*lines = append(*lines, difference(path, expected, actual)...)
+			}
+
+			return
+		}
+		diffValues(path, expected.Elem(), actual.Elem(), lines)
+	case reflect.Struct:
+		for index := 0; index < expected.NumField(); index++ {
+			name := expected.Type().Field(index).Name
+			diffValues(path+"."+name, expected.Field(index), actual.Field(index), lines)
+		}
+	case reflect.Map:
+		keys := append(expected.MapKeys(), actual.MapKeys()...)
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		seen := make(map[string]bool)
+		for _, key := range keys {
+			keyPath := fmt.Sprintf("%s[%#v]", path, key)
+			if seen[keyPath] {
+				continue
+			}
+			seen[keyPath] = true
+			diffValues(keyPath, expected.MapIndex(key), actual.MapIndex(key), lines)
+		}
+	case reflect.Slice, reflect.Array:
+		for index := 0; index < max(expected.Len(), actual.Len()); index++ {
+			var expectedElement, actualElement reflect.Value
+			if index < expected.Len() {
+				expectedElement = expected.Index(index)
+			}
+			if index < actual.Len() {
+				actualElement = actual.Index(index)
+			}
+			diffValues(fmt.Sprintf("%s[%d]", path, index), expectedElement, actualElement, lines)
+		}
+	default:
+		// values of unexported fields can not be compared as interfaces, fmt formats the values they hold
+		if fmt.Sprintf("%#v", expected) != fmt.Sprintf("%#v", actual) {
+			*lines = append(*lines, difference(path, expected, actual)...)
+		}
+	}
+}
+
+// difference returns the lines of the differing values at the path. A missing value, such as the value of a key
+// only one of the maps has, has no line.
+func difference(path string, expected, actual reflect.Value) []string {
+	if len(path) == 0 {
+		path = "value"
+	}
+	lines := make([]string, 0, 2)
+	if expected.IsValid() {
+		lines = append(lines, fmt.Sprintf("-%s: %#v", path, expected))
+	}
+	if actual.IsValid() {
+		lines = append(lines, fmt.Sprintf("+%s: %#v", path, actual))
+	}
+
+	return lines
+}
@@ -0,0 +1,90 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertions_Equal(t *testing.T) {
+	type address struct {
+		City string
+		Zip  int
+	}
+	type customer struct {
+		Name    string
+		Address *address
+		Tags    []string
+		Limits  map[string]int
+	}
+
+	t.Run("should return nil for deeply equal values", func(t *testing.T) {
+		err := Assert(context.Background()).Equal(customer{Name: "Ann", Tags: []string{"vip"}},
+			customer{Name: "Ann", Tags: []string{"vip"}})
+
+		require.Nil(t, err)
+	})
+	t.Run("should return the paths of the differing fields, keys and elements of structs", func(t *testing.T) {
+		expected := customer{
+			Name:    "Ann",
+			Address: &address{City: "Berlin", Zip: 10115},
+			Tags:    []string{"vip"},
+			Limits:  map[string]int{"daily": 100, "monthly": 1000},
+		}
+		actual := customer{
+			Name:    "Ann",
+			Address: &address{City: "Munich", Zip: 10115},
+			Tags:    []string{"vip", "new"},
+			Limits:  map[string]int{"daily": 50, "weekly": 300},
+		}
+
+		err := Assert(context.Background()).Equal(expected, actual, "customer %s", "Ann")
+
+		require.EqualError(t, err, `values are not equal: customer Ann
+-.Address.City: "Berlin"
++.Address.City: "Munich"
++.Tags[1]: "new"
+-.Limits["daily"]: 100
++.Limits["daily"]: 50
+-.Limits["monthly"]: 1000
++.Limits["weekly"]: 300`)
+	})
+	t.Run("should return the unified diff of multi-line strings", func(t *testing.T) {
+		expected := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm"
+		actual := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\nn"
+
+		err := Assert(context.Background()).Equal(expected, actual)
+
+		require.EqualError(t, err, `values are not equal
+--- expected
++++ actual
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -11,3 +11,4 @@
+ k
+ l
+ m
++n`)
+	})
+	t.Run("should return both values of other types", func(t *testing.T) {
+		err := Assert(context.Background()).Equal(3, int64(3))
+
+		require.EqualError(t, err, "values are not equal\nexpected: 3\nactual:   3")
+	})
+	t.Run("should copy the compared values to the failure of the step", func(t *testing.T) {
+		err := Assert(context.Background()).Equal("paid", 3)
+
+		failure := models.NewFailure(fmt.Errorf("order is wrong, error=%w", err))
+
+		require.Equal(t, "paid", failure.Expected)
+		require.Equal(t, "3", failure.Actual)
+	})
+}
@@ -0,0 +1,129 @@
+// Package cacik is the stable import path of the cacik runtime. It re-exports the runner, the executor and the
+// models so integrations do not depend on the layout of the implementation packages.
+package cacik
+
+import (
+	"context"
+
+	"github.com/denizgursoy/cacik/pkg/assert"
+	"github.com/denizgursoy/cacik/pkg/executor"
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/denizgursoy/cacik/pkg/runner"
+)
+
+const (
+	StatusPassed    = models.StatusPassed
+	StatusFailed    = models.StatusFailed
+	StatusSkipped   = models.StatusSkipped
+	StatusUndefined = models.StatusUndefined
+
+	SkipCauseTag          = models.SkipCauseTag
+	SkipCausePrecondition = models.SkipCausePrecondition
+	SkipCauseHook         = models.SkipCauseHook
+	SkipCauseFailFast     = models.SkipCauseFailFast
+	SkipCausePending      = models.SkipCausePending
+	SkipCausePreviousStep = models.SkipCausePreviousStep
+)
+
+// ErrPending is returned from a step, optionally wrapped with the reason, to skip it and the rest of the scenario
+var ErrPending = models.ErrPending
+
+type (
+	Runner         = runner.CucumberRunner
+	Executor       = runner.Executor
+	Reporter       = runner.Reporter
+	MultiReporter  = runner.MultiReporter
+	StepExecutor   = executor.StepExecutor
+	HookExecutor   = executor.HookExecutor
+	StepDefinition = executor.StepDefinition
+
+	Config         = models.Config
+	Hooks          = models.Hooks
+	Status         = models.Status
+	SkipCause      = models.SkipCause
+	HookResult     = models.HookResult
+	StepResult     = models.StepResult
+	Failure        = models.Failure
+	ScenarioResult = models.ScenarioResult
+	FeatureResult  = models.FeatureResult
+	RunResult      = models.RunResult
+	StepUsage      = models.StepUsage
+	SkipError      = models.SkipError
+	Data           = models.Data
+	Logger         = models.Logger
+	Attachment     = models.Attachment
+	Table          = models.Table
+	Row            = models.Row
+	Args           = models.Args
+	Assertions     = assert.Assertions
+)
+
+// NewRunner creates a runner which executes scenarios with the default step executor
+func NewRunner() *Runner {
+	return runner.NewCucumberRunner(nil)
+}
+
+// NewRunnerWithExecutor creates a runner which executes scenarios with the executor
+func NewRunnerWithExecutor(exec Executor) *Runner {
+	return runner.NewCucumberRunner(exec)
+}
+
+func NewStepExecutor() *StepExecutor {
+	return executor.NewStepExecutor()
+}
+
+// SkipScenario returns an error which marks the scenario as skipped when returned from a BeforeScenario hook
+func SkipScenario(reason string) error {
+	return models.SkipScenario(reason)
+}
+
+// DataFrom returns the data store shared by the hooks and the steps of the scenario the context belongs to
+func DataFrom(ctx context.Context) *Data {
+	return models.DataFrom(ctx)
+}
+
+// LoggerFrom returns the logger of the scenario the context belongs to
+func LoggerFrom(ctx context.Context) *Logger {
+	return models.LoggerFrom(ctx)
+}
+
+// StepResultFrom returns the result of the step an AfterStep hook runs after, with its Failure if it failed
+func StepResultFrom(ctx context.Context) *StepResult {
+	return models.StepResultFrom(ctx)
+}
+
+// Attach attaches data such as a screenshot or a JSON payload to the running step or hook
+func Attach(ctx context.Context, name string, mediaType string, data []byte) {
+	models.Attach(ctx, name, mediaType, data)
+}
+
+// LoadRunResult reads a result saved with RunResult.Save
+func LoadRunResult(path string) (*RunResult, error) {
+	return models.LoadRunResult(path)
+}
+
+// MergeRunResults merges the results of several runs into one result
+func MergeRunResults(results ...*RunResult) *RunResult {
+	return models.MergeRunResults(results...)
+}
+
+// GetAs returns the value of the key in the data store of the scenario if it has the type T
+func GetAs[T any](ctx context.Context, key string) (T, bool) {
+	return models.GetAs[T](ctx, key)
+}
+
+// SetAs sets the value of the key in the data store of the scenario
+func SetAs[T any](ctx context.Context, key string, value T) {
+	models.SetAs(ctx, key, value)
+}
+
+// World returns the world created for the scenario by the World factory of the config, or the zero value of T if
+// the scenario has no world of the type
+func World[T any](ctx context.Context) T {
+	return models.WorldFrom[T](ctx)
+}
+
+// Assert returns the assertions of the scenario the context belongs to, such as Eventually and Consistently
+func Assert(ctx context.Context) *Assertions {
+	return assert.Assert(ctx)
+}
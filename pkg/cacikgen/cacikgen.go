@@ -0,0 +1,45 @@
+// Package cacikgen is the stable import path of the cacik code generator which creates the main file registering
+// the step functions found in @cacik comments.
+package cacikgen
+
+import (
+	"context"
+
+	"github.com/denizgursoy/cacik/internal/comment_parser"
+	"github.com/denizgursoy/cacik/internal/generator"
+)
+
+type (
+	GoCodeParser        = generator.GoCodeParser
+	Output              = generator.Output
+	Options             = generator.Options
+	SourceOptions       = generator.SourceOptions
+	FunctionLocator     = generator.FunctionLocator
+	StepFunctionLocator = generator.StepFunctionLocator
+	StepDoc             = generator.StepDoc
+	ParameterDoc        = generator.ParameterDoc
+	Diagnosis           = generator.Diagnosis
+)
+
+// Generate parses the go files of the directories given with the -code flag, or the working directory, and
+// creates the main file
+func Generate(ctx context.Context) error {
+	return generator.StartGenerator(ctx, NewGoSourceFileParser())
+}
+
+// Diagnose checks that the environment can generate and run the scenarios of the module in the working directory:
+// the go version, the gherkin modules, the time zone database, the feature directories, the step definitions and
+// whether the generated file is up to date
+func Diagnose(ctx context.Context) []*Diagnosis {
+	return generator.NewDoctor(NewGoSourceFileParser()).Diagnose(ctx)
+}
+
+// Failed reports whether any check of the diagnoses failed
+func Failed(diagnoses []*Diagnosis) bool {
+	return generator.Failed(diagnoses)
+}
+
+// NewGoSourceFileParser returns the parser finding step functions in @cacik comments
+func NewGoSourceFileParser() GoCodeParser {
+	return comment_parser.NewGoSourceFileParser()
+}
@@ -0,0 +1,194 @@
+// Package config_file reads cacik.yaml, the file configuring both the generator and the runner of a project in
+// one place.
+package config_file
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ModuleFile = "go.mod"
+)
+
+var (
+	// FileNames are the names of the config file searched in order
+	FileNames = []string{"cacik.yaml", "cacik.yml"}
+)
+
+type (
+	File struct {
+		// Features are the feature directories or paths executed by the runner
+		Features []string `yaml:"features"`
+		// Tags are the tags used if the runner is started without tags
+		Tags []string `yaml:"tags"`
+		// Format is the name of the built-in reporter writing to the standard output
+		Format string `yaml:"format"`
+		// Symbols and Palette style the statuses of the console output, see reporter.SymbolsByName and
+		// reporter.PaletteByName
+		Symbols string `yaml:"symbols"`
+		Palette string `yaml:"palette"`
+		// Verbosity is how much of the run the console output writes, see reporter.VerbosityByName
+		Verbosity string `yaml:"verbosity"`
+		// Locations sets whether the console output writes the locations of scenarios and failed steps
+		Locations *bool `yaml:"locations"`
+		// SlowStepThreshold marks the steps taking longer as slow, such as 2s
+		SlowStepThreshold time.Duration `yaml:"slowStepThreshold"`
+		// Diagnostics measures the allocations and goroutines of every scenario
+		Diagnostics bool `yaml:"diagnostics"`
+		// StrictKeywords fails steps using a definition registered for another keyword instead of warning
+		StrictKeywords bool      `yaml:"strictKeywords"`
+		Reports        Reports   `yaml:"reports"`
+		Generator      Generator `yaml:"generator"`
+	}
+
+	Reports struct {
+		Directory string `yaml:"directory"`
+		Markdown  string `yaml:"markdown"`
+		HTML      string `yaml:"html"`
+		// HTMLOptions brand the HTML report, see reporter.HTMLReportOptions
+		HTMLOptions HTMLOptions `yaml:"htmlOptions"`
+		// Cucumber is the file the Cucumber JSON report is written to
+		Cucumber string `yaml:"cucumber"`
+		// History is the directory every run appends its summary to, see models.AppendHistory
+		History  string `yaml:"history"`
+		Result   string `yaml:"result"`
+		Snippets string `yaml:"snippets"`
+		Rerun    string `yaml:"rerun"`
+		Usage    string `yaml:"usage"`
+		Badge    string `yaml:"badge"`
+		// Metrics is the file the Prometheus metrics of the run are written to
+		Metrics     string      `yaml:"metrics"`
+		Pushgateway Pushgateway `yaml:"pushgateway"`
+		Trace       string      `yaml:"trace"`
+		Manifest    string      `yaml:"manifest"`
+	}
+
+	HTMLOptions struct {
+		Title            string `yaml:"title"`
+		Logo             string `yaml:"logo"`
+		Theme            string `yaml:"theme"`
+		EmbedAttachments bool   `yaml:"embedAttachments"`
+		EmbedResult      bool   `yaml:"embedResult"`
+	}
+
+	// Pushgateway is the Prometheus Pushgateway the metrics of the run are pushed to with the job
+	Pushgateway struct {
+		URL string `yaml:"url"`
+		Job string `yaml:"job"`
+	}
+
+	Generator struct {
+		// Code are the directories searched for step functions
+		Code    []string `yaml:"code"`
+		Output  string   `yaml:"output"`
+		Package string   `yaml:"package"`
+		Test    bool     `yaml:"test"`
+		// Include and Exclude are glob patterns selecting the go files searched for step functions
+		Include []string `yaml:"include"`
+		Exclude []string `yaml:"exclude"`
+		// Tags are the build tags the go files are matched with
+		Tags []string `yaml:"tags"`
+		// TimeZoneData embeds the time zone database in the generated file
+		TimeZoneData bool `yaml:"tzdata"`
+		// Docs is the markdown file the documentation of the steps is written to
+		Docs string `yaml:"docs"`
+	}
+)
+
+// Load reads the config file at path. Relative paths of the file are resolved against its directory.
+func Load(path string) (*File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read config file %s, error=%w", path, err)
+	}
+
+	file := &File{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid config file %s, error=%w", path, err)
+	}
+	file.resolvePaths(filepath.Dir(path))
+
+	return file, nil
+}
+
+// resolvePaths makes the relative feature, report and generator paths relative to the directory of the config
+// file instead of the working directory. The logo of the HTML report is relative to the report, so it is kept.
+// If a report directory is set, the report files are kept relative too, so that they are written to the directory
+// of every run.
+func (f *File) resolvePaths(directory string) {
+	resolve := func(path *string) {
+		if len(*path) > 0 && !filepath.IsAbs(*path) {
+			*path = filepath.Join(directory, *path)
+		}
+	}
+
+	for i := range f.Features {
+		resolve(&f.Features[i])
+	}
+	for _, path := range []*string{&f.Reports.Directory, &f.Reports.History, &f.Generator.Output,
+		&f.Generator.Docs} {
+		resolve(path)
+	}
+	if len(f.Reports.Directory) == 0 {
+		for _, path := range []*string{&f.Reports.Markdown, &f.Reports.HTML, &f.Reports.Cucumber, &f.Reports.Result,
+			&f.Reports.Snippets, &f.Reports.Rerun, &f.Reports.Usage, &f.Reports.Badge, &f.Reports.Metrics,
+			&f.Reports.Trace, &f.Reports.Manifest} {
+			resolve(path)
+		}
+	}
+	for i := range f.Generator.Code {
+		resolve(&f.Generator.Code[i])
+	}
+}
+
+// Find returns the path of the config file in the root of the module containing the directory. It returns false
+// if the directory is not in a module or the module has no config file.
+func Find(directory string) (string, bool) {
+	directory, err := filepath.Abs(directory)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(directory, ModuleFile)); err == nil {
+			for _, name := range FileNames {
+				path := filepath.Join(directory, name)
+				if _, err := os.Stat(path); err == nil {
+					return path, true
+				}
+			}
+
+			return "", false
+		}
+		parent := filepath.Dir(directory)
+		if parent == directory {
+			return "", false
+		}
+		directory = parent
+	}
+}
+
+// FindAndLoad loads the config file of the module containing the working directory. It returns nil without an
+// error if there is no config file.
+func FindAndLoad() (*File, error) {
+	directory, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	path, ok := Find(directory)
+	if !ok {
+		return nil, nil
+	}
+
+	return Load(path)
+}
@@ -0,0 +1,75 @@
+package config_file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("should read the settings", func(t *testing.T) {
+		file, err := Load("testdata/cacik.yaml")
+
+		require.Nil(t, err)
+		require.Equal(t, &File{
+			Features:          []string{filepath.Join("testdata", "features")},
+			Tags:              []string{"smoke"},
+			Format:            "pretty",
+			SlowStepThreshold: 1500 * time.Millisecond,
+			Reports: Reports{Directory: filepath.Join("testdata", "reports"),
+				Markdown: "report.md"},
+			Generator: Generator{
+				Code:    []string{filepath.Join("testdata", "steps")},
+				Output:  filepath.Join("testdata", "cacik_test.go"),
+				Package: "checkout",
+				Test:    true,
+			},
+		}, file)
+	})
+	t.Run("should keep absolute paths", func(t *testing.T) {
+		directory := t.TempDir()
+		path := filepath.Join(directory, "cacik.yaml")
+		features := filepath.Join(t.TempDir(), "features")
+		require.Nil(t, os.WriteFile(path, []byte("features: ["+features+", local]\n"), 0o644))
+
+		file, err := Load(path)
+
+		require.Nil(t, err)
+		require.Equal(t, []string{features, filepath.Join(directory, "local")}, file.Features)
+	})
+	t.Run("should return error for unknown settings", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cacik.yaml")
+		require.Nil(t, os.WriteFile(path, []byte("featurs: [features]\n"), 0o644))
+
+		_, err := Load(path)
+
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "field featurs not found")
+	})
+}
+
+func TestFind(t *testing.T) {
+	t.Run("should find the config file in the root of the module", func(t *testing.T) {
+		root := t.TempDir()
+		nested := filepath.Join(root, "a", "b")
+		require.Nil(t, os.MkdirAll(nested, 0o755))
+		require.Nil(t, os.WriteFile(filepath.Join(root, ModuleFile), []byte("module a\n"), 0o644))
+		require.Nil(t, os.WriteFile(filepath.Join(root, "cacik.yml"), []byte("tags: [smoke]\n"), 0o644))
+
+		path, ok := Find(nested)
+
+		require.True(t, ok)
+		require.Equal(t, filepath.Join(root, "cacik.yml"), path)
+	})
+	t.Run("should not find a config file if the module has none", func(t *testing.T) {
+		root := t.TempDir()
+		require.Nil(t, os.WriteFile(filepath.Join(root, ModuleFile), []byte("module a\n"), 0o644))
+
+		_, ok := Find(root)
+
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,309 @@
+package converter
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DurationPattern matches the durations accepted by ParseDuration, such as 1h30m, 250ms, 2d12h, 1.5µs or
+	// 3 weeks
+	DurationPattern = `[-+]?(?:\d+\s*(?:weeks?|months?|years?)\b|(?:\d+(?:\.\d+)?\s*(?:d|h|ms|m|s|us|µs|μs|ns))+)`
+)
+
+type (
+	// NumberFormat describes how numbers are written in feature files
+	NumberFormat struct {
+		// ThousandsSeparator separates groups of three digits, it is not accepted if empty
+		ThousandsSeparator string
+		// DecimalSeparator separates the fraction of a float
+		DecimalSeparator string
+	}
+)
+
+var (
+	// ErrOutOfRange is wrapped by the errors of dates and times with a component out of its range, such as
+	// 2024-02-30 or 25:00, which are rejected instead of being normalized to another date or time
+	ErrOutOfRange = errors.New("out of range")
+
+	// EnglishNumbers accepts numbers such as 1,234.56
+	EnglishNumbers = NumberFormat{ThousandsSeparator: ",", DecimalSeparator: "."}
+	// EuropeanNumbers accepts numbers such as 1.234,56
+	EuropeanNumbers = NumberFormat{ThousandsSeparator: ".", DecimalSeparator: ","}
+
+	// Numbers is the format of the numbers parsed by ParseInt, ParseUint and ParseFloat
+	Numbers = EnglishNumbers
+
+	// BoolValues maps the lower case words accepted by ParseBool to their value. Domain specific pairs can be
+	// added with AddBoolSynonyms, which guards the map with boolValuesMutex, so it can be called while steps
+	// convert their arguments.
+	BoolValues = map[string]bool{
+		"true": true, "false": false,
+		"t": true, "f": false,
+		"1": true, "0": false,
+		"yes": true, "no": false,
+		"y": true, "n": false,
+		"on": true, "off": false,
+		"enabled": true, "disabled": false,
+	}
+	boolValuesMutex sync.RWMutex
+
+	// ListSeparator separates the items of the lists split by SplitList, such as "a, b, c"
+	ListSeparator = ","
+	// ListSeparators are the separators of the lists captured by the parameter types which use another separator
+	// than ListSeparator, such as ListSeparators["word"] = "|". The key of capture groups written as regular
+	// expressions is the empty string, as for {}.
+	ListSeparators = map[string]string{}
+
+	// DayLength is the duration of a day unit in ParseDuration
+	DayLength = 24 * time.Hour
+
+	// Now is the clock the calendar units of ParseDuration are relative to
+	Now = time.Now
+
+	dayPattern      = regexp.MustCompile(`(\d+(?:\.\d+)?)d`)
+	calendarPattern = regexp.MustCompile(`^([-+]?\d+)\s*(weeks?|months?|years?)$`)
+
+	// microsecondUnits are the spellings of the microsecond unit normalized to us, the micro sign U+00B5 and the
+	// greek letter mu U+03BC
+	microsecondUnits = strings.NewReplacer("µs", "us", "μs", "us")
+
+	// groupingPatterns caches the regular expression of NumberFormat.groupingPattern for each format
+	groupingPatterns sync.Map
+
+	// TimeLayouts are the layouts tried in order by ParseTime
+	TimeLayouts = []string{
+		time.RFC3339Nano,
+		time.RFC3339,
+		time.DateTime,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04",
+		time.DateOnly,
+		time.TimeOnly,
+		"15:04",
+	}
+)
+
+// ParseInt parses the value as an integer written in the Numbers format, such as 1,234
+func ParseInt(value string, bitSize int) (int64, error) {
+	normalized, err := Numbers.normalize(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(normalized, 10, bitSize)
+}
+
+// ParseUint parses the value as an unsigned integer written in the Numbers format
+func ParseUint(value string, bitSize int) (uint64, error) {
+	normalized, err := Numbers.normalize(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseUint(normalized, 10, bitSize)
+}
+
+// ParseFloat parses the value as a float written in the Numbers format, such as 1,234.56
+func ParseFloat(value string, bitSize int) (float64, error) {
+	normalized, err := Numbers.normalize(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseFloat(normalized, bitSize)
+}
+
+// ParseBool parses the value with the case-insensitive words of BoolValues
+func ParseBool(value string) (bool, error) {
+	boolValuesMutex.RLock()
+	parsed, ok := BoolValues[strings.ToLower(strings.TrimSpace(value))]
+	boolValuesMutex.RUnlock()
+	if ok {
+		return parsed, nil
+	}
+
+	return false, fmt.Errorf("could not parse %q as bool", value)
+}
+
+// AddBoolSynonyms makes ParseBool accept the words, such as active and inactive, as true and false. It returns an
+// error without adding either word if a word is already accepted with the opposite value, such as yes as false.
+func AddBoolSynonyms(trueWord, falseWord string) error {
+	trueWord, falseWord = strings.ToLower(trueWord), strings.ToLower(falseWord)
+
+	if trueWord == falseWord {
+		return fmt.Errorf("could not add %q as both true and false", trueWord)
+	}
+
+	boolValuesMutex.Lock()
+	defer boolValuesMutex.Unlock()
+	if value, ok := BoolValues[trueWord]; ok && !value {
+		return fmt.Errorf("could not add %q as true, it is accepted as false", trueWord)
+	}
+	if value, ok := BoolValues[falseWord]; ok && value {
+		return fmt.Errorf("could not add %q as false, it is accepted as true", falseWord)
+	}
+	BoolValues[trueWord] = true
+	BoolValues[falseWord] = false
+
+	return nil
+}
+
+// SplitList splits the value captured by the parameter type into its items with the list separator of the type,
+// removing the white space around the items. An empty value is an empty list.
+func SplitList(value string, parameterType string) []string {
+	separator, ok := ListSeparators[parameterType]
+	if !ok {
+		separator = ListSeparator
+	}
+	if len(strings.TrimSpace(value)) == 0 {
+		return []string{}
+	}
+
+	items := strings.Split(value, separator)
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+	}
+
+	return items
+}
+
+// ParseTime parses the value with the first matching layout of TimeLayouts. A value written in a layout with a
+// component out of range, such as 2024-02-30 or 25:00, is rejected with an error wrapping ErrOutOfRange.
+func ParseTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	var outOfRange string
+	for _, layout := range TimeLayouts {
+		parsed, err := time.Parse(layout, value)
+		if err == nil {
+			return parsed, nil
+		}
+		// the range error is reported only if the value is written in the layout, 30 March is not an hour
+		var parseError *time.ParseError
+		if errors.As(err, &parseError) && strings.HasSuffix(parseError.Message, " out of range") &&
+			len(outOfRange) == 0 && digitShape(value) == digitShape(layout) {
+			outOfRange = strings.TrimSuffix(strings.TrimPrefix(parseError.Message, ": "), " out of range")
+		}
+	}
+	if len(outOfRange) > 0 {
+		return time.Time{}, fmt.Errorf("could not parse %q as time, %s %w", value, outOfRange, ErrOutOfRange)
+	}
+
+	return time.Time{}, fmt.Errorf("could not parse %q as time", value)
+}
+
+// digitShape replaces every digit of the value with 0
+func digitShape(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return '0'
+		}
+
+		return r
+	}, value)
+}
+
+// ParseDuration parses the value like time.ParseDuration after removing white space and normalizing the spellings
+// of microseconds. It also accepts a day unit, such as 2d12h, with days of DayLength, and a number of weeks,
+// months or years, such as 3 weeks, which is converted to the duration from Now to the same time on that date.
+func ParseDuration(value string) (time.Duration, error) {
+	return Settings{}.ParseDuration(value)
+}
+
+// ParseDuration works like the ParseDuration function with calendar units relative to the clock of the settings
+func (s Settings) ParseDuration(value string) (time.Duration, error) {
+	if match := calendarPattern.FindStringSubmatch(strings.TrimSpace(value)); match != nil {
+		return parseCalendarDuration(s.Time(), match[1], match[2])
+	}
+
+	normalized := microsecondUnits.Replace(strings.Join(strings.Fields(value), ""))
+	sign := time.Duration(1)
+	if strings.HasPrefix(normalized, "-") {
+		sign = -1
+	}
+	unsigned := strings.TrimPrefix(strings.TrimPrefix(normalized, "-"), "+")
+
+	days := time.Duration(0)
+	if location := dayPattern.FindStringSubmatchIndex(unsigned); location != nil && location[0] == 0 {
+		count, err := strconv.ParseFloat(unsigned[location[2]:location[3]], 64)
+		if err != nil {
+			return 0, fmt.Errorf("could not parse %q as duration", value)
+		}
+		days = time.Duration(count * float64(DayLength))
+		unsigned = unsigned[location[1]:]
+		if len(unsigned) == 0 {
+			return sign * days, nil
+		}
+	}
+
+	parsed, err := time.ParseDuration(unsigned)
+	if err != nil || strings.HasPrefix(unsigned, "-") || strings.HasPrefix(unsigned, "+") {
+		return 0, fmt.Errorf("could not parse %q as duration", value)
+	}
+
+	return sign * (days + parsed), nil
+}
+
+// parseCalendarDuration converts the count of the calendar unit to a duration relative to now, so a month is as
+// long as the month which starts now
+func parseCalendarDuration(now time.Time, count, unit string) (time.Duration, error) {
+	n, err := strconv.Atoi(count)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse %q as duration", count+" "+unit)
+	}
+
+	switch strings.TrimSuffix(unit, "s") {
+	case "week":
+		return now.AddDate(0, 0, 7*n).Sub(now), nil
+	case "month":
+		return now.AddDate(0, n, 0).Sub(now), nil
+	default:
+		return now.AddDate(n, 0, 0).Sub(now), nil
+	}
+}
+
+// normalize removes the thousands separators and replaces the decimal separator with a dot, so that the value can
+// be parsed by strconv. Values with a thousands separator are rejected unless their digits are grouped by three,
+// such as 1.5 or 1.23 written with EuropeanNumbers.
+func (f NumberFormat) normalize(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	decimal := f.decimalSeparator()
+
+	if len(f.ThousandsSeparator) > 0 && strings.Contains(value, f.ThousandsSeparator) {
+		if !f.groupingPattern().MatchString(value) {
+			return "", fmt.Errorf("could not parse %q as number, digits must be grouped by three with %q",
+				value, f.ThousandsSeparator)
+		}
+		value = strings.ReplaceAll(value, f.ThousandsSeparator, "")
+	}
+
+	return strings.Replace(value, decimal, ".", 1), nil
+}
+
+// groupingPattern returns the regular expression matching the numbers whose digits are grouped by the thousands
+// separator. It is compiled once for each format.
+func (f NumberFormat) groupingPattern() *regexp.Regexp {
+	if pattern, ok := groupingPatterns.Load(f); ok {
+		return pattern.(*regexp.Regexp)
+	}
+
+	decimal := f.decimalSeparator()
+	pattern, _ := groupingPatterns.LoadOrStore(f, regexp.MustCompile(`^[-+]?\d{1,3}(?:`+
+		regexp.QuoteMeta(f.ThousandsSeparator)+`\d{3})+(?:`+regexp.QuoteMeta(decimal)+`\d*)?$`))
+
+	return pattern.(*regexp.Regexp)
+}
+
+// decimalSeparator returns the decimal separator of the format, a dot if it is not set
+func (f NumberFormat) decimalSeparator() string {
+	if len(f.DecimalSeparator) == 0 {
+		return "."
+	}
+
+	return f.DecimalSeparator
+}
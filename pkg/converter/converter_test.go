@@ -0,0 +1,214 @@
+package converter
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTime(t *testing.T) {
+	t.Run("should parse supported layouts", func(t *testing.T) {
+		expected := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+		parsed, err := ParseTime("2024-02-29")
+
+		require.Nil(t, err)
+		require.Equal(t, expected, parsed)
+	})
+	t.Run("should return error for unknown layouts", func(t *testing.T) {
+		_, err := ParseTime("29 Feb")
+
+		require.NotNil(t, err)
+	})
+	t.Run("should reject components out of range instead of normalizing them", func(t *testing.T) {
+		_, err := ParseTime("2023-02-29")
+
+		require.ErrorIs(t, err, ErrOutOfRange)
+		require.EqualError(t, err, `could not parse "2023-02-29" as time, day out of range`)
+
+		_, err = ParseTime("25:00")
+
+		require.EqualError(t, err, `could not parse "25:00" as time, hour out of range`)
+	})
+}
+
+func TestSplitList(t *testing.T) {
+	t.Run("should split the list with the separator of the parameter type", func(t *testing.T) {
+		ListSeparators["word"] = "|"
+		defer delete(ListSeparators, "word")
+
+		require.Equal(t, []string{"a", "b", "c"}, SplitList("a, b ,c", ""))
+		require.Equal(t, []string{"a,b", "c"}, SplitList("a,b|c", "word"))
+		require.Equal(t, []string{}, SplitList(" ", ""))
+	})
+}
+
+func TestParseInt(t *testing.T) {
+	t.Run("should ignore surrounding white space", func(t *testing.T) {
+		parsed, err := ParseInt(" 42 ", 64)
+
+		require.Nil(t, err)
+		require.Equal(t, int64(42), parsed)
+	})
+	t.Run("should accept thousands separators", func(t *testing.T) {
+		parsed, err := ParseInt("1,234", 64)
+
+		require.Nil(t, err)
+		require.Equal(t, int64(1234), parsed)
+	})
+}
+
+func TestParseFloat(t *testing.T) {
+	t.Run("should accept thousands separators", func(t *testing.T) {
+		parsed, err := ParseFloat("1,234,567.5", 64)
+
+		require.Nil(t, err)
+		require.Equal(t, 1234567.5, parsed)
+	})
+	t.Run("should use the configured number format", func(t *testing.T) {
+		defer func(numbers NumberFormat) { Numbers = numbers }(Numbers)
+		Numbers = EuropeanNumbers
+
+		parsed, err := ParseFloat("1.234,56", 64)
+		require.Nil(t, err)
+		require.Equal(t, 1234.56, parsed)
+
+		integer, err := ParseInt("-1.234", 64)
+		require.Nil(t, err)
+		require.Equal(t, int64(-1234), integer)
+	})
+	t.Run("should return error for wrongly grouped digits", func(t *testing.T) {
+		for _, value := range []string{"1,23", "12,3456", ",123"} {
+			_, err := ParseFloat(value, 64)
+
+			require.NotNil(t, err, value)
+		}
+	})
+	t.Run("should return error for wrongly grouped digits in the configured number format", func(t *testing.T) {
+		defer func(numbers NumberFormat) { Numbers = numbers }(Numbers)
+		Numbers = EuropeanNumbers
+
+		for _, value := range []string{"1.5", "1.23", "12.3456"} {
+			_, err := ParseFloat(value, 64)
+			require.NotNil(t, err, value)
+
+			_, err = ParseInt(value, 64)
+			require.NotNil(t, err, value)
+		}
+	})
+}
+
+func TestParseBool(t *testing.T) {
+	t.Run("should parse synonyms case-insensitively", func(t *testing.T) {
+		values := map[string]bool{"TRUE": true, " yes ": true, "On": true, "enabled": true, "no": false, "off": false}
+
+		for value, expected := range values {
+			parsed, err := ParseBool(value)
+
+			require.Nil(t, err, value)
+			require.Equal(t, expected, parsed, value)
+		}
+	})
+	t.Run("should parse added synonyms", func(t *testing.T) {
+		defer func() {
+			delete(BoolValues, "active")
+			delete(BoolValues, "inactive")
+		}()
+		require.Nil(t, AddBoolSynonyms("Active", "Inactive"))
+
+		active, err := ParseBool("active")
+		require.Nil(t, err)
+		require.True(t, active)
+
+		inactive, err := ParseBool("INACTIVE")
+		require.Nil(t, err)
+		require.False(t, inactive)
+	})
+	t.Run("should return error for synonyms accepted with the opposite value", func(t *testing.T) {
+		require.EqualError(t, AddBoolSynonyms("No", "nope"), `could not add "no" as true, it is accepted as false`)
+		require.EqualError(t, AddBoolSynonyms("sure", "Yes"), `could not add "yes" as false, it is accepted as true`)
+		require.EqualError(t, AddBoolSynonyms("maybe", "Maybe"), `could not add "maybe" as both true and false`)
+
+		no, err := ParseBool("no")
+		require.Nil(t, err)
+		require.False(t, no)
+		_, err = ParseBool("nope")
+		require.NotNil(t, err)
+	})
+	t.Run("should return error for unknown words", func(t *testing.T) {
+		_, err := ParseBool("maybe")
+
+		require.EqualError(t, err, `could not parse "maybe" as bool`)
+	})
+}
+
+func TestParseDuration(t *testing.T) {
+	t.Run("should parse durations with normalized units", func(t *testing.T) {
+		durations := map[string]time.Duration{
+			"1h30m":    90 * time.Minute,
+			" 1h 30m ": 90 * time.Minute,
+			"5us":      5 * time.Microsecond,
+			"5\u00b5s": 5 * time.Microsecond,
+			"5\u03bcs": 5 * time.Microsecond,
+			"2d12h":    60 * time.Hour,
+			"1.5d":     36 * time.Hour,
+			"-2d":      -48 * time.Hour,
+		}
+
+		for value, expected := range durations {
+			parsed, err := ParseDuration(value)
+
+			require.Nil(t, err, value)
+			require.Equal(t, expected, parsed, value)
+		}
+	})
+	t.Run("should reject the micro sign decoded as Latin-1", func(t *testing.T) {
+		_, err := ParseDuration("5\u00c2\u00b5s")
+
+		require.NotNil(t, err)
+		require.False(t, regexp.MustCompile(`^`+DurationPattern+`$`).MatchString("5\u00c2\u00b5s"))
+	})
+	t.Run("should use the configured day length", func(t *testing.T) {
+		defer func(dayLength time.Duration) { DayLength = dayLength }(DayLength)
+		DayLength = 8 * time.Hour
+
+		parsed, err := ParseDuration("2d")
+
+		require.Nil(t, err)
+		require.Equal(t, 16*time.Hour, parsed)
+	})
+	t.Run("should convert calendar units relative to the clock", func(t *testing.T) {
+		defer func(now func() time.Time) { Now = now }(Now)
+		Now = func() time.Time { return time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC) }
+		durations := map[string]time.Duration{
+			"3 weeks":  21 * 24 * time.Hour,
+			"1 month":  31 * 24 * time.Hour,
+			"2 months": 60 * 24 * time.Hour,
+			"1 year":   366 * 24 * time.Hour,
+			"-1 week":  -7 * 24 * time.Hour,
+		}
+
+		for value, expected := range durations {
+			parsed, err := ParseDuration(value)
+
+			require.Nil(t, err, value)
+			require.Equal(t, expected, parsed, value)
+		}
+	})
+	t.Run("should return error for invalid durations", func(t *testing.T) {
+		for _, value := range []string{"", "d", "2d-3h", "3 fortnights", "12"} {
+			_, err := ParseDuration(value)
+
+			require.NotNil(t, err, value)
+		}
+	})
+	t.Run("should match the durations with DurationPattern", func(t *testing.T) {
+		pattern := regexp.MustCompile(`^` + DurationPattern + `$`)
+
+		for _, value := range []string{"1h30m", "2d12h", "250ms", "1.5\u00b5s", "-5m", "3 weeks", "1 month"} {
+			require.True(t, pattern.MatchString(value), value)
+		}
+	})
+}
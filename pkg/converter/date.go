@@ -0,0 +1,218 @@
+package converter
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// NowPattern matches the {now} placeholder of ParseDate with an optional duration added or subtracted, such as
+	// {now}, {now} + 2d or {now} - 1 month
+	NowPattern = `\{now\}(?:\s*[-+]\s*(?:` + DurationPattern + `))?`
+)
+
+var (
+	// monthNames maps the lower case month names and abbreviations of every registered language to their month by
+	// language. It is guarded by monthNamesMutex like monthLanguage, the language of SetMonthLanguage.
+	monthNames      = make(map[string]map[string]time.Month)
+	monthLanguage   string
+	monthNamesMutex sync.RWMutex
+
+	nowDate        = regexp.MustCompile(`^\{now\}(?:\s*([-+])\s*(.+))?$`)
+	dayFirstDate   = regexp.MustCompile(`^(\d{1,2})\.?\s+(\pL+)\.?,?\s+(\d{4})$`)
+	monthFirstDate = regexp.MustCompile(`^(\pL+)\.?\s+(\d{1,2}),?\s+(\d{4})$`)
+)
+
+func init() {
+	RegisterMonthNames("en", []string{"January", "February", "March", "April", "May", "June", "July", "August",
+		"September", "October", "November", "December"})
+}
+
+// RegisterMonthNames registers the names of the months of a language, from January to December, so written dates
+// such as "3 März 2024" can be parsed. The first three letters of every name are registered as its abbreviation.
+// The names replace the ones registered for the language before. Month names must be registered before the step
+// definitions using {date}.
+func RegisterMonthNames(language string, names []string) {
+	months := make(map[string]time.Month, 2*len(names))
+	for i, name := range names {
+		if i >= 12 {
+			break
+		}
+		lower := strings.ToLower(name)
+		months[lower] = time.Month(i + 1)
+		if runes := []rune(lower); len(runes) > 3 {
+			months[string(runes[:3])] = time.Month(i + 1)
+		}
+	}
+
+	monthNamesMutex.Lock()
+	defer monthNamesMutex.Unlock()
+	monthNames[language] = months
+}
+
+// SetMonthLanguage sets the language whose month names are accepted in addition to English, such as the language
+// of models.Config. The month names of every registered language are accepted if it is empty, which is the
+// default. It must be set before the step definitions using {date}.
+func SetMonthLanguage(language string) error {
+	if err := CheckMonthLanguage(language); err != nil {
+		return err
+	}
+
+	monthNamesMutex.Lock()
+	defer monthNamesMutex.Unlock()
+	monthLanguage = language
+
+	return nil
+}
+
+// CheckMonthLanguage returns an error if the month names of the language are not registered. The empty language
+// selecting every registered language is valid.
+func CheckMonthLanguage(language string) error {
+	monthNamesMutex.RLock()
+	defer monthNamesMutex.RUnlock()
+	if _, ok := monthNames[language]; len(language) > 0 && !ok {
+		return fmt.Errorf("month names of language %s are not registered, register them with RegisterMonthNames",
+			language)
+	}
+
+	return nil
+}
+
+// MonthNamePattern returns a regular expression matching the month names and abbreviations of the accepted
+// languages
+func MonthNamePattern() string {
+	monthNamesMutex.RLock()
+	defer monthNamesMutex.RUnlock()
+
+	unique := make(map[string]bool)
+	for _, language := range acceptedLanguages("") {
+		for name := range monthNames[language] {
+			unique[name] = true
+		}
+	}
+	names := make([]string, 0, len(unique))
+	for name := range unique {
+		names = append(names, regexp.QuoteMeta(name))
+	}
+	// longer names first, so that a name is not matched by its abbreviation
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+
+		return names[i] < names[j]
+	})
+
+	return `(?i:` + strings.Join(names, "|") + `)`
+}
+
+// lookupMonth returns the month of the lower case name in the first language accepted with the language which has
+// it
+func lookupMonth(name, language string) (time.Month, bool) {
+	monthNamesMutex.RLock()
+	defer monthNamesMutex.RUnlock()
+
+	for _, accepted := range acceptedLanguages(language) {
+		if month, ok := monthNames[accepted][name]; ok {
+			return month, true
+		}
+	}
+
+	return 0, false
+}
+
+// acceptedLanguages returns the language, or the language of SetMonthLanguage if it is empty, and English. If
+// neither is set, it returns English and every other registered language in order. The caller must hold
+// monthNamesMutex.
+func acceptedLanguages(language string) []string {
+	if len(language) == 0 {
+		language = monthLanguage
+	}
+	if len(language) > 0 {
+		return []string{language, "en"}
+	}
+
+	languages := make([]string, 0, len(monthNames))
+	for language := range monthNames {
+		if language != "en" {
+			languages = append(languages, language)
+		}
+	}
+	sort.Strings(languages)
+
+	return append([]string{"en"}, languages...)
+}
+
+// DatePattern returns a regular expression matching the dates accepted by ParseDate
+func DatePattern() string {
+	months := MonthNamePattern()
+
+	return NowPattern + `|\d{4}-\d{2}-\d{2}|\d{1,2}\.?\s+` + months + `\.?,?\s+\d{4}|` + months + `\.?\s+\d{1,2},?\s+\d{4}`
+}
+
+// ParseDate parses the value with the layouts of ParseTime or as a written date with a registered month name,
+// such as "2 January 2024", "January 2, 2024" or "2. Januar 2024". Days which do not exist in the month, such as
+// 30 February 2024, are rejected with an error wrapping ErrOutOfRange. The {now} placeholder is the time of Now,
+// and a duration can be added to or subtracted from it, such as {now} + 2 weeks.
+func ParseDate(value string) (time.Time, error) {
+	return Settings{}.ParseDate(value)
+}
+
+// ParseDate works like the ParseDate function with {now} read from the clock of the settings and the month names
+// of their language
+func (s Settings) ParseDate(value string) (time.Time, error) {
+	if match := nowDate.FindStringSubmatch(strings.TrimSpace(value)); match != nil {
+		return s.parseNow(value, match[1], match[2])
+	}
+	if parsed, err := ParseTime(value); err == nil || errors.Is(err, ErrOutOfRange) {
+		return parsed, err
+	}
+
+	value = strings.TrimSpace(value)
+	var day, month, year string
+	if match := dayFirstDate.FindStringSubmatch(value); match != nil {
+		day, month, year = match[1], match[2], match[3]
+	} else if match := monthFirstDate.FindStringSubmatch(value); match != nil {
+		month, day, year = match[1], match[2], match[3]
+	} else {
+		return time.Time{}, fmt.Errorf("could not parse %q as date", value)
+	}
+
+	monthNumber, ok := lookupMonth(strings.ToLower(month), s.Language)
+	if !ok {
+		return time.Time{}, fmt.Errorf("could not parse %q as date, unknown month %q", value, month)
+	}
+	dayNumber, _ := strconv.Atoi(day)
+	yearNumber, _ := strconv.Atoi(year)
+	if days := daysIn(monthNumber, yearNumber); dayNumber < 1 || dayNumber > days {
+		return time.Time{}, fmt.Errorf("could not parse %q as date, day %d of %s %d is %w, the month has %d days",
+			value, dayNumber, monthNumber, yearNumber, ErrOutOfRange, days)
+	}
+
+	return time.Date(yearNumber, monthNumber, dayNumber, 0, 0, 0, 0, time.UTC), nil
+}
+
+// daysIn returns the number of days of the month in the year
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// parseNow returns the time of the clock with the duration added with the sign
+func (s Settings) parseNow(value, sign, duration string) (time.Time, error) {
+	now := s.Time()
+	if len(duration) == 0 {
+		return now, nil
+	}
+	offset, err := s.ParseDuration(sign + strings.TrimLeft(duration, "+"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not parse %q as date, error=%w", value, err)
+	}
+
+	return now.Add(offset), nil
+}
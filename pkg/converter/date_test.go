@@ -0,0 +1,132 @@
+package converter
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDate(t *testing.T) {
+	t.Run("should parse written dates", func(t *testing.T) {
+		expected := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+
+		for _, value := range []string{"2024-03-02", "2 March 2024", "March 2, 2024", "2 mar 2024", "Mar. 2 2024"} {
+			parsed, err := ParseDate(value)
+
+			require.Nil(t, err, value)
+			require.Equal(t, expected, parsed, value)
+		}
+	})
+	t.Run("should parse registered month names", func(t *testing.T) {
+		registerGermanMonths(t)
+
+		parsed, err := ParseDate("2. März 2024")
+
+		require.Nil(t, err)
+		require.Equal(t, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), parsed)
+		require.True(t, regexp.MustCompile(`^(?:`+DatePattern()+`)$`).MatchString("2. März 2024"))
+	})
+	t.Run("should accept English and the month names of the language only", func(t *testing.T) {
+		registerGermanMonths(t)
+		RegisterMonthNames("fr", []string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août",
+			"septembre", "octobre", "novembre", "décembre"})
+		require.Nil(t, SetMonthLanguage("de"))
+		t.Cleanup(func() {
+			require.Nil(t, SetMonthLanguage(""))
+			monthNamesMutex.Lock()
+			defer monthNamesMutex.Unlock()
+			delete(monthNames, "fr")
+		})
+
+		for _, value := range []string{"2. März 2024", "2 March 2024"} {
+			_, err := ParseDate(value)
+
+			require.Nil(t, err, value)
+		}
+		_, err := ParseDate("2 février 2024")
+		require.EqualError(t, err, `could not parse "2 février 2024" as date, unknown month "février"`)
+		require.NotContains(t, DatePattern(), "février")
+	})
+	t.Run("should return error for languages without month names", func(t *testing.T) {
+		require.EqualError(t, SetMonthLanguage("tr"), "month names of language tr are not registered, "+
+			"register them with RegisterMonthNames")
+	})
+	t.Run("should parse {now} with the clock", func(t *testing.T) {
+		now := time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)
+		Now = func() time.Time { return now }
+		defer func() {
+			Now = time.Now
+		}()
+
+		for value, expected := range map[string]time.Time{
+			"{now}":           now,
+			"{now} + 1h30m":   now.Add(90 * time.Minute),
+			"{now}-2d":        now.AddDate(0, 0, -2),
+			"{now} + 1 month": time.Date(2024, 4, 2, 9, 0, 0, 0, time.UTC),
+		} {
+			parsed, err := ParseDate(value)
+
+			require.Nil(t, err, value)
+			require.Equal(t, expected, parsed, value)
+			require.Regexp(t, `^(?:`+DatePattern()+`)$`, value)
+		}
+	})
+	t.Run("should parse {now} with the clock of the settings", func(t *testing.T) {
+		now := time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)
+		ctx := ContextWithSettings(context.Background(), Settings{Now: func() time.Time { return now }})
+		settings := SettingsFrom(ctx)
+
+		parsed, err := settings.ParseDate("{now} + 1 month")
+
+		require.Nil(t, err)
+		require.Equal(t, time.Date(2024, 4, 2, 9, 0, 0, 0, time.UTC), parsed)
+		duration, err := settings.ParseDuration("1 week")
+		require.Nil(t, err)
+		require.Equal(t, 7*24*time.Hour, duration)
+	})
+	t.Run("should return error for unknown months", func(t *testing.T) {
+		_, err := ParseDate("2 Brumaire 2024")
+
+		require.EqualError(t, err, `could not parse "2 Brumaire 2024" as date, unknown month "Brumaire"`)
+	})
+	t.Run("should reject days which do not exist in the month", func(t *testing.T) {
+		_, err := ParseDate("30 February 2024")
+
+		require.ErrorIs(t, err, ErrOutOfRange)
+		require.EqualError(t, err, `could not parse "30 February 2024" as date, day 30 of February 2024 is out of `+
+			`range, the month has 29 days`)
+
+		_, err = ParseDate("2023-02-29")
+
+		require.EqualError(t, err, `could not parse "2023-02-29" as time, day out of range`)
+	})
+}
+
+func TestLoadLocation(t *testing.T) {
+	t.Run("should load the time zone", func(t *testing.T) {
+		location, err := LoadLocation("UTC")
+
+		require.NoError(t, err)
+		require.Equal(t, time.UTC.String(), location.String())
+	})
+	t.Run("should explain how to embed the time zone database if the time zone is not available", func(t *testing.T) {
+		_, err := LoadLocation("Nowhere/Unknown")
+
+		require.ErrorContains(t, err, "time zone Nowhere/Unknown is not available")
+		require.ErrorContains(t, err, "time/tzdata")
+	})
+}
+
+// registerGermanMonths registers the German month names until the test ends
+func registerGermanMonths(t *testing.T) {
+	RegisterMonthNames("de", []string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August",
+		"September", "Oktober", "November", "Dezember"})
+	t.Cleanup(func() {
+		monthNamesMutex.Lock()
+		defer monthNamesMutex.Unlock()
+		delete(monthNames, "de")
+	})
+}
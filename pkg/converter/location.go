@@ -0,0 +1,19 @@
+package converter
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoadLocation loads the IANA time zone with the name, such as Europe/Istanbul. Minimal containers often have no
+// zoneinfo files; the error then explains how to embed the time zone database in the test binary.
+func LoadLocation(name string) (*time.Location, error) {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("time zone %s is not available, embed the time zone database with "+
+			"import _ \"time/tzdata\", the -tzdata flag of cacik or by building with -tags timetzdata, error=%w",
+			name, err)
+	}
+
+	return location, nil
+}
@@ -0,0 +1,123 @@
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// OrdinalWords maps the lower case ordinal words accepted by ParseOrdinal to their number. Words of other
+	// languages can be added before the step definitions using {ordinal}.
+	OrdinalWords = map[string]int{
+		"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5,
+		"sixth": 6, "seventh": 7, "eighth": 8, "ninth": 9, "tenth": 10,
+		"eleventh": 11, "twelfth": 12,
+	}
+
+	ordinalNumberPattern = regexp.MustCompile(`^(\d+)(st|nd|rd|th)$`)
+)
+
+// NumberPattern returns a regular expression matching the integers and floats written in the Numbers format, such
+// as -3, 2.5 or 1,234.56
+func NumberPattern() string {
+	return IntegerPattern() + `(?:` + regexp.QuoteMeta(Numbers.decimalSeparator()) + `\d+)?`
+}
+
+// IntegerPattern returns a regular expression matching the integers written in the Numbers format, such as -3 or
+// 1,234
+func IntegerPattern() string {
+	digits := `\d+`
+	if len(Numbers.ThousandsSeparator) > 0 {
+		digits = `\d{1,3}(?:` + regexp.QuoteMeta(Numbers.ThousandsSeparator) + `\d{3})+|\d+`
+	}
+
+	return `-?(?:` + digits + `)`
+}
+
+// FloatPattern returns a regular expression matching the floats written in the Numbers format, which may omit the
+// integer part, such as 2.5, .5 or 1,234.56
+func FloatPattern() string {
+	return `(?:` + NumberPattern() + `|-?` + regexp.QuoteMeta(Numbers.decimalSeparator()) + `\d+)`
+}
+
+// PercentPattern returns a regular expression matching the percentages accepted by ParsePercent, such as 25% or
+// 12.5 %
+func PercentPattern() string {
+	return `(?:` + NumberPattern() + `)\s?%`
+}
+
+// OrdinalPattern returns a regular expression matching the ordinals accepted by ParseOrdinal, such as 1st, 22nd or
+// third
+func OrdinalPattern() string {
+	words := make([]string, 0, len(OrdinalWords))
+	for word := range OrdinalWords {
+		words = append(words, regexp.QuoteMeta(word))
+	}
+	// longer words first, so that a word is not matched by its prefix
+	sort.Slice(words, func(i, j int) bool {
+		if len(words[i]) != len(words[j]) {
+			return len(words[i]) > len(words[j])
+		}
+
+		return words[i] < words[j]
+	})
+
+	return `(?i:\d+(?:st|nd|rd|th)|` + strings.Join(words, "|") + `)`
+}
+
+// ParsePercent parses the percentage, such as 25%, as the fraction it is of a hundred, such as 0.25
+func ParsePercent(value string) (float64, error) {
+	number, ok := strings.CutSuffix(strings.TrimSpace(value), "%")
+	if !ok {
+		return 0, fmt.Errorf("could not parse %q as percentage", value)
+	}
+	parsed, err := ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse %q as percentage", value)
+	}
+
+	return parsed / 100, nil
+}
+
+// ParseOrdinal parses the ordinal written with digits and its English suffix, such as 2nd, or as a word of
+// OrdinalWords, such as second
+func ParseOrdinal(value string) (int, error) {
+	text := strings.ToLower(strings.TrimSpace(value))
+	if number, ok := OrdinalWords[text]; ok {
+		return number, nil
+	}
+
+	match := ordinalNumberPattern.FindStringSubmatch(text)
+	if match == nil {
+		return 0, fmt.Errorf("could not parse %q as ordinal", value)
+	}
+	number, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, fmt.Errorf("could not parse %q as ordinal, error=%w", value, err)
+	}
+	if suffix := ordinalSuffix(number); suffix != match[2] {
+		return 0, fmt.Errorf("could not parse %q as ordinal, %d is written as %d%s", value, number, number, suffix)
+	}
+
+	return number, nil
+}
+
+// ordinalSuffix returns the English suffix of the ordinal, such as nd for 2 and th for 12
+func ordinalSuffix(number int) string {
+	if number%100 >= 11 && number%100 <= 13 {
+		return "th"
+	}
+	switch number % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+
+	return "th"
+}
@@ -0,0 +1,71 @@
+package converter
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumberPattern(t *testing.T) {
+	t.Run("should match integers and floats in the number format", func(t *testing.T) {
+		number := regexp.MustCompile(`^(?:` + NumberPattern() + `)$`)
+
+		for _, value := range []string{"3", "-3", "2.5", "1,234.56", "1234"} {
+			require.True(t, number.MatchString(value), value)
+		}
+		require.False(t, number.MatchString("2."))
+	})
+	t.Run("should use the configured number format", func(t *testing.T) {
+		Numbers = EuropeanNumbers
+		defer func() {
+			Numbers = EnglishNumbers
+		}()
+
+		require.Regexp(t, `^(?:`+NumberPattern()+`)$`, "1.234,56")
+	})
+}
+
+func TestParsePercent(t *testing.T) {
+	t.Run("should parse percentages as fractions", func(t *testing.T) {
+		for value, expected := range map[string]float64{"25%": 0.25, "12.5 %": 0.125, "-50%": -0.5, "1,000%": 10} {
+			parsed, err := ParsePercent(value)
+
+			require.Nil(t, err, value)
+			require.InDelta(t, expected, parsed, 1e-9, value)
+			require.Regexp(t, `^(?:`+PercentPattern()+`)$`, value)
+		}
+	})
+	t.Run("should return error without a percent sign", func(t *testing.T) {
+		_, err := ParsePercent("25")
+
+		require.EqualError(t, err, `could not parse "25" as percentage`)
+	})
+}
+
+func TestParseOrdinal(t *testing.T) {
+	t.Run("should parse ordinals with suffixes and words", func(t *testing.T) {
+		for value, expected := range map[string]int{"1st": 1, "2nd": 2, "3rd": 3, "11th": 11, "22nd": 22,
+			"113th": 113, "Second": 2, "twelfth": 12} {
+			parsed, err := ParseOrdinal(value)
+
+			require.Nil(t, err, value)
+			require.Equal(t, expected, parsed, value)
+			require.Regexp(t, `^(?:`+OrdinalPattern()+`)$`, value)
+		}
+	})
+	t.Run("should return error for wrong suffixes", func(t *testing.T) {
+		_, err := ParseOrdinal("2th")
+
+		require.EqualError(t, err, `could not parse "2th" as ordinal, 2 is written as 2nd`)
+	})
+	t.Run("should parse added words", func(t *testing.T) {
+		OrdinalWords["zweite"] = 2
+		defer delete(OrdinalWords, "zweite")
+
+		parsed, err := ParseOrdinal("zweite")
+
+		require.Nil(t, err)
+		require.Equal(t, 2, parsed)
+	})
+}
@@ -0,0 +1,43 @@
+package converter
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Settings are the settings of the conversions of one run, such as the run of an executor, so that runs in the
+	// same process can use their own clock and month names
+	Settings struct {
+		// Now is the clock of {now} and of calendar durations, Now of the package is used if it is nil
+		Now func() time.Time
+		// Language is the language whose month names are accepted in addition to English, the language of
+		// SetMonthLanguage is used if it is empty
+		Language string
+	}
+
+	settingsKey struct{}
+)
+
+// ContextWithSettings returns a copy of the context carrying the settings
+func ContextWithSettings(ctx context.Context, settings Settings) context.Context {
+	return context.WithValue(ctx, settingsKey{}, settings)
+}
+
+// SettingsFrom returns the settings carried by the context, or the zero settings using the package defaults
+func SettingsFrom(ctx context.Context) Settings {
+	if settings, ok := ctx.Value(settingsKey{}).(Settings); ok {
+		return settings
+	}
+
+	return Settings{}
+}
+
+// Time returns the current time of the clock of the settings
+func (s Settings) Time() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+
+	return Now()
+}
@@ -0,0 +1,142 @@
+package converter
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// UUIDPattern matches the UUIDs accepted by ParseUUID, such as 123e4567-e89b-12d3-a456-426614174000
+	UUIDPattern = `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`
+	// IPPattern matches IPv4 addresses, such as 192.0.2.1, and IPv6 addresses, such as 2001:db8::1. The matched
+	// addresses are checked by ParseIP.
+	IPPattern = `\d{1,3}(?:\.\d{1,3}){3}|[0-9a-fA-F]*:[0-9a-fA-F:]+(?:\.\d{1,3}){0,3}`
+	// VersionPattern matches the semantic versions accepted by ParseVersion, such as 1.4.2, v2.0.0-rc.1 or
+	// 1.0.0+build.5
+	VersionPattern = `v?\d+\.\d+\.\d+(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?`
+)
+
+var versionPattern = regexp.MustCompile(`^(?:` + VersionPattern + `)$`)
+
+type (
+	// Version is a semantic version, such as 2.0.0-rc.1+build.5
+	Version struct {
+		Major      int
+		Minor      int
+		Patch      int
+		PreRelease string
+		Build      string
+	}
+)
+
+// ParseUUID parses the value as a UUID written in its canonical form
+func ParseUUID(value string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("could not parse %q as UUID, error=%w", value, err)
+	}
+
+	return parsed, nil
+}
+
+// ParseIP parses the value as an IPv4 or IPv6 address
+func ParseIP(value string) (net.IP, error) {
+	parsed := net.ParseIP(strings.TrimSpace(value))
+	if parsed == nil {
+		return nil, fmt.Errorf("could not parse %q as IP address", value)
+	}
+
+	return parsed, nil
+}
+
+// ParseVersion parses the value as a semantic version with an optional v prefix, such as v1.4.2
+func ParseVersion(value string) (Version, error) {
+	version := Version{}
+	text := strings.TrimPrefix(strings.TrimSpace(value), "v")
+	if !versionPattern.MatchString(text) {
+		return Version{}, fmt.Errorf("could not parse %q as semantic version", value)
+	}
+	text, version.Build, _ = strings.Cut(text, "+")
+	text, version.PreRelease, _ = strings.Cut(text, "-")
+
+	numbers := strings.Split(text, ".")
+	for i, target := range []*int{&version.Major, &version.Minor, &version.Patch} {
+		number, err := strconv.Atoi(numbers[i])
+		if err != nil || (len(numbers[i]) > 1 && numbers[i][0] == '0') {
+			return Version{}, fmt.Errorf("could not parse %q as semantic version", value)
+		}
+		*target = number
+	}
+
+	return version, nil
+}
+
+// String returns the version without the v prefix, such as 2.0.0-rc.1+build.5
+func (v Version) String() string {
+	text := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.PreRelease) > 0 {
+		text += "-" + v.PreRelease
+	}
+	if len(v.Build) > 0 {
+		text += "+" + v.Build
+	}
+
+	return text
+}
+
+// Compare returns -1, 0 or 1 if the version has a lower, the same or a higher precedence than the other version.
+// A pre-release has a lower precedence than its release and build metadata is ignored.
+func (v Version) Compare(other Version) int {
+	for _, pair := range [][2]int{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			return cmp.Compare(pair[0], pair[1])
+		}
+	}
+	if v.PreRelease == other.PreRelease {
+		return 0
+	}
+	if len(v.PreRelease) == 0 {
+		return 1
+	}
+	if len(other.PreRelease) == 0 {
+		return -1
+	}
+
+	identifiers, others := strings.Split(v.PreRelease, "."), strings.Split(other.PreRelease, ".")
+	for i := 0; i < len(identifiers) && i < len(others); i++ {
+		if identifiers[i] == others[i] {
+			continue
+		}
+		number, numberErr := strconv.Atoi(identifiers[i])
+		otherNumber, otherErr := strconv.Atoi(others[i])
+		switch {
+		case numberErr == nil && otherErr == nil:
+			return cmp.Compare(number, otherNumber)
+		case numberErr == nil:
+			// numeric identifiers have a lower precedence than alphanumeric identifiers
+			return -1
+		case otherErr == nil:
+			return 1
+		default:
+			return strings.Compare(identifiers[i], others[i])
+		}
+	}
+
+	return cmp.Compare(len(identifiers), len(others))
+}
+
+// ParseJSON unmarshals the value into the target, such as a *map[string]any or a pointer to a struct
+func ParseJSON(value string, target any) error {
+	if err := json.Unmarshal([]byte(value), target); err != nil {
+		return fmt.Errorf("could not parse %q as JSON, error=%w", value, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package converter
+
+import (
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUUID(t *testing.T) {
+	t.Run("should parse UUIDs in any case", func(t *testing.T) {
+		parsed, err := ParseUUID("123E4567-e89b-12d3-a456-426614174000")
+
+		require.Nil(t, err)
+		require.Equal(t, "123e4567-e89b-12d3-a456-426614174000", parsed.String())
+	})
+	t.Run("should return error for invalid UUIDs", func(t *testing.T) {
+		_, err := ParseUUID("123e4567")
+
+		require.ErrorContains(t, err, `could not parse "123e4567" as UUID`)
+	})
+}
+
+func TestParseIP(t *testing.T) {
+	t.Run("should parse IPv4 and IPv6 addresses", func(t *testing.T) {
+		for _, value := range []string{"192.0.2.1", "2001:db8::1", "::1", "::ffff:192.0.2.1"} {
+			parsed, err := ParseIP(value)
+
+			require.Nil(t, err, value)
+			require.True(t, net.ParseIP(value).Equal(parsed), value)
+			require.True(t, regexp.MustCompile(`^(?:`+IPPattern+`)$`).MatchString(value), value)
+		}
+	})
+	t.Run("should return error for invalid addresses", func(t *testing.T) {
+		_, err := ParseIP("256.0.0.1")
+
+		require.EqualError(t, err, `could not parse "256.0.0.1" as IP address`)
+	})
+}
+
+func TestParseVersion(t *testing.T) {
+	t.Run("should parse semantic versions", func(t *testing.T) {
+		parsed, err := ParseVersion("v2.0.10-rc.1+build-5")
+
+		require.Nil(t, err)
+		require.Equal(t, Version{Major: 2, Minor: 0, Patch: 10, PreRelease: "rc.1", Build: "build-5"}, parsed)
+		require.Equal(t, "2.0.10-rc.1+build-5", parsed.String())
+	})
+	t.Run("should return error for invalid versions", func(t *testing.T) {
+		for _, value := range []string{"1.2", "1.02.3", "1.2.3-", "1.2.3+"} {
+			_, err := ParseVersion(value)
+
+			require.Error(t, err, value)
+		}
+	})
+	t.Run("should compare versions by precedence", func(t *testing.T) {
+		ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+			"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.2.0", "2.0.0"}
+		for i := 1; i < len(ordered); i++ {
+			lower, err := ParseVersion(ordered[i-1])
+			require.Nil(t, err)
+			higher, err := ParseVersion(ordered[i])
+			require.Nil(t, err)
+
+			require.Equal(t, -1, lower.Compare(higher), ordered[i])
+			require.Equal(t, 1, higher.Compare(lower), ordered[i])
+		}
+		require.Equal(t, 0, Version{Major: 1, Build: "a"}.Compare(Version{Major: 1, Build: "b"}))
+	})
+}
+
+func TestParseJSON(t *testing.T) {
+	t.Run("should unmarshal into the target", func(t *testing.T) {
+		var fields map[string]any
+
+		require.Nil(t, ParseJSON(`{"id": 7}`, &fields))
+		require.Equal(t, map[string]any{"id": float64(7)}, fields)
+	})
+	t.Run("should return error for invalid JSON", func(t *testing.T) {
+		var fields map[string]any
+
+		require.ErrorContains(t, ParseJSON(`{"id": }`, &fields), `could not parse "{\"id\": }" as JSON`)
+	})
+}
@@ -0,0 +1,48 @@
+package executor
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	// goroutineSettleTime is how long goroutines stopping at the end of a scenario, such as the ones of a closed
+	// HTTP server, are waited for before they are counted as leaked
+	goroutineSettleTime = 100 * time.Millisecond
+)
+
+type (
+	// diagnosticsProbe measures the allocations and goroutines of a scenario from its start
+	diagnosticsProbe struct {
+		memory     runtime.MemStats
+		goroutines int
+	}
+)
+
+func startDiagnostics() *diagnosticsProbe {
+	probe := &diagnosticsProbe{goroutines: runtime.NumGoroutine()}
+	runtime.ReadMemStats(&probe.memory)
+
+	return probe
+}
+
+// finish returns the allocations and goroutines since the probe started
+func (p *diagnosticsProbe) finish() *models.Diagnostics {
+	goroutines := runtime.NumGoroutine()
+	for deadline := time.Now().Add(goroutineSettleTime); goroutines > p.goroutines && time.Now().Before(deadline); {
+		time.Sleep(goroutineSettleTime / 10)
+		goroutines = runtime.NumGoroutine()
+	}
+	memory := runtime.MemStats{}
+	runtime.ReadMemStats(&memory)
+
+	return &models.Diagnostics{
+		Allocations:      int64(memory.Mallocs - p.memory.Mallocs),
+		AllocatedBytes:   int64(memory.TotalAlloc - p.memory.TotalAlloc),
+		HeapBytes:        int64(memory.HeapAlloc) - int64(p.memory.HeapAlloc),
+		GoroutinesBefore: p.goroutines,
+		GoroutinesAfter:  goroutines,
+	}
+}
@@ -1,73 +1,662 @@
 package executor
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	gherkin "github.com/cucumber/gherkin/go/v26"
 	messages "github.com/cucumber/messages/go/v21"
+	"github.com/denizgursoy/cacik/pkg/converter"
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	// skipTag skips the scenarios tagged with it
+	skipTag = "@skip"
 )
 
 type (
 	StepExecutor struct {
+		steps []*StepDefinition
+		// index finds the steps which may match a text, so every text is not matched against every step
+		index     *stepIndex
+		hooks     *HookExecutor
+		filter    PickleFilter
+		scheduler *scheduler
+		// failFast skips the scenarios which have not started once failed is set by a failing scenario
+		failFast bool
+		failed   atomic.Bool
+		// world creates the world of every scenario
+		world func() any
+		// pause is called before every step
+		pause func(ctx context.Context, step string)
+		// finished is called with the result of every scenario Execute executes as soon as it finishes
+		finished func(scenario *models.ScenarioResult)
+		// trace records the scenarios, hooks, steps and conversions if it is set
+		trace *Trace
+		// diagnostics measures the allocations and goroutines of every scenario if it is set
+		diagnostics bool
+		// strictKeywords fails the steps using a definition registered for another keyword
+		strictKeywords bool
+		providers      map[reflect.Type]reflect.Value
+		// configProviders are the types provided by the config of SetConfig, which are replaced by the next config
+		configProviders []reflect.Type
+		// configErrors are the problems of the config of SetConfig
+		configErrors error
+		// settings are the settings of the conversions of the arguments, such as the clock of {now} and the
+		// language of the month names
+		settings converter.Settings
+		// parameterTypes are the custom parameter types by name
+		parameterTypes map[string]*parameterType
 	}
-)
 
-func (c *StepExecutor) Execute(*messages.GherkinDocument) error {
-	//TODO implement me
-	panic("implement me")
-}
+	// PickleFilter decides whether a pickle of the document is executed
+	PickleFilter func(document *messages.GherkinDocument, pickle *messages.Pickle) bool
+)
 
 func NewStepExecutor() *StepExecutor {
-	return &StepExecutor{}
+	return &StepExecutor{
+		steps:          make([]*StepDefinition, 0),
+		index:          newStepIndex(),
+		hooks:          NewHookExecutor(nil),
+		scheduler:      newScheduler(nil),
+		providers:      make(map[reflect.Type]reflect.Value),
+		parameterTypes: make(map[string]*parameterType),
+	}
 }
 
-func (c *StepExecutor) execute(document *messages.GherkinDocument) error {
-	var featureBackground *messages.Background
+// SetConfig sets the hooks, the concurrency, fail fast, the world factory and the month language of the config and
+// registers its providers in place of the providers of the config set before. Problems of the config are returned
+// by ConfigErrors. Providers which are not valid or are provided twice by the config are left out, they are
+// reported by ValidateProviders. The month language is only used by the conversions of this executor.
+func (c *StepExecutor) SetConfig(config *models.Config) {
+	c.hooks = NewHookExecutor(config)
+	c.scheduler = newScheduler(config)
+	c.failFast = config != nil && config.FailFast
+	c.world = nil
+	c.settings.Language = ""
+	c.configErrors = nil
+	for _, provided := range c.configProviders {
+		delete(c.providers, provided)
+	}
+	c.configProviders = nil
+	if config == nil {
+		return
+	}
 
-	for _, child := range document.Feature.Children {
-		if child.Background != nil {
-			featureBackground = child.Background
-		} else if child.Rule != nil {
-			c.executeRule(child.Rule, featureBackground)
+	c.world = config.World
+	problems := make([]error, 0)
+	if err := converter.CheckMonthLanguage(config.Language); err != nil {
+		problems = append(problems, err)
+	} else {
+		c.settings.Language = config.Language
+	}
+	for _, provider := range config.Providers {
+		function := reflect.ValueOf(provider)
+		if validateProvider(function) != nil || slices.Contains(c.configProviders, function.Type().Out(0)) {
+			continue
+		}
+		if err := c.Provide(provider); err != nil {
+			problems = append(problems, err)
 		} else {
-			c.executeScenarioWithBackground(child.Scenario, featureBackground)
+			c.configProviders = append(c.configProviders, function.Type().Out(0))
+		}
+	}
+	c.configErrors = errors.Join(problems...)
+}
+
+// ConfigErrors returns the problems of the config set with SetConfig: a language without registered month names
+// and the providers of types which are already provided, such as by Provide
+func (c *StepExecutor) ConfigErrors() error {
+	return c.configErrors
+}
+
+// SetPause sets the function called with the context of the scenario and the text of every step before the step
+// and its hooks run, such as a debugger waiting until it is continued. A nil pause removes it.
+func (c *StepExecutor) SetPause(pause func(ctx context.Context, step string)) {
+	c.pause = pause
+}
+
+// SetClock sets the clock of {now} and of the calendar units of durations in the arguments of the steps, and of the
+// wait steps of the steps package. A nil clock uses converter.Now.
+func (c *StepExecutor) SetClock(clock func() time.Time) {
+	c.settings.Now = clock
+}
+
+// StartRun starts a new run of the feature files, forgetting the scenarios which failed in earlier runs, so that a
+// fail fast run of a reused executor, such as the one of the REPL, executes its scenarios again
+func (c *StepExecutor) StartRun() {
+	c.failed.Store(false)
+}
+
+// SetScenarioFinished sets the function called with the result of every scenario Execute executes as soon as it
+// finishes, such as a reporter writing the results live. Scenarios executed at the same time call it one at a time.
+// A nil function removes it.
+func (c *StepExecutor) SetScenarioFinished(finished func(scenario *models.ScenarioResult)) {
+	c.finished = finished
+}
+
+// SetStepLocation sets where the steps of the definition are defined, which their failures point at if they did not
+// panic, such as the step library running the steps in a subprocess instead of the function calling it
+func (c *StepExecutor) SetStepLocation(definition string, location *models.SourceLocation) {
+	for _, step := range c.steps {
+		if step.Definition == definition {
+			step.Location = location
 		}
 	}
+}
+
+// SetTrace records the scenarios with their hooks, steps and argument conversions in the trace. A nil trace stops
+// recording.
+func (c *StepExecutor) SetTrace(trace *Trace) {
+	c.trace = trace
+}
+
+// SetDiagnostics sets whether the allocations and goroutines of every scenario are measured and added to its
+// result. Measuring stops the program twice for every scenario, and scenarios starting goroutines wait up to
+// 100ms for them to stop. The measurements are taken for the whole process, so they are only meaningful if the
+// scenarios are executed one at a time.
+func (c *StepExecutor) SetDiagnostics(enabled bool) {
+	c.diagnostics = enabled
+}
+
+// SetFilter sets the filter selecting the pickles to execute. Pickles which are not selected are left out of
+// the results. A nil filter executes every pickle.
+func (c *StepExecutor) SetFilter(filter PickleFilter) {
+	c.filter = filter
+}
+
+// RegisterStep registers the function for the step definition of steps of every keyword
+func (c *StepExecutor) RegisterStep(definition string, function any) error {
+	return c.registerStep(definition, function, messages.PickleStepType_UNKNOWN)
+}
+
+// registerStep registers the function for the step definition of steps of the type, or of every type if it is
+// unknown
+func (c *StepExecutor) registerStep(definition string, function any, stepType messages.PickleStepType) error {
+	step, err := newStepDefinition(definition, function, c.parameterTypes)
+	if err != nil {
+		return err
+	}
+	step.Type = stepType
+	if err := step.validateArguments(); err != nil {
+		return err
+	}
+	c.index.add(len(c.steps), step.pattern.String())
+	c.steps = append(c.steps, step)
 
 	return nil
 }
 
-func (c *StepExecutor) executeRule(rule *messages.Rule, featureBackground *messages.Background) {
-	var ruleBackground *messages.Background
+// Execute executes the pickles of the document selected by the filter with ExecutePickle and returns their
+// results in the order of the document. Pickles are executed at the same time up to the concurrency of the config.
+func (c *StepExecutor) Execute(document *messages.GherkinDocument) ([]*models.ScenarioResult, error) {
+	results := make([]*models.ScenarioResult, 0)
+	if document.Feature == nil {
+		return results, nil
+	}
+
+	pickles := make([]*messages.Pickle, 0)
+	for _, pickle := range gherkin.Pickles(*document, document.Uri, (&messages.Incrementing{}).NewId) {
+		if c.filter == nil || c.filter(document, pickle) {
+			pickles = append(pickles, pickle)
+		}
+	}
 
-	for _, child := range rule.Children {
-		if child.Background != nil {
-			ruleBackground = child.Background
-		} else {
-			c.executeBackground(featureBackground)
-			c.executeScenarioWithBackground(child.Scenario, ruleBackground)
+	results = make([]*models.ScenarioResult, len(pickles))
+	var finishedMutex sync.Mutex
+	c.scheduler.run(len(pickles), func(i int) []string {
+		return pickleTags(pickles[i])
+	}, func(i, worker int) {
+		results[i] = c.ExecutePickle(context.Background(), document, pickles[i])
+		results[i].Worker = worker
+		if c.finished != nil {
+			finishedMutex.Lock()
+			defer finishedMutex.Unlock()
+			c.finished(results[i])
+		}
+	})
+
+	return results, nil
+}
+
+// ExecutePickle executes a pickle of the document with its own scenario data, logger, attachments and provided
+// values. The filter is not applied, the pickle is always executed.
+func (c *StepExecutor) ExecutePickle(ctx context.Context, document *messages.GherkinDocument,
+	pickle *messages.Pickle) *models.ScenarioResult {
+	result := c.executePickle(ctx, pickle)
+	result.Line = PickleLine(document, pickle)
+	if document.Feature != nil {
+		result.Feature = document.Feature.Name
+		result.FeatureDescription = trimDescription(document.Feature.Description)
+	}
+	if len(pickle.AstNodeIds) > 0 {
+		if scenario := findScenario(document, pickle.AstNodeIds[0]); scenario != nil {
+			result.Description = trimDescription(scenario.Description)
+			result.ScenarioLine = int(scenario.Location.Line)
+			if len(pickle.AstNodeIds) > 1 {
+				result.Examples = exampleValues(scenario, pickle.AstNodeIds[1])
+			}
 		}
 	}
+	steps := documentSteps(document)
+	for i, step := range pickle.Steps {
+		describeStep(result.Steps[i], step, steps)
+	}
+
+	return result
 }
 
-func (c *StepExecutor) executeScenarioWithBackground(scenario *messages.Scenario, background *messages.Background) {
+func (c *StepExecutor) executePickle(ctx context.Context, pickle *messages.Pickle) *models.ScenarioResult {
+	result := &models.ScenarioResult{
+		Name:   pickle.Name,
+		Uri:    pickle.Uri,
+		Status: models.StatusPassed,
+		Steps:  make([]*models.StepResult, 0, len(pickle.Steps)),
+		Hooks:  make([]*models.HookResult, 0),
+	}
+	var probe *diagnosticsProbe
+	if c.diagnostics {
+		probe = startDiagnostics()
+	}
+	start := time.Now()
+	result.StartedAt = start
+	span := c.trace.begin(TraceCategoryScenario, pickle.Name)
+	tags := pickleTags(pickle)
+	if len(tags) > 0 {
+		result.Tags = tags
+	}
+	logger := models.NewLogger()
+	attachments := models.NewAttachments()
+	ctx = models.ContextWithData(ctx, models.NewData())
+	ctx = models.ContextWithLogger(ctx, logger)
+	ctx = models.ContextWithAttachments(ctx, attachments)
+	ctx = contextWithInstances(ctx, c.providers)
+	ctx = c.contextWithWorld(ctx)
+	ctx = ContextWithTrace(ctx, c.trace)
+	ctx = converter.ContextWithSettings(ctx, c.settings)
+
+	// scenarios tagged with @skip, whose preconditions are not met or which have not started before a scenario
+	// failed in a fail fast run are skipped without running their hooks. Steps are skipped with the cause and the
+	// reason the scenario stopped for. A hook has a reason if it skipped the scenario and an error if it failed.
+	var cause models.SkipCause
+	reason := ""
+	if slices.Contains(tags, skipTag) {
+		result.Status, cause, reason = models.StatusSkipped, models.SkipCauseTag, "scenario is tagged with "+skipTag
+	} else if hook := checkRequirements(tags); hook != nil {
+		result.Hooks = append(result.Hooks, hook)
+		result.Status, cause, reason = hook.Status, models.SkipCausePrecondition, hook.Reason+hook.Error
+	} else if c.failFast && c.failed.Load() {
+		result.Status, cause, reason = models.StatusSkipped, models.SkipCauseFailFast, "a scenario failed before"
+	}
+	started := result.Status == models.StatusPassed
+
+	if started {
+		for _, hook := range c.hooks.BeforeScenario(ctx, tags) {
+			result.Hooks = append(result.Hooks, hook)
+			if result.Status == models.StatusPassed && hook.Status != models.StatusPassed {
+				result.Status, cause, reason = hook.Status, models.SkipCauseHook, hook.Reason+hook.Error
+			}
+		}
+	}
+	if result.Status == models.StatusSkipped {
+		result.SkipCause, result.Reason = cause, reason
+	}
+
+	scenarioAttachments := attachments.Take()
 
-	c.executeBackground(background)
+	for _, step := range pickle.Steps {
+		if result.Status != models.StatusPassed {
+			skipped := &models.StepResult{
+				Text:      step.Text,
+				Status:    models.StatusSkipped,
+				SkipCause: cause,
+				Reason:    reason,
+			}
+			if definition, _ := c.matchStep(step.Text, step.Type); definition != nil {
+				skipped.Definition = definition.Definition
+				skipped.Arguments = definition.Arguments(step.Text)
+			}
+			result.Steps = append(result.Steps, skipped)
+			continue
+		}
 
-	isScenarioOutline := false
-	if scenario.Examples != nil {
-		isScenarioOutline = true
+		// the context returned by a step is passed to the following steps and hooks
+		var stepResult *models.StepResult
+		logged := len(logger.Entries())
+		stepResult, ctx = c.executeStep(ctx, step, tags, result)
+		stepResult.Attachments = attachments.Take()
+		if entries := logger.Entries(); len(entries) > logged {
+			stepResult.Logs = slices.Clone(entries[logged:])
+		}
+		result.Steps = append(result.Steps, stepResult)
+		if stepResult.Status != models.StatusPassed {
+			result.Status = stepResult.Status
+			if stepResult.SkipCause == models.SkipCausePending {
+				result.SkipCause, result.Reason = stepResult.SkipCause, stepResult.Reason
+			}
+			cause, reason = models.SkipCausePreviousStep, fmt.Sprintf("step %q %s", step.Text, stepResult.Status)
+		}
 	}
 
-	for _, step := range scenario.Steps {
-		fmt.Println(step.Text, isScenarioOutline)
+	if started {
+		afterHooks := c.hooks.AfterScenario(ctx, tags)
+		result.Hooks = append(result.Hooks, afterHooks...)
+		if firstFailure(afterHooks) != nil {
+			result.Status = models.StatusFailed
+			result.SkipCause, result.Reason = "", ""
+		}
+	}
+	if result.Status == models.StatusFailed || result.Status == models.StatusUndefined {
+		c.failed.Store(true)
+	}
+	result.Duration = time.Since(start)
+	result.Logs = logger.Entries()
+	result.Fingerprint = result.FailureFingerprint()
+	result.Attachments = append(scenarioAttachments, attachments.Take()...)
+	if probe != nil {
+		result.Diagnostics = probe.finish()
 	}
+	span.end(map[string]string{"uri": pickle.Uri, "status": string(result.Status)})
 
+	return result
 }
 
-func (c *StepExecutor) executeBackground(background *messages.Background) {
-	if background != nil {
-		for _, step := range background.Steps {
-			fmt.Println(step.Text)
+// executeStep executes the step between its hooks and returns its result with the context returned by the step
+func (c *StepExecutor) executeStep(ctx context.Context, step *messages.PickleStep, tags []string,
+	scenario *models.ScenarioResult) (*models.StepResult, context.Context) {
+	result := &models.StepResult{
+		Text:   step.Text,
+		Status: models.StatusPassed,
+	}
+	if c.pause != nil {
+		c.pause(ctx, step.Text)
+	}
+
+	beforeHooks := c.hooks.BeforeStep(ctx, tags)
+	scenario.Hooks = append(scenario.Hooks, beforeHooks...)
+	if hook := firstFailure(beforeHooks); hook != nil {
+		result.Status = models.StatusFailed
+		result.Fail(&models.Failure{Message: hook.Error})
+
+		return result, ctx
+	}
+
+	start := time.Now()
+	span := traceFrom(ctx).begin(TraceCategoryStep, step.Text)
+	definition, arguments := c.matchStep(step.Text, step.Type)
+	mismatch := ""
+	if definition != nil {
+		mismatch = checkKeyword(definition, step)
+	}
+	if definition == nil {
+		result.Status = models.StatusUndefined
+		result.Fail(c.undefinedFailure(step.Text))
+	} else if len(mismatch) > 0 && c.strictKeywords {
+		result.Definition = definition.Definition
+		result.Status = models.StatusFailed
+		result.Fail(&models.Failure{Message: mismatch})
+	} else {
+		if len(mismatch) > 0 {
+			result.Warnings = append(result.Warnings, mismatch)
+		}
+		result.Definition = definition.Definition
+		result.Arguments = definition.Arguments(step.Text)
+		var stack string
+		var err error
+		ctx, stack, err = callStep(ctx, definition, arguments, step.Argument)
+		if errors.Is(err, models.ErrPending) {
+			result.Status = models.StatusSkipped
+			result.SkipCause = models.SkipCausePending
+			result.Reason = err.Error()
+		} else if err != nil {
+			result.Status = models.StatusFailed
+			failure := models.NewFailure(err)
+			failure.Stack, failure.Location = stack, failureLocation(definition, stack)
+			result.Fail(failure)
+		}
+	}
+	result.Duration = time.Since(start)
+	span.end(map[string]string{"definition": result.Definition, "status": string(result.Status)})
+
+	// the hooks can read the result of the step, while the following steps get the context without it
+	afterHooks := c.hooks.AfterStep(models.ContextWithStepResult(ctx, result), tags)
+	scenario.Hooks = append(scenario.Hooks, afterHooks...)
+	if hook := firstFailure(afterHooks); hook != nil && result.Status == models.StatusPassed {
+		result.Status = models.StatusFailed
+		result.Fail(&models.Failure{Message: hook.Error})
+	}
+
+	return result, ctx
+}
+
+// callStep calls the step definition. A panic of the step function is returned as an error with the stack trace of
+// the panic, so it fails the step instead of the run.
+func callStep(ctx context.Context, definition *StepDefinition, arguments []string,
+	argument *messages.PickleStepArgument) (next context.Context, stack string, err error) {
+	next = ctx
+	defer func() {
+		if r := recover(); r != nil {
+			stack = trimStack(debug.Stack())
+			err = fmt.Errorf("step %s panicked: %v", definition.Definition, r)
+		}
+	}()
+
+	next, err = definition.Call(ctx, arguments, argument)
+
+	return next, "", err
+}
+
+// trimStack returns the frames of the stack trace between the panic and the call of the step function with the
+// offsets of their instructions removed, so the trace shows only the code of the step
+func trimStack(stack []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(stack)), "\n")
+	frames := make([]string, 0)
+	panicked := false
+	// the first line is the goroutine, followed by a function and its location for every frame
+	for i := 1; i+1 < len(lines); i += 2 {
+		function := lines[i]
+		if !panicked {
+			panicked = strings.HasPrefix(function, "panic(")
+
+			continue
+		}
+		// step functions are called with reflect, whose frames are followed by the ones of the executor
+		if strings.HasPrefix(function, "reflect.") {
+			break
+		}
+		// frames of the runtime raising panics, such as runtime.panicmem for nil pointers, are left out
+		if strings.HasPrefix(function, "runtime.") {
+			continue
+		}
+		location := lines[i+1]
+		if index := strings.LastIndex(location, " +0x"); index > 0 {
+			location = location[:index]
+		}
+		frames = append(frames, function, location)
+	}
+
+	return strings.Join(frames, "\n")
+}
+
+// failureLocation returns the location of the first frame of the stack trace of a panic, or where the step is
+// defined if it did not panic
+func failureLocation(definition *StepDefinition, stack string) *models.SourceLocation {
+	if frames := strings.Split(stack, "\n"); len(frames) > 1 {
+		location := strings.TrimSpace(frames[1])
+		if index := strings.LastIndex(location, ":"); index > 0 {
+			if line, err := strconv.Atoi(location[index+1:]); err == nil {
+				return &models.SourceLocation{File: location[:index], Line: line}
+			}
+		}
+	}
+
+	return definition.Location
+}
+
+// functionLocation returns the first line of the function, or nil if it is not known
+func functionLocation(function any) *models.SourceLocation {
+	pointer := reflect.ValueOf(function).Pointer()
+	if f := runtime.FuncForPC(pointer); f != nil {
+		file, line := f.FileLine(f.Entry())
+
+		return &models.SourceLocation{File: file, Line: line}
+	}
+
+	return nil
+}
+
+// MatchStep returns the first registered step definition matching the text with the arguments it captures, or
+// nil if no step definition matches
+func (c *StepExecutor) MatchStep(text string) (*StepDefinition, []string) {
+	return c.matchStep(text, messages.PickleStepType_UNKNOWN)
+}
+
+// matchStep returns the step definition matching the text of a step of the type with the arguments it captures.
+// Definitions registered for the type are preferred, then the ones registered for every type, then the ones
+// registered for other types, each in the order of registration.
+func (c *StepExecutor) matchStep(text string, stepType messages.PickleStepType) (*StepDefinition, []string) {
+	var (
+		matched   *StepDefinition
+		arguments []string
+		rank      int
+	)
+	for _, position := range c.index.candidates(text) {
+		step := c.steps[position]
+		current := keywordRank(step.Type, stepType)
+		if matched != nil && current >= rank {
+			continue
+		}
+		if captured, ok := step.Match(text); ok {
+			matched, arguments, rank = step, captured, current
+			if rank == 0 {
+				break
+			}
+		}
+	}
+
+	return matched, arguments
+}
+
+// contextWithWorld returns a copy of the context carrying a new world if the config has a world factory
+func (c *StepExecutor) contextWithWorld(ctx context.Context) context.Context {
+	if c.world == nil {
+		return ctx
+	}
+
+	return models.ContextWithWorld(ctx, c.world())
+}
+
+func pickleTags(pickle *messages.Pickle) []string {
+	tags := make([]string, 0, len(pickle.Tags))
+	for _, tag := range pickle.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	return tags
+}
+
+// documentSteps returns the steps in the document by their AST node id
+func documentSteps(document *messages.GherkinDocument) map[string]*messages.Step {
+	steps := make(map[string]*messages.Step)
+	if document.Feature == nil {
+		return steps
+	}
+	addSteps := func(scenarioSteps []*messages.Step) {
+		for _, step := range scenarioSteps {
+			steps[step.Id] = step
+		}
+	}
+	addScenarioOrBackground := func(background *messages.Background, scenario *messages.Scenario) {
+		if background != nil {
+			addSteps(background.Steps)
+		}
+		if scenario != nil {
+			addSteps(scenario.Steps)
+		}
+	}
+
+	for _, child := range document.Feature.Children {
+		addScenarioOrBackground(child.Background, child.Scenario)
+		if child.Rule != nil {
+			for _, ruleChild := range child.Rule.Children {
+				addScenarioOrBackground(ruleChild.Background, ruleChild.Scenario)
+			}
+		}
+	}
+
+	return steps
+}
+
+// exampleValues returns the values of the Examples row of the scenario outline with the id by their columns
+func exampleValues(scenario *messages.Scenario, id string) []models.ExampleValue {
+	for _, examples := range scenario.Examples {
+		if examples.TableHeader == nil {
+			continue
+		}
+		for _, row := range examples.TableBody {
+			if row.Id != id {
+				continue
+			}
+			values := make([]models.ExampleValue, 0, len(row.Cells))
+			for i, cell := range row.Cells {
+				if i < len(examples.TableHeader.Cells) {
+					values = append(values, models.ExampleValue{Name: examples.TableHeader.Cells[i].Value,
+						Value: cell.Value})
+				}
+			}
+
+			return values
+		}
+	}
+
+	return nil
+}
+
+// trimDescription removes the indentation of the lines of a Gherkin description
+func trimDescription(description string) string {
+	lines := strings.Split(strings.TrimSpace(description), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// describeStep copies the keyword, the line and the argument of the pickle step to the result
+func describeStep(result *models.StepResult, step *messages.PickleStep, steps map[string]*messages.Step) {
+	if len(step.AstNodeIds) > 0 {
+		if source, ok := steps[step.AstNodeIds[0]]; ok {
+			result.Keyword, result.Line = source.Keyword, int(source.Location.Line)
+		}
+	}
+	if step.Argument == nil {
+		return
+	}
+	if step.Argument.DocString != nil {
+		result.DocString = &models.DocString{
+			MediaType: step.Argument.DocString.MediaType,
+			Content:   step.Argument.DocString.Content,
+		}
+	}
+	if step.Argument.DataTable != nil {
+		result.DataTable = make([][]string, 0, len(step.Argument.DataTable.Rows))
+		for _, row := range step.Argument.DataTable.Rows {
+			cells := make([]string, 0, len(row.Cells))
+			for _, cell := range row.Cells {
+				cells = append(cells, cell.Value)
+			}
+			result.DataTable = append(result.DataTable, cells)
 		}
 	}
 }
@@ -0,0 +1,1267 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	gherkin "github.com/cucumber/gherkin/go/v26"
+	messages "github.com/cucumber/messages/go/v21"
+	"github.com/denizgursoy/cacik/pkg/converter"
+	"github.com/denizgursoy/cacik/pkg/gherkin_parser"
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/denizgursoy/cacik/pkg/pattern"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	appleFeature = `Feature: Apples
+
+  Scenario: Eat apples
+    Given I have 3 apples
+    When I eat 1 apple
+`
+)
+
+func parseDocument(t *testing.T, source string) *messages.GherkinDocument {
+	document, err := gherkin_parser.ParseGherkinFile(strings.NewReader(source))
+	require.Nil(t, err)
+
+	return document
+}
+
+func panickingHook(context.Context) error {
+	panic("broken hook")
+}
+
+func failingHook(context.Context) error {
+	return errors.New("hook error")
+}
+
+func TestStepExecutor_Execute(t *testing.T) {
+	t.Run("should call step functions with converted arguments", func(t *testing.T) {
+		apples := 0
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I have (\d+) apples$`, func(ctx context.Context, count int) error {
+			apples = count
+			return nil
+		}))
+		require.Nil(t, executor.RegisterStep(`^I eat (\d+) apple$`, func(count int) {
+			apples -= count
+		}))
+
+		results, err := executor.Execute(parseDocument(t, appleFeature))
+
+		require.Nil(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, models.StatusPassed, results[0].Status)
+		require.Equal(t, 2, apples)
+		require.Equal(t, "Given ", results[0].Steps[0].Keyword)
+		require.Equal(t, `^I have (\d+) apples$`, results[0].Steps[0].Definition)
+		require.Empty(t, results[0].Fingerprint)
+		require.Equal(t, "When ", results[0].Steps[1].Keyword)
+		require.Equal(t, 4, results[0].Steps[0].Line)
+		require.Equal(t, 5, results[0].Steps[1].Line)
+	})
+
+	t.Run("should convert durations", func(t *testing.T) {
+		var waited time.Duration
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I wait (.+)$`, func(duration time.Duration) {
+			waited += duration
+		}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Waiting
+
+  Scenario: Wait
+    Given I wait 1d 2h
+    And I wait 5µs
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status)
+		require.Equal(t, 26*time.Hour+5*time.Microsecond, waited)
+	})
+
+	t.Run("should convert UUIDs, IP addresses, versions and JSON", func(t *testing.T) {
+		type order struct {
+			ID    int      `json:"id"`
+			Items []string `json:"items"`
+		}
+		var (
+			id       uuid.UUID
+			address  net.IP
+			version  converter.Version
+			received order
+			fields   map[string]any
+		)
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^order {uuid} is sent from {ip}$`, func(value uuid.UUID, ip net.IP) {
+			id, address = value, ip
+		}))
+		require.Nil(t, executor.RegisterStep(`^the service runs {semver}$`, func(value converter.Version) {
+			version = value
+		}))
+		require.Nil(t, executor.RegisterStep(`^the order is {json}$`, func(value order) {
+			received = value
+		}))
+		require.Nil(t, executor.RegisterStep(`^the response is$`, func(value map[string]any) {
+			fields = value
+		}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Orders
+
+  Scenario: Send order
+    Given order 123e4567-e89b-12d3-a456-426614174000 is sent from 192.0.2.1
+    And the service runs v2.1.0-rc.1
+    When the order is '{"id": 7, "items": ["apple"]}'
+    Then the response is
+      """json
+      {"status": "accepted"}
+      """
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status, results[0].Steps)
+		require.Equal(t, uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"), id)
+		require.True(t, net.ParseIP("192.0.2.1").Equal(address))
+		require.Equal(t, converter.Version{Major: 2, Minor: 1, PreRelease: "rc.1"}, version)
+		require.Equal(t, order{ID: 7, Items: []string{"apple"}}, received)
+		require.Equal(t, map[string]any{"status": "accepted"}, fields)
+	})
+
+	t.Run("should pass doc strings as is to string parameters", func(t *testing.T) {
+		var body string
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^the message is$`, func(value string) {
+			body = value
+		}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Messages
+
+  Scenario: Send message
+    Given the message is
+      """
+      hello world
+      """
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status, results[0].Steps)
+		require.Equal(t, "hello world", body)
+	})
+
+	t.Run("should convert numbers, percentages and ordinals", func(t *testing.T) {
+		var (
+			position int
+			price    float64
+			discount float64
+			label    string
+		)
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^the {ordinal} item costs {number} with {percent} off$`,
+			func(item int, cost float64, off float64) {
+				position, price, discount = item, cost, off
+			}))
+		require.Nil(t, executor.RegisterStep(`^the label says {percent}$`, func(text string) {
+			label = text
+		}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Discounts
+
+  Scenario: Discount an item
+    Given the third item costs 1,250.5 with 20% off
+    Then the label says 20 %
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status, results[0].Steps)
+		require.Equal(t, 3, position)
+		require.Equal(t, 1250.5, price)
+		require.InDelta(t, 0.2, discount, 1e-9)
+		require.Equal(t, "20 %", label)
+	})
+
+	t.Run("should convert the parameters of definitions built with the pattern builder", func(t *testing.T) {
+		var (
+			position int
+			discount float64
+			received map[string]any
+			paid     money
+		)
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterParameterType("money", `\d+ (?:EUR|USD)`, parseMoney))
+		require.Nil(t, executor.RegisterStep(pattern.NewBuilder().Literal("the ").Parameter("ordinal").
+			Literal(" item has ").Parameter("percent").Literal(" off").MustBuild(), func(item int, off float64) {
+			position, discount = item, off
+		}))
+		require.Nil(t, executor.RegisterStep(pattern.NewBuilder().Literal("the order is ").Parameter("json").
+			MustBuild(), func(value map[string]any) {
+			received = value
+		}))
+		require.Nil(t, executor.RegisterStep(pattern.NewBuilder().Literal("I pay ").Parameter("money").MustBuild(),
+			func(amount money) {
+				paid = amount
+			}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Discounts
+
+  Scenario: Discount an item
+    Given the 2nd item has 25% off
+    When the order is '{"id": 7}'
+    Then I pay 5 EUR
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status, results[0].Steps)
+		require.Equal(t, 2, position)
+		require.InDelta(t, 0.25, discount, 1e-9)
+		require.Equal(t, map[string]any{"id": 7.0}, received)
+		require.Equal(t, money{amount: 5, currency: "EUR"}, paid)
+	})
+
+	t.Run("should fail the step if the JSON is not valid", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^the order is {json}$`, func(map[string]any) {}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Orders
+
+  Scenario: Send order
+    When the order is '{"id": }'
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusFailed, results[0].Status)
+		require.Contains(t, results[0].Steps[0].Error, "could not parse")
+	})
+
+	t.Run("should pass named capture groups as Args", func(t *testing.T) {
+		var captured models.Args
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I move (?P<count>\d+) boxes to (?P<room>\w+) in {duration} on {date}$`,
+			func(ctx context.Context, args models.Args) {
+				captured = args
+			}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Boxes
+
+  Scenario: Move boxes
+    When I move 3 boxes to kitchen in 5m on 2024-02-29
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status, results[0].Steps)
+		require.Equal(t, models.Args{
+			"count": "3",
+			"room":  "kitchen",
+			"3":     5 * time.Minute,
+			"4":     time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		}, captured)
+		count, err := captured.GetInt("count")
+		require.Nil(t, err)
+		require.Equal(t, 3, count)
+	})
+
+	t.Run("should match parameter types", func(t *testing.T) {
+		apples := 0
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(count int) {
+			apples = count
+		}))
+		require.Nil(t, executor.RegisterStep(`^I eat {int} apple$`, func(count int) {
+			apples -= count
+		}))
+
+		results, err := executor.Execute(parseDocument(t, appleFeature))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status)
+		require.Equal(t, 2, apples)
+		require.Equal(t, `^I have {int} apples$`, results[0].Steps[0].Definition)
+	})
+	t.Run("should split captured lists for slice parameters", func(t *testing.T) {
+		var features []string
+		var counts []int
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I enable features (.+)$`, func(enabled []string) {
+			features = enabled
+		}))
+		require.Nil(t, executor.RegisterStep(`^the counts are {}$`, func(values []int) {
+			counts = values
+		}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Lists
+
+  Scenario: Enable features
+    Given I enable features a, b, c
+    And the counts are 1, 2
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status)
+		require.Equal(t, []string{"a", "b", "c"}, features)
+		require.Equal(t, []int{1, 2}, counts)
+	})
+	t.Run("should copy the descriptions of the feature and the scenario", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(int) {}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Apples
+    As a customer
+    I want apples
+
+  Scenario: Buy apples
+      Apples are sold by piece
+
+    Given I have 3 apples
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, "As a customer\nI want apples", results[0].FeatureDescription)
+		require.Equal(t, "Apples are sold by piece", results[0].Description)
+	})
+	t.Run("should copy the feature, the scenario line and the values of the Examples row", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I have {int} {word}$`, func(int, string) {}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Fruits
+
+  Scenario Outline: Buy fruits
+    Given I have <count> <fruit>
+
+    Examples:
+      | fruit  | count |
+      | apples | 3     |
+      | pears  | 5     |
+`))
+
+		require.Nil(t, err)
+		require.Len(t, results, 2)
+		require.Equal(t, "Fruits", results[1].Feature)
+		require.Equal(t, 3, results[1].ScenarioLine)
+		require.Equal(t, 9, results[1].Line)
+		require.Equal(t, []models.ExampleValue{{Name: "fruit", Value: "pears"}, {Name: "count", Value: "5"}},
+			results[1].Examples)
+	})
+	t.Run("should fail the step with the argument index if a date does not exist", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^the order of {int} apples is due on {date}$`, func(int, time.Time) {}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Orders
+
+  Scenario: Order apples
+    Given the order of 3 apples is due on 31 February 2024
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusFailed, results[0].Status)
+		require.Equal(t, `could not convert argument 2 of step ^the order of {int} apples is due on {date}$, `+
+			`error=could not parse "31 February 2024" as date, day 31 of February 2024 is out of range, the month `+
+			`has 29 days`, results[0].Steps[0].Error)
+	})
+
+	t.Run("should keep the order of the document when scenarios are executed at the same time", func(t *testing.T) {
+		executor := NewStepExecutor()
+		executor.SetConfig(&models.Config{Concurrency: 3, ResourceLimits: map[string]int{"@heavy": 1}})
+		require.Nil(t, executor.RegisterStep(`^I wait {int} ms$`, func(milliseconds int) {
+			time.Sleep(time.Duration(milliseconds) * time.Millisecond)
+		}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Waiting
+
+  @heavy
+  Scenario: Slow
+    Given I wait 20 ms
+
+  Scenario: Fast
+    Given I wait 1 ms
+
+  @heavy
+  Scenario: Slow again
+    Given I wait 20 ms
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, []string{"Slow", "Fast", "Slow again"},
+			[]string{results[0].Name, results[1].Name, results[2].Name})
+	})
+
+	t.Run("should mark undefined steps and skip the rest", func(t *testing.T) {
+		executor := NewStepExecutor()
+
+		results, err := executor.Execute(parseDocument(t, appleFeature))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusUndefined, results[0].Status)
+		require.Equal(t, models.StatusUndefined, results[0].Steps[0].Status)
+		require.Equal(t, models.StatusSkipped, results[0].Steps[1].Status)
+	})
+
+	t.Run("should attribute hook panics to the hook and fail the step", func(t *testing.T) {
+		executor := NewStepExecutor()
+		executor.SetConfig(&models.Config{BeforeStep: panickingHook})
+		require.Nil(t, executor.RegisterStep(`^I have (\d+) apples$`, func(int) {}))
+
+		results, err := executor.Execute(parseDocument(t, appleFeature))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusFailed, results[0].Status)
+		require.Len(t, results[0].Hooks, 1)
+		require.Equal(t, models.StatusFailed, results[0].Hooks[0].Status)
+		require.True(t, strings.HasSuffix(results[0].Hooks[0].Name, "panickingHook"))
+		require.Contains(t, results[0].Steps[0].Error, "broken hook")
+		require.Equal(t, results[0].FailureFingerprint(), results[0].Fingerprint)
+		require.NotEmpty(t, results[0].Fingerprint)
+	})
+}
+
+func TestStepExecutor_ExecutePickle(t *testing.T) {
+	t.Run("should execute the pickle even if the filter does not select it", func(t *testing.T) {
+		apples := 0
+		executor := NewStepExecutor()
+		executor.SetFilter(func(*messages.GherkinDocument, *messages.Pickle) bool {
+			return false
+		})
+		require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(count int) {
+			apples = count
+		}))
+		require.Nil(t, executor.RegisterStep(`^I eat {int} apple$`, func(count int) {
+			apples -= count
+		}))
+		document := parseDocument(t, appleFeature)
+		pickles := gherkin.Pickles(*document, document.Uri, (&messages.Incrementing{}).NewId)
+
+		result := executor.ExecutePickle(context.Background(), document, pickles[0])
+
+		require.Equal(t, models.StatusPassed, result.Status)
+		require.Equal(t, 2, apples)
+		require.Equal(t, 3, result.Line)
+		require.Equal(t, "When ", result.Steps[1].Keyword)
+	})
+}
+
+func TestStepExecutor_MatchStep(t *testing.T) {
+	executor := NewStepExecutor()
+	require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(int) {}))
+
+	t.Run("should return the matching definition with its arguments", func(t *testing.T) {
+		definition, arguments := executor.MatchStep("I have 3 apples")
+
+		require.NotNil(t, definition)
+		require.Equal(t, `^I have {int} apples$`, definition.Definition)
+		require.Equal(t, []string{"3"}, arguments)
+	})
+
+	t.Run("should return nil if no definition matches", func(t *testing.T) {
+		definition, arguments := executor.MatchStep("I have some pears")
+
+		require.Nil(t, definition)
+		require.Nil(t, arguments)
+	})
+}
+
+func TestStepDefinition_Arguments(t *testing.T) {
+	t.Run("should return the captured parameters with their offsets, names and types", func(t *testing.T) {
+		definition, err := NewStepDefinition(`^I move (?P<count>\d+) apples to {string}( quickly)?$`,
+			func(models.Args) {})
+		require.Nil(t, err)
+
+		arguments := definition.Arguments(`I move 3 apples to "the basket"`)
+
+		require.Equal(t, []*models.StepArgument{
+			{Value: "3", Start: 7, End: 8, Name: "count"},
+			{Value: "the basket", Start: 20, End: 30, Type: "string"},
+		}, arguments)
+		require.Nil(t, definition.Arguments("I move apples"))
+	})
+	t.Run("should set the arguments of executed steps", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(int) {}))
+		require.Nil(t, executor.RegisterStep(`^I eat (\d+) apple$`, func(int) {}))
+
+		results, err := executor.Execute(parseDocument(t, appleFeature))
+
+		require.Nil(t, err)
+		require.Equal(t, []*models.StepArgument{{Value: "3", Start: 7, End: 8, Type: "int"}},
+			results[0].Steps[0].Arguments)
+		require.Equal(t, []*models.StepArgument{{Value: "1", Start: 6, End: 7}}, results[0].Steps[1].Arguments)
+	})
+}
+
+func TestStepExecutor_Execute_DataTable(t *testing.T) {
+	t.Run("should convert data tables to maps", func(t *testing.T) {
+		var user map[string]string
+		var products []map[string]string
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^user$`, func(values map[string]string) {
+			user = values
+		}))
+		require.Nil(t, executor.RegisterStep(`^(\d+) products$`, func(ctx context.Context, count int, rows []map[string]string) {
+			products = rows
+		}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Tables
+
+  Scenario: Convert tables
+    Given user
+      | name | deniz |
+      | age  | 30    |
+    And 2 products
+      | name  | price |
+      | apple | 3     |
+      | pear  | 4     |
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status)
+		require.Equal(t, map[string]string{"name": "deniz", "age": "30"}, user)
+		require.Equal(t, []map[string]string{{"name": "apple", "price": "3"}, {"name": "pear", "price": "4"}}, products)
+	})
+	t.Run("should convert data tables to Table", func(t *testing.T) {
+		total := 0
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^products$`, func(table models.Table) error {
+			for _, row := range table.SkipHeader() {
+				price, err := row.GetInt("price")
+				if err != nil {
+					return err
+				}
+				total += price
+			}
+			return nil
+		}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Tables
+
+  Scenario: Convert tables
+    Given products
+      | name  | price |
+      | apple | 3     |
+      | pear  | 4     |
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status)
+		require.Equal(t, 7, total)
+	})
+	t.Run("should fail if table does not have two columns for map", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^user$`, func(values map[string]string) {}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Tables
+
+  Scenario: Convert tables
+    Given user
+      | name | deniz | x |
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusFailed, results[0].Status)
+		require.Contains(t, results[0].Steps[0].Error, "needs a two column table")
+	})
+}
+
+func TestStepExecutor_Execute_SkipScenario(t *testing.T) {
+	t.Run("should skip scenario with the reason returned from BeforeScenario hook", func(t *testing.T) {
+		called := false
+		executor := NewStepExecutor()
+		executor.SetConfig(&models.Config{
+			BeforeScenario: func(ctx context.Context) error {
+				return models.SkipScenario("database is not available")
+			},
+		})
+		require.Nil(t, executor.RegisterStep(`^I have (\d+) apples$`, func(int) {
+			called = true
+		}))
+
+		results, err := executor.Execute(parseDocument(t, appleFeature))
+
+		require.Nil(t, err)
+		require.False(t, called)
+		require.Equal(t, models.StatusSkipped, results[0].Status)
+		require.Equal(t, "database is not available", results[0].Reason)
+		require.Equal(t, models.SkipCauseHook, results[0].SkipCause)
+		require.Equal(t, models.StatusSkipped, results[0].Hooks[0].Status)
+		require.Equal(t, models.StatusSkipped, results[0].Steps[0].Status)
+		require.Equal(t, models.SkipCauseHook, results[0].Steps[0].SkipCause)
+	})
+
+	t.Run("should skip scenarios tagged with @skip", func(t *testing.T) {
+		executor := NewStepExecutor()
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Apples
+
+  @skip
+  Scenario: Eat apples
+    Given I have 3 apples
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusSkipped, results[0].Status)
+		require.Equal(t, models.SkipCauseTag, results[0].SkipCause)
+		require.Equal(t, "scenario is tagged with @skip", results[0].Steps[0].Reason)
+	})
+
+	t.Run("should skip the rest of the scenario after a pending step", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(int) error {
+			return fmt.Errorf("waiting for the orchard API: %w", models.ErrPending)
+		}))
+
+		results, err := executor.Execute(parseDocument(t, appleFeature))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusSkipped, results[0].Status)
+		require.Equal(t, models.SkipCausePending, results[0].SkipCause)
+		require.Equal(t, "waiting for the orchard API: step is pending", results[0].Reason)
+		require.Equal(t, models.SkipCausePending, results[0].Steps[0].SkipCause)
+		require.Equal(t, models.SkipCausePreviousStep, results[0].Steps[1].SkipCause)
+		require.Equal(t, `step "I have 3 apples" skipped`, results[0].Steps[1].Reason)
+	})
+
+	t.Run("should skip the scenarios after a failed scenario with fail fast", func(t *testing.T) {
+		executor := NewStepExecutor()
+		executor.SetConfig(&models.Config{FailFast: true})
+		require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(count int) error {
+			if count == 1 {
+				return errors.New("not enough apples")
+			}
+
+			return nil
+		}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Apples
+
+  Scenario: One apple
+    Given I have 1 apples
+
+  Scenario: Two apples
+    Given I have 2 apples
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusFailed, results[0].Status)
+		require.Empty(t, results[0].SkipCause)
+		require.Equal(t, models.StatusSkipped, results[1].Status)
+		require.Equal(t, models.SkipCauseFailFast, results[1].SkipCause)
+	})
+
+	t.Run("should execute the scenarios of a new run after a failed run with fail fast", func(t *testing.T) {
+		executor := NewStepExecutor()
+		executor.SetConfig(&models.Config{FailFast: true})
+		require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(count int) error {
+			if count == 1 {
+				return errors.New("not enough apples")
+			}
+
+			return nil
+		}))
+		_, err := executor.Execute(parseDocument(t, `Feature: Apples
+
+  Scenario: One apple
+    Given I have 1 apples
+`))
+		require.Nil(t, err)
+
+		executor.StartRun()
+		results, err := executor.Execute(parseDocument(t, `Feature: Apples
+
+  Scenario: Two apples
+    Given I have 2 apples
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status)
+	})
+}
+
+func TestStepExecutor_Execute_Requirements(t *testing.T) {
+	feature := `Feature: Staging
+
+  @requires(env:CACIK_STAGING_URL) @requires(cmd:go)
+  Scenario: Deploy
+    Given I have 3 apples
+`
+
+	t.Run("should skip scenarios whose preconditions are not met without running hooks", func(t *testing.T) {
+		t.Setenv("CACIK_STAGING_URL", "")
+		hooks := 0
+		countHook := func(context.Context) error {
+			hooks++
+
+			return nil
+		}
+		executor := NewStepExecutor()
+		executor.SetConfig(&models.Config{BeforeScenario: countHook, AfterScenario: countHook})
+		require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(int) {}))
+
+		results, err := executor.Execute(parseDocument(t, feature))
+
+		require.Nil(t, err)
+		require.Equal(t, 0, hooks)
+		require.Equal(t, models.StatusSkipped, results[0].Status)
+		require.Equal(t, "environment variable CACIK_STAGING_URL is not set", results[0].Reason)
+		require.Equal(t, models.SkipCausePrecondition, results[0].SkipCause)
+		require.Equal(t, "@requires(env:CACIK_STAGING_URL)", results[0].Hooks[0].Name)
+		require.Equal(t, models.StatusSkipped, results[0].Steps[0].Status)
+	})
+
+	t.Run("should execute scenarios whose preconditions are met", func(t *testing.T) {
+		t.Setenv("CACIK_STAGING_URL", "https://staging.example.com")
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(int) {}))
+
+		results, err := executor.Execute(parseDocument(t, feature))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status)
+		require.Empty(t, results[0].Hooks)
+	})
+
+	t.Run("should fail scenarios with an unknown precondition", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(int) {}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Staging
+
+  @requires(gpu:cuda)
+  Scenario: Train
+    Given I have 3 apples
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusFailed, results[0].Status)
+		require.Equal(t, "precondition kind gpu of tag @requires(gpu:cuda) is unknown", results[0].FailureMessage())
+	})
+
+	t.Run("should leave tags which only start with requires", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(int) {}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Staging
+
+  @requires_login @requiresAdmin
+  Scenario: Manage
+    Given I have 3 apples
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status)
+		require.Empty(t, results[0].Hooks)
+	})
+}
+
+func TestStepExecutor_SetConfig_Language(t *testing.T) {
+	converter.RegisterMonthNames("de", []string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli",
+		"August", "September", "Oktober", "November", "Dezember"})
+	converter.RegisterMonthNames("fr", []string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet",
+		"août", "septembre", "octobre", "novembre", "décembre"})
+
+	t.Run("should convert the month names of the language of each executor", func(t *testing.T) {
+		feature := parseDocument(t, `Feature: Calendar
+
+  Scenario: German
+    Given the meeting is on 2 März 2024
+
+  Scenario: French
+    Given the meeting is on 2 février 2024
+`)
+		statuses := make(map[string][]models.Status)
+		for _, language := range []string{"de", "fr"} {
+			executor := NewStepExecutor()
+			executor.SetConfig(&models.Config{Language: language})
+			require.Nil(t, executor.ConfigErrors())
+			require.Nil(t, executor.RegisterStep(`^the meeting is on {date}$`, func(time.Time) {}))
+
+			results, err := executor.Execute(feature)
+
+			require.Nil(t, err)
+			statuses[language] = []models.Status{results[0].Status, results[1].Status}
+		}
+
+		require.Equal(t, []models.Status{models.StatusPassed, models.StatusFailed}, statuses["de"])
+		require.Equal(t, []models.Status{models.StatusFailed, models.StatusPassed}, statuses["fr"])
+	})
+	t.Run("should return error for languages without month names", func(t *testing.T) {
+		executor := NewStepExecutor()
+
+		executor.SetConfig(&models.Config{Language: "xx"})
+
+		require.EqualError(t, executor.ConfigErrors(),
+			"month names of language xx are not registered, register them with RegisterMonthNames")
+	})
+}
+
+func TestStepExecutor_Execute_ScenarioData(t *testing.T) {
+	t.Run("should share scenario data between BeforeScenario hook and steps", func(t *testing.T) {
+		tokens := make([]any, 0)
+		executor := NewStepExecutor()
+		executor.SetConfig(&models.Config{
+			BeforeScenario: func(ctx context.Context) error {
+				models.DataFrom(ctx).Set("token", "secret")
+				return nil
+			},
+		})
+		require.Nil(t, executor.RegisterStep(`^I have (\d+) apples$`, func(ctx context.Context, count int) {
+			token, _ := models.DataFrom(ctx).Get("token")
+			tokens = append(tokens, token)
+		}))
+		require.Nil(t, executor.RegisterStep(`^I eat (\d+) apple$`, func(ctx context.Context, count int) {
+			models.DataFrom(ctx).Set("token", "changed")
+		}))
+
+		_, err := executor.Execute(parseDocument(t, appleFeature+`
+  Scenario: Eat more apples
+    Given I have 5 apples
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, []any{"secret", "secret"}, tokens)
+	})
+}
+
+func TestStepExecutor_Execute_World(t *testing.T) {
+	type basket struct{ items int }
+
+	t.Run("should create a world for every scenario shared by its hooks and steps", func(t *testing.T) {
+		totals := make([]int, 0)
+		executor := NewStepExecutor()
+		executor.SetConfig(&models.Config{
+			World: func() any { return &basket{} },
+			AfterScenario: func(ctx context.Context) error {
+				totals = append(totals, models.WorldFrom[*basket](ctx).items)
+				return nil
+			},
+		})
+		require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(ctx context.Context, count int) {
+			models.WorldFrom[*basket](ctx).items += count
+		}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Apples
+
+  Scenario: Three apples
+    Given I have 1 apples
+    And I have 2 apples
+
+  Scenario: One apple
+    Given I have 1 apples
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status)
+		require.Equal(t, []int{3, 1}, totals)
+	})
+}
+
+func TestStepExecutor_Execute_Panic(t *testing.T) {
+	t.Run("should fail the panicking step with its stack trace and continue with the next scenario", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I have (\d+) apples$`, func(count int) {
+			var basket map[string]int
+			basket["apples"] = count
+		}))
+		require.Nil(t, executor.RegisterStep(`^I eat (\d+) apple$`, func(int) {}))
+
+		results, err := executor.Execute(parseDocument(t, appleFeature+`
+  Scenario: Eat more apples
+    When I eat 1 apple
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusFailed, results[0].Status)
+		step := results[0].Steps[0]
+		require.Equal(t, `step ^I have (\d+) apples$ panicked: assignment to entry in nil map`, step.Error)
+		lines := strings.Split(step.Failure.Stack, "\n")
+		require.Len(t, lines, 2)
+		require.Regexp(t, `^github.com/denizgursoy/cacik/pkg/executor.TestStepExecutor_Execute_Panic.func1.1\(`, lines[0])
+		require.Regexp(t, `^\t.+/executor_test.go:\d+$`, lines[1])
+		require.Equal(t, strings.TrimSpace(lines[1]), fmt.Sprintf("%s:%d", step.Failure.Location.File,
+			step.Failure.Location.Line))
+		require.Equal(t, models.StatusSkipped, results[0].Steps[1].Status)
+		require.Equal(t, models.StatusPassed, results[1].Status)
+	})
+}
+
+type comparisonError struct {
+	expected, actual string
+}
+
+func (c *comparisonError) Error() string {
+	return fmt.Sprintf("expected %s, got %s", c.expected, c.actual)
+}
+
+func (c *comparisonError) Expected() string {
+	return c.expected
+}
+
+func (c *comparisonError) Actual() string {
+	return c.actual
+}
+
+func TestStepExecutor_Execute_Failure(t *testing.T) {
+	t.Run("should describe the failure with the compared values and the location of the step function", func(t *testing.T) {
+		var seen *models.StepResult
+		executor := NewStepExecutor()
+		executor.SetConfig(&models.Config{
+			AfterStep: func(ctx context.Context) error {
+				seen = models.StepResultFrom(ctx)
+				return nil
+			},
+		})
+		require.Nil(t, executor.RegisterStep(`^I have (\d+) apples$`, func(count int) error {
+			return fmt.Errorf("basket is wrong, error=%w", &comparisonError{expected: "2", actual: strconv.Itoa(count)})
+		}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Apples
+
+  Scenario: One step
+    Given I have 3 apples
+    And I have 4 apples
+`))
+
+		require.Nil(t, err)
+		step := results[0].Steps[0]
+		require.Equal(t, "basket is wrong, error=expected 2, got 3", step.Error)
+		require.Equal(t, step.Error, step.Failure.Message)
+		require.Equal(t, "2", step.Failure.Expected)
+		require.Equal(t, "3", step.Failure.Actual)
+		require.Empty(t, step.Failure.Stack)
+		require.True(t, strings.HasSuffix(step.Failure.Location.File, "executor_test.go"))
+		require.Positive(t, step.Failure.Location.Line)
+		require.Same(t, step, seen)
+		require.Nil(t, results[0].Steps[1].Failure)
+	})
+	t.Run("should point the failure at the location set for the step", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I have (\d+) apples$`, func(count int) error {
+			return errors.New("no apples")
+		}))
+		executor.SetStepLocation(`^I have (\d+) apples$`, &models.SourceLocation{File: "orchard-steps"})
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Apples
+
+  Scenario: One step
+    Given I have 3 apples
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, &models.SourceLocation{File: "orchard-steps"}, results[0].Steps[0].Failure.Location)
+	})
+	t.Run("should describe undefined steps with their message", func(t *testing.T) {
+		executor := NewStepExecutor()
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Apples
+
+  Scenario: One step
+    Given I have 3 apples
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, &models.Failure{Message: `step "I have 3 apples" is not defined`}, results[0].Steps[0].Failure)
+	})
+	t.Run("should suggest the closest step definitions for undefined steps", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(int) {}))
+		require.Nil(t, executor.RegisterStep(`^I have (\d+) pears$`, func(int) {}))
+		require.Nil(t, executor.RegisterStep(`^I open basket {string}$`, func(string) {}))
+		require.Nil(t, executor.RegisterStep(`^the basket is empty$`, func() {}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Apples
+
+  Scenario: One step
+    Given I hav 3 apple
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, &models.Failure{
+			Message:     `step "I hav 3 apple" is not defined, did you mean ^I have {int} apples$ or ^I have (\d+) pears$?`,
+			Suggestions: []string{`^I have {int} apples$`, `^I have (\d+) pears$`},
+		}, results[0].Steps[0].Failure)
+	})
+}
+
+func TestStepExecutor_Execute_Pause(t *testing.T) {
+	t.Run("should pause before every step with the scenario data of the step", func(t *testing.T) {
+		paused := make([]string, 0)
+		executor := NewStepExecutor()
+		executor.SetPause(func(ctx context.Context, step string) {
+			paused = append(paused, fmt.Sprintf("%s %v", step, models.DataFrom(ctx).Keys()))
+		})
+		require.Nil(t, executor.RegisterStep(`^I have (\d+) apples$`, func(ctx context.Context, count int) {
+			models.DataFrom(ctx).Set("apples", count)
+		}))
+		require.Nil(t, executor.RegisterStep(`^I eat (\d+) apple$`, func(int) {}))
+
+		_, err := executor.Execute(parseDocument(t, appleFeature))
+
+		require.Nil(t, err)
+		require.Equal(t, []string{"I have 3 apples []", "I eat 1 apple [apples]"}, paused)
+	})
+}
+
+func TestStepExecutor_Execute_Trace(t *testing.T) {
+	t.Run("should record the scenario with its hooks, steps and conversions", func(t *testing.T) {
+		trace := NewTrace()
+		executor := NewStepExecutor()
+		executor.SetConfig(&models.Config{BeforeStep: func(context.Context) error { return nil }})
+		executor.SetTrace(trace)
+		require.Nil(t, executor.RegisterStep(`^I have (\d+) apples$`, func(count int) {}))
+		require.Nil(t, executor.RegisterStep(`^I eat (\d+) apple$`, func(count int) {}))
+
+		_, err := executor.Execute(parseDocument(t, appleFeature))
+
+		require.Nil(t, err)
+		names := make([]string, 0)
+		for _, event := range trace.Events() {
+			names = append(names, event.Category+" "+event.Name)
+			require.Equal(t, "X", event.Phase)
+			require.NotZero(t, event.Thread)
+		}
+		hook := "hook " + functionName(executor.hooks.config.BeforeStep)
+		require.Equal(t, []string{hook, "conversion convert argument 1", "step I have 3 apples",
+			hook, "conversion convert argument 1", "step I eat 1 apple", "scenario Eat apples"}, names)
+		require.Equal(t, map[string]string{"argument": "3", "type": "int"}, trace.Events()[1].Args)
+	})
+}
+
+func TestStepExecutor_Execute_Diagnostics(t *testing.T) {
+	t.Run("should measure the allocations and the goroutines left running by the scenario", func(t *testing.T) {
+		stop, stopped := make(chan struct{}), make(chan struct{})
+		defer func() {
+			close(stop)
+			<-stopped
+		}()
+		executor := NewStepExecutor()
+		executor.SetDiagnostics(true)
+		require.Nil(t, executor.RegisterStep(`^I have (\d+) apples$`, func(count int) {
+			go func() {
+				defer close(stopped)
+				<-stop
+			}()
+		}))
+		require.Nil(t, executor.RegisterStep(`^I eat (\d+) apple$`, func(count int) {
+			_ = make([]byte, 1024*1024)
+		}))
+
+		results, err := executor.Execute(parseDocument(t, appleFeature))
+
+		require.Nil(t, err)
+		diagnostics := results[0].Diagnostics
+		require.NotNil(t, diagnostics)
+		require.Equal(t, 1, diagnostics.LeakedGoroutines())
+		require.GreaterOrEqual(t, diagnostics.AllocatedBytes, int64(1024*1024))
+		require.NotZero(t, diagnostics.Allocations)
+	})
+
+	t.Run("should not measure scenarios without diagnostics", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I have (\d+) apples$`, func(count int) {}))
+		require.Nil(t, executor.RegisterStep(`^I eat (\d+) apple$`, func(count int) {}))
+
+		results, err := executor.Execute(parseDocument(t, appleFeature))
+
+		require.Nil(t, err)
+		require.Nil(t, results[0].Diagnostics)
+	})
+}
+
+func TestStepExecutor_Execute_HookContext(t *testing.T) {
+	t.Run("should give every hook the data store and logger of the scenario", func(t *testing.T) {
+		executor := NewStepExecutor()
+		executor.SetConfig(&models.Config{
+			BeforeScenario: func(ctx context.Context) error {
+				models.DataFrom(ctx).Set("fixture", 1)
+				return nil
+			},
+			BeforeStep: func(ctx context.Context) error {
+				value, _ := models.DataFrom(ctx).Get("fixture")
+				models.LoggerFrom(ctx).Logf("before step fixture=%v", value)
+				return nil
+			},
+			AfterStep: func(ctx context.Context) error {
+				models.LoggerFrom(ctx).Log("after step")
+				return nil
+			},
+			AfterScenario: func(ctx context.Context) error {
+				value, _ := models.DataFrom(ctx).Get("fixture")
+				models.LoggerFrom(ctx).Logf("after scenario fixture=%v", value)
+				return nil
+			},
+		})
+		require.Nil(t, executor.RegisterStep(`^I have (\d+) apples$`, func(ctx context.Context, count int) {
+			models.DataFrom(ctx).Set("fixture", count)
+			models.LoggerFrom(ctx).Log("step")
+		}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Apples
+
+  Scenario: One step
+    Given I have 3 apples
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, []string{"before step fixture=1", "step", "after step", "after scenario fixture=3"}, results[0].Logs)
+		require.Equal(t, []string{"before step fixture=1", "step", "after step"}, results[0].Steps[0].Logs)
+	})
+}
+
+func TestStepExecutor_Execute_ContextChaining(t *testing.T) {
+	type basketKey struct{}
+
+	t.Run("should pass the context returned by a step to the following steps and hooks", func(t *testing.T) {
+		seen := make([]any, 0)
+		executor := NewStepExecutor()
+		executor.SetConfig(&models.Config{
+			AfterScenario: func(ctx context.Context) error {
+				seen = append(seen, ctx.Value(basketKey{}))
+				return nil
+			},
+		})
+		require.Nil(t, executor.RegisterStep(`^I open basket {string}$`,
+			func(ctx context.Context, name string) (context.Context, error) {
+				return context.WithValue(ctx, basketKey{}, name), nil
+			}))
+		require.Nil(t, executor.RegisterStep(`^I check the basket$`, func(ctx context.Context) error {
+			seen = append(seen, ctx.Value(basketKey{}))
+			return nil
+		}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Basket
+
+  Scenario: Open basket
+    Given I open basket "fruits"
+    Then I check the basket
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status, results[0].Steps)
+		require.Equal(t, []any{"fruits", "fruits"}, seen)
+	})
+
+	t.Run("should reject step functions returning other values", func(t *testing.T) {
+		err := NewStepExecutor().RegisterStep(`^I count the basket$`, func() int { return 0 })
+
+		require.EqualError(t, err, "step ^I count the basket$ can only return context.Context and error, got int")
+	})
+
+	t.Run("should reject step functions taking a context after other parameters", func(t *testing.T) {
+		err := NewStepExecutor().RegisterStep(`^I open basket {string}$`, func(name string, ctx context.Context) {})
+
+		require.EqualError(t, err, "step ^I open basket {string}$ can only take context.Context as its first parameter")
+	})
+
+	t.Run("should reject variadic step functions", func(t *testing.T) {
+		err := NewStepExecutor().RegisterStep(`^I open baskets {string}$`, func(names ...string) {})
+
+		require.EqualError(t, err, "step ^I open baskets {string}$ can not be variadic")
+	})
+
+	t.Run("should reject step functions without parameters for the captured arguments", func(t *testing.T) {
+		err := NewStepExecutor().RegisterStep(`^I put {int} apples into basket {string}$`, func(count int) {})
+
+		require.EqualError(t, err, "step ^I put {int} apples into basket {string}$ captures 2 arguments but the "+
+			"function has 1 parameters for them")
+	})
+
+	t.Run("should accept step functions taking a data table after the captured arguments", func(t *testing.T) {
+		err := NewStepExecutor().RegisterStep(`^basket {string} has$`, func(name string, table models.Table) {})
+
+		require.Nil(t, err)
+	})
+}
+
+func TestStepExecutor_Execute_Attachments(t *testing.T) {
+	t.Run("should store attachments on the step or the scenario they were made in", func(t *testing.T) {
+		executor := NewStepExecutor()
+		executor.SetConfig(&models.Config{
+			BeforeScenario: func(ctx context.Context) error {
+				models.Attach(ctx, "setup", "text/plain", []byte("setup"))
+				return nil
+			},
+			AfterScenario: func(ctx context.Context) error {
+				models.Attach(ctx, "screenshot", "image/png", []byte{1, 2})
+				return nil
+			},
+		})
+		require.Nil(t, executor.RegisterStep(`^I have (\d+) apples$`, func(ctx context.Context, count int) {
+			models.Attach(ctx, "response", "application/json", []byte(`{"apples":3}`))
+		}))
+		require.Nil(t, executor.RegisterStep(`^I eat (\d+) apple$`, func(int) {}))
+
+		results, err := executor.Execute(parseDocument(t, appleFeature))
+
+		require.Nil(t, err)
+		require.Equal(t, []*models.Attachment{
+			{Name: "response", MediaType: "application/json", Data: []byte(`{"apples":3}`)},
+		}, results[0].Steps[0].Attachments)
+		require.Empty(t, results[0].Steps[1].Attachments)
+		require.Len(t, results[0].Attachments, 2)
+		require.Equal(t, "setup", results[0].Attachments[0].Name)
+		require.Equal(t, "screenshot", results[0].Attachments[1].Name)
+	})
+}
+
+func TestStepExecutor_Execute_TaggedHooks(t *testing.T) {
+	t.Run("should run hook sets only for scenarios matching their tags", func(t *testing.T) {
+		resets := make([]string, 0)
+		executor := NewStepExecutor()
+		executor.SetConfig(&models.Config{
+			Hooks: []*models.Hooks{
+				{
+					Tags: "@db and not @slow",
+					BeforeScenario: func(ctx context.Context) error {
+						resets = append(resets, "db")
+						return nil
+					},
+				},
+				{
+					Tags: "@ui",
+					BeforeScenario: func(ctx context.Context) error {
+						resets = append(resets, "ui")
+						return nil
+					},
+				},
+			},
+		})
+		require.Nil(t, executor.RegisterStep(`^I have (\d+) apples$`, func(int) {}))
+
+		document := parseDocument(t, `Feature: Apples
+
+  @db
+  Scenario: With database
+    Given I have 3 apples
+
+  @db @slow
+  Scenario: Slow with database
+    Given I have 3 apples
+`)
+		results, err := executor.Execute(document)
+
+		require.Nil(t, err)
+		require.Equal(t, []string{"db"}, resets)
+		require.Len(t, results[0].Hooks, 1)
+		require.Len(t, results[1].Hooks, 0)
+	})
+}
+
+func TestHookExecutor(t *testing.T) {
+	t.Run("should return nil if hook is not set", func(t *testing.T) {
+		require.Nil(t, NewHookExecutor(nil).BeforeAll(context.Background()))
+	})
+
+	t.Run("should record name, duration and error of the hook", func(t *testing.T) {
+		result := NewHookExecutor(&models.Config{AfterAll: failingHook}).AfterAll(context.Background())
+
+		require.NotNil(t, result)
+		require.Equal(t, models.StatusFailed, result.Status)
+		require.True(t, strings.HasSuffix(result.Name, "failingHook"))
+		require.Contains(t, result.Error, "hook error")
+		require.Greater(t, result.Duration, time.Duration(0))
+	})
+}
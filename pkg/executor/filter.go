@@ -0,0 +1,123 @@
+package executor
+
+import (
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	messages "github.com/cucumber/messages/go/v21"
+)
+
+// AllFilters returns a filter selecting the pickles selected by every filter
+func AllFilters(filters ...PickleFilter) PickleFilter {
+	return func(document *messages.GherkinDocument, pickle *messages.Pickle) bool {
+		for _, filter := range filters {
+			if !filter(document, pickle) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// ShardFilter selects the pickles of the shard with the index out of total shards. A pickle is assigned to a shard
+// by a stable hash of its feature URI, scenario name and Examples row index, so every shard of a CI job selects
+// a disjoint set of scenarios without coordination.
+func ShardFilter(index, total int) PickleFilter {
+	return func(document *messages.GherkinDocument, pickle *messages.Pickle) bool {
+		hash := fnv.New32a()
+		_, _ = fmt.Fprintf(hash, "%s\x00%s\x00%d", pickle.Uri, pickle.Name, ExampleIndex(document, pickle))
+
+		return int(hash.Sum32()%uint32(total)) == index
+	}
+}
+
+// ExampleIndex returns the position of the Examples row the pickle was created from among all Examples rows of
+// its scenario, or -1 if the pickle is not created from a scenario outline
+func ExampleIndex(document *messages.GherkinDocument, pickle *messages.Pickle) int {
+	if len(pickle.AstNodeIds) < 2 {
+		return -1
+	}
+	scenario := findScenario(document, pickle.AstNodeIds[0])
+	if scenario == nil {
+		return -1
+	}
+
+	index := 0
+	for _, examples := range scenario.Examples {
+		for _, row := range examples.TableBody {
+			if row.Id == pickle.AstNodeIds[1] {
+				return index
+			}
+			index++
+		}
+	}
+
+	return -1
+}
+
+// NameFilter selects the pickles whose name matches the pattern
+func NameFilter(pattern *regexp.Regexp) PickleFilter {
+	return func(document *messages.GherkinDocument, pickle *messages.Pickle) bool {
+		return pattern.MatchString(pickle.Name)
+	}
+}
+
+// LocationFilter selects the pickles at the lines of the feature files. Locations are keyed by the path of the
+// feature file, and a line is either the line of a scenario or the line of an Examples row.
+func LocationFilter(locations map[string][]int) PickleFilter {
+	cleaned := make(map[string][]int, len(locations))
+	for path, lines := range locations {
+		cleaned[filepath.Clean(path)] = append(cleaned[filepath.Clean(path)], lines...)
+	}
+
+	return func(document *messages.GherkinDocument, pickle *messages.Pickle) bool {
+		return slices.Contains(cleaned[filepath.Clean(pickle.Uri)], PickleLine(document, pickle))
+	}
+}
+
+// PickleLine returns the line of the Examples row the pickle was created from, or the line of its scenario if the
+// pickle is not created from a scenario outline. It returns 0 if the scenario is not found in the document.
+func PickleLine(document *messages.GherkinDocument, pickle *messages.Pickle) int {
+	if len(pickle.AstNodeIds) == 0 {
+		return 0
+	}
+	scenario := findScenario(document, pickle.AstNodeIds[0])
+	if scenario == nil {
+		return 0
+	}
+	if len(pickle.AstNodeIds) > 1 {
+		for _, examples := range scenario.Examples {
+			for _, row := range examples.TableBody {
+				if row.Id == pickle.AstNodeIds[1] {
+					return int(row.Location.Line)
+				}
+			}
+		}
+	}
+
+	return int(scenario.Location.Line)
+}
+
+func findScenario(document *messages.GherkinDocument, id string) *messages.Scenario {
+	if document.Feature == nil {
+		return nil
+	}
+	for _, child := range document.Feature.Children {
+		if child.Scenario != nil && child.Scenario.Id == id {
+			return child.Scenario
+		}
+		if child.Rule != nil {
+			for _, ruleChild := range child.Rule.Children {
+				if ruleChild.Scenario != nil && ruleChild.Scenario.Id == id {
+					return ruleChild.Scenario
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package executor
+
+import (
+	"regexp"
+	"testing"
+
+	gherkin "github.com/cucumber/gherkin/go/v26"
+	messages "github.com/cucumber/messages/go/v21"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	outlineFeature = `Feature: Outline
+
+  Scenario: Plain
+    Given I have 1 apples
+
+  Scenario Outline: Eat <count>
+    Given I have <count> apples
+
+    Examples:
+      | count |
+      | 1     |
+      | 2     |
+
+    Examples:
+      | count |
+      | 3     |
+`
+)
+
+func TestExampleIndex(t *testing.T) {
+	t.Run("should return index of the row among all examples", func(t *testing.T) {
+		document := parseDocument(t, outlineFeature)
+		pickles := gherkin.Pickles(*document, "a.feature", (&messages.Incrementing{}).NewId)
+
+		indexes := make([]int, 0)
+		for _, pickle := range pickles {
+			indexes = append(indexes, ExampleIndex(document, pickle))
+		}
+
+		require.Equal(t, []int{-1, 0, 1, 2}, indexes)
+	})
+}
+
+func TestShardFilter(t *testing.T) {
+	t.Run("should assign every pickle to exactly one shard", func(t *testing.T) {
+		document := parseDocument(t, outlineFeature)
+		pickles := gherkin.Pickles(*document, "a.feature", (&messages.Incrementing{}).NewId)
+		total := 3
+
+		for _, pickle := range pickles {
+			selected := 0
+			for index := 0; index < total; index++ {
+				if ShardFilter(index, total)(document, pickle) {
+					selected++
+				}
+			}
+			require.Equal(t, 1, selected, pickle.Name)
+		}
+	})
+	t.Run("should be stable across parses", func(t *testing.T) {
+		first := parseDocument(t, outlineFeature)
+		second := parseDocument(t, outlineFeature)
+		firstPickles := gherkin.Pickles(*first, "a.feature", (&messages.Incrementing{}).NewId)
+		secondPickles := gherkin.Pickles(*second, "a.feature", (&messages.Incrementing{}).NewId)
+
+		for i := range firstPickles {
+			require.Equal(t, ShardFilter(0, 2)(first, firstPickles[i]), ShardFilter(0, 2)(second, secondPickles[i]))
+		}
+	})
+}
+
+func TestPickleLine(t *testing.T) {
+	t.Run("should return the line of the scenario or the examples row", func(t *testing.T) {
+		document := parseDocument(t, outlineFeature)
+		pickles := gherkin.Pickles(*document, "a.feature", (&messages.Incrementing{}).NewId)
+
+		lines := make([]int, 0)
+		for _, pickle := range pickles {
+			lines = append(lines, PickleLine(document, pickle))
+		}
+
+		require.Equal(t, []int{3, 11, 12, 16}, lines)
+	})
+}
+
+func TestLocationFilter(t *testing.T) {
+	t.Run("should select the pickles at the locations", func(t *testing.T) {
+		document := parseDocument(t, outlineFeature)
+		pickles := gherkin.Pickles(*document, "features/a.feature", (&messages.Incrementing{}).NewId)
+		filter := LocationFilter(map[string][]int{"./features/a.feature": {3, 12}, "features/b.feature": {11}})
+
+		names := make([]string, 0)
+		for _, pickle := range pickles {
+			if filter(document, pickle) {
+				names = append(names, pickle.Name)
+			}
+		}
+
+		require.Equal(t, []string{"Plain", "Eat 2"}, names)
+	})
+}
+
+func TestNameFilter(t *testing.T) {
+	t.Run("should select the pickles with a matching name", func(t *testing.T) {
+		document := parseDocument(t, outlineFeature)
+		pickles := gherkin.Pickles(*document, "a.feature", (&messages.Incrementing{}).NewId)
+		filter := NameFilter(regexp.MustCompile(`^Eat [12]$`))
+
+		names := make([]string, 0)
+		for _, pickle := range pickles {
+			if filter(document, pickle) {
+				names = append(names, pickle.Name)
+			}
+		}
+
+		require.Equal(t, []string{"Eat 1", "Eat 2"}, names)
+	})
+}
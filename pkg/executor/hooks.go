@@ -0,0 +1,181 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/denizgursoy/cacik/pkg/tag_expression"
+)
+
+type (
+	HookExecutor struct {
+		config   *models.Config
+		hookSets []*hookSet
+	}
+
+	hookSet struct {
+		hooks      *models.Hooks
+		expression tag_expression.Expression
+	}
+
+	hookSelector func(hooks *models.Hooks) func(ctx context.Context) error
+)
+
+// NewHookExecutor creates a hook executor for the config. Hook sets with an invalid tag expression never run,
+// they are reported by ValidateHooks.
+func NewHookExecutor(config *models.Config) *HookExecutor {
+	if config == nil {
+		config = &models.Config{}
+	}
+
+	hookSets := make([]*hookSet, 0)
+	for _, hooks := range config.Hooks {
+		if hooks == nil {
+			continue
+		}
+		expression, err := tag_expression.Parse(hooks.Tags)
+		if err != nil {
+			continue
+		}
+		hookSets = append(hookSets, &hookSet{hooks: hooks, expression: expression})
+	}
+
+	return &HookExecutor{
+		config:   config,
+		hookSets: hookSets,
+	}
+}
+
+// ValidateHooks returns an error for every hook set of the config with an invalid tag expression
+func ValidateHooks(config *models.Config) []error {
+	problems := make([]error, 0)
+	if config == nil {
+		return problems
+	}
+	for _, hooks := range config.Hooks {
+		if hooks == nil {
+			continue
+		}
+		if _, err := tag_expression.Parse(hooks.Tags); err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	return problems
+}
+
+func (h *HookExecutor) BeforeAll(ctx context.Context) *models.HookResult {
+	return h.execute(ctx, h.config.BeforeAll)
+}
+
+func (h *HookExecutor) AfterAll(ctx context.Context) *models.HookResult {
+	return h.execute(ctx, h.config.AfterAll)
+}
+
+// BeforeScenario runs the BeforeScenario hooks matching the scenario tags. A result has StatusSkipped if the
+// hook returned models.SkipScenario.
+func (h *HookExecutor) BeforeScenario(ctx context.Context, tags []string) []*models.HookResult {
+	return h.executeAll(ctx, tags, h.config.BeforeScenario, func(hooks *models.Hooks) func(ctx context.Context) error {
+		return hooks.BeforeScenario
+	})
+}
+
+func (h *HookExecutor) AfterScenario(ctx context.Context, tags []string) []*models.HookResult {
+	return h.executeAll(ctx, tags, h.config.AfterScenario, func(hooks *models.Hooks) func(ctx context.Context) error {
+		return hooks.AfterScenario
+	})
+}
+
+func (h *HookExecutor) BeforeStep(ctx context.Context, tags []string) []*models.HookResult {
+	return h.executeAll(ctx, tags, h.config.BeforeStep, func(hooks *models.Hooks) func(ctx context.Context) error {
+		return hooks.BeforeStep
+	})
+}
+
+func (h *HookExecutor) AfterStep(ctx context.Context, tags []string) []*models.HookResult {
+	return h.executeAll(ctx, tags, h.config.AfterStep, func(hooks *models.Hooks) func(ctx context.Context) error {
+		return hooks.AfterStep
+	})
+}
+
+// executeAll runs the global hook followed by the hooks of every hook set matching the tags
+func (h *HookExecutor) executeAll(ctx context.Context, tags []string, global func(ctx context.Context) error,
+	selector hookSelector) []*models.HookResult {
+	results := make([]*models.HookResult, 0)
+	if result := h.execute(ctx, global); result != nil {
+		results = append(results, result)
+	}
+
+	for _, set := range h.hookSets {
+		if !set.expression.Evaluate(tags) {
+			continue
+		}
+		if result := h.execute(ctx, selector(set.hooks)); result != nil {
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+// execute runs the hook, recovering any panic so that a broken hook is reported as a failure of that hook.
+// It returns nil if the hook is not set.
+func (h *HookExecutor) execute(ctx context.Context, hook func(ctx context.Context) error) (result *models.HookResult) {
+	if hook == nil {
+		return nil
+	}
+
+	result = &models.HookResult{
+		Name:   functionName(hook),
+		Status: models.StatusPassed,
+	}
+
+	start := time.Now()
+	span := traceFrom(ctx).begin(TraceCategoryHook, result.Name)
+	defer func() {
+		result.Duration = time.Since(start)
+		if r := recover(); r != nil {
+			result.Status = models.StatusFailed
+			result.Error = fmt.Sprintf("hook %s panicked: %v", result.Name, r)
+		}
+		span.end(map[string]string{"status": string(result.Status)})
+	}()
+
+	err := hook(ctx)
+	if reason, ok := models.IsSkip(err); ok {
+		result.Status = models.StatusSkipped
+		result.Reason = reason
+	} else if err != nil {
+		result.Status = models.StatusFailed
+		result.Error = fmt.Sprintf("hook %s failed: %s", result.Name, err.Error())
+	}
+
+	return result
+}
+
+// firstFailure returns the first failed hook result
+func firstFailure(results []*models.HookResult) *models.HookResult {
+	for _, result := range results {
+		if result.Status == models.StatusFailed {
+			return result
+		}
+	}
+
+	return nil
+}
+
+func functionName(function any) string {
+	value := reflect.ValueOf(function)
+	if value.Kind() != reflect.Func {
+		return value.Type().String()
+	}
+	if f := runtime.FuncForPC(value.Pointer()); f != nil {
+		return f.Name()
+	}
+
+	return value.Type().String()
+}
@@ -0,0 +1,70 @@
+package executor
+
+import (
+	"fmt"
+
+	messages "github.com/cucumber/messages/go/v21"
+)
+
+var (
+	// keywords are the primary keywords of the step types
+	keywords = map[messages.PickleStepType]string{
+		messages.PickleStepType_CONTEXT: "Given",
+		messages.PickleStepType_ACTION:  "When",
+		messages.PickleStepType_OUTCOME: "Then",
+	}
+)
+
+// RegisterGiven registers the function for the step definition of Given steps, which set up the context of a
+// scenario. And and But steps following a Given step are Given steps too. Using the definition for When or Then
+// steps adds a warning to the step, or fails it if keywords are strict.
+func (c *StepExecutor) RegisterGiven(definition string, function any) error {
+	return c.registerStep(definition, function, messages.PickleStepType_CONTEXT)
+}
+
+// RegisterWhen registers the function for the step definition of When steps, which perform the action of a
+// scenario, see RegisterGiven
+func (c *StepExecutor) RegisterWhen(definition string, function any) error {
+	return c.registerStep(definition, function, messages.PickleStepType_ACTION)
+}
+
+// RegisterThen registers the function for the step definition of Then steps, which check the outcome of a
+// scenario, see RegisterGiven
+func (c *StepExecutor) RegisterThen(definition string, function any) error {
+	return c.registerStep(definition, function, messages.PickleStepType_OUTCOME)
+}
+
+// SetStrictKeywords sets whether steps using a definition registered for another keyword fail instead of getting
+// a warning. Definitions registered with RegisterStep are used for every keyword.
+func (c *StepExecutor) SetStrictKeywords(strict bool) {
+	c.strictKeywords = strict
+}
+
+// keywordRank ranks a definition registered for the definition type for a step of the step type: 0 if the types
+// are the same or the step has no keyword type, 1 if the definition is registered for every type and 2 otherwise
+func keywordRank(definitionType, stepType messages.PickleStepType) int {
+	if _, ok := keywords[stepType]; !ok || definitionType == stepType {
+		return 0
+	}
+	if _, ok := keywords[definitionType]; !ok {
+		return 1
+	}
+
+	return 2
+}
+
+// checkKeyword returns why the definition should not be used for the step if the definition is registered for
+// another keyword than the one of the step, or an empty string
+func checkKeyword(definition *StepDefinition, step *messages.PickleStep) string {
+	registered, ok := keywords[definition.Type]
+	if !ok {
+		return ""
+	}
+	used, ok := keywords[step.Type]
+	if !ok || definition.Type == step.Type {
+		return ""
+	}
+
+	return fmt.Sprintf("step %q is a %s step but %s is registered for %s steps", step.Text, used,
+		definition.Definition, registered)
+}
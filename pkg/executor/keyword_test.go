@@ -0,0 +1,77 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+const keywordFeature = `Feature: Basket
+
+  Scenario: Fill basket
+    Given the basket is empty
+    And I put 3 apples into the basket
+    When I weigh the basket
+    Then the basket has 3 apples
+`
+
+func TestStepExecutor_RegisterGiven(t *testing.T) {
+	newExecutor := func(t *testing.T) *StepExecutor {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterGiven(`^the basket is empty$`, func() {}))
+		require.Nil(t, executor.RegisterThen(`^I put {int} apples into the basket$`, func(int) {}))
+		require.Nil(t, executor.RegisterWhen(`^I weigh the basket$`, func() {}))
+		require.Nil(t, executor.RegisterStep(`^the basket has {int} apples$`, func(int) {}))
+
+		return executor
+	}
+
+	t.Run("should warn about steps using a definition of another keyword", func(t *testing.T) {
+		results, err := newExecutor(t).Execute(parseDocument(t, keywordFeature))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status)
+		require.Empty(t, results[0].Steps[0].Warnings)
+		require.Equal(t, []string{`step "I put 3 apples into the basket" is a Given step but ` +
+			`^I put {int} apples into the basket$ is registered for Then steps`}, results[0].Steps[1].Warnings)
+		require.Empty(t, results[0].Steps[2].Warnings)
+		require.Empty(t, results[0].Steps[3].Warnings)
+	})
+	t.Run("should fail steps using a definition of another keyword if keywords are strict", func(t *testing.T) {
+		executor := newExecutor(t)
+		executor.SetStrictKeywords(true)
+
+		results, err := executor.Execute(parseDocument(t, keywordFeature))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusFailed, results[0].Status)
+		require.Equal(t, models.StatusPassed, results[0].Steps[0].Status)
+		require.Equal(t, models.StatusFailed, results[0].Steps[1].Status)
+		require.Equal(t, `step "I put 3 apples into the basket" is a Given step but `+
+			`^I put {int} apples into the basket$ is registered for Then steps`, results[0].Steps[1].Error)
+		require.Equal(t, models.StatusSkipped, results[0].Steps[2].Status)
+	})
+	t.Run("should prefer the definition registered for the keyword of the step", func(t *testing.T) {
+		used := make([]string, 0)
+		executor := NewStepExecutor()
+		executor.SetStrictKeywords(true)
+		require.Nil(t, executor.RegisterGiven(`^the basket is empty$`, func() { used = append(used, "given") }))
+		require.Nil(t, executor.RegisterStep(`^the basket is empty$`, func() { used = append(used, "any") }))
+		require.Nil(t, executor.RegisterThen(`^the basket is empty$`, func() { used = append(used, "then") }))
+		require.Nil(t, executor.RegisterWhen(`^I empty the basket$`, func() { used = append(used, "when") }))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Basket
+
+  Scenario: Empty basket
+    Given the basket is empty
+    When I empty the basket
+    And the basket is empty
+    Then the basket is empty
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status, results[0].Steps)
+		require.Equal(t, []string{"given", "when", "any", "then"}, used)
+	})
+}
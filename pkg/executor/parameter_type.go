@@ -0,0 +1,37 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/denizgursoy/cacik/pkg/pattern"
+)
+
+type (
+	parameterType struct {
+		regex     string
+		transform func(string) (any, error)
+	}
+)
+
+// RegisterParameterType adds the parameter type {name} matching the regular expression, such as {money} matching
+// `\d+ (?:EUR|USD)`. The text matched by it is converted with the transform function, whose result is passed to
+// the parameter of the step function. Parameter types must be registered before the steps using them, steps
+// using a parameter type which is not registered are rejected.
+func (c *StepExecutor) RegisterParameterType(name, regex string, transform func(string) (any, error)) error {
+	if err := pattern.ValidateType(name, regex); err != nil {
+		return err
+	}
+	if transform == nil {
+		return errors.New("transform function of parameter type {" + name + "} can not be nil")
+	}
+	if _, ok := c.parameterTypes[name]; ok {
+		return fmt.Errorf("parameter type {%s} is registered more than once", name)
+	}
+	c.parameterTypes[name] = &parameterType{
+		regex:     regex,
+		transform: transform,
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package executor
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+type money struct {
+	amount   int
+	currency string
+}
+
+func parseMoney(text string) (any, error) {
+	fields := strings.Fields(text)
+	amount, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return money{amount: amount, currency: fields[1]}, nil
+}
+
+func TestStepExecutor_RegisterParameterType(t *testing.T) {
+	t.Run("should pass the transformed value of custom parameter types", func(t *testing.T) {
+		var paid money
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterParameterType("money", `\d+ (?:EUR|USD)`, parseMoney))
+		require.Nil(t, executor.RegisterStep(`^I pay {money} for {int} apples$`, func(amount money, count int) {
+			paid = amount
+		}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Shop
+
+  Scenario: Pay
+    Given I pay 5 EUR for 3 apples
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status)
+		require.Equal(t, money{amount: 5, currency: "EUR"}, paid)
+	})
+	t.Run("should fail the step if the transform fails or its result does not fit the parameter", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterParameterType("money", `\d+ (?:EUR|USD)`, func(string) (any, error) {
+			return nil, errors.New("unknown currency")
+		}))
+		require.Nil(t, executor.RegisterParameterType("account", `\w+`, func(text string) (any, error) {
+			return text, nil
+		}))
+		require.Nil(t, executor.RegisterStep(`^I pay {money}$`, func(money) {}))
+		require.Nil(t, executor.RegisterStep(`^I log in as {account}$`, func(int) {}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Shop
+
+  Scenario: Pay
+    Given I pay 5 EUR
+
+  Scenario: Log in
+    Given I log in as admin
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, "could not convert argument 1 of step ^I pay {money}$, error=unknown currency",
+			results[0].Steps[0].Error)
+		require.Equal(t, `could not convert argument 1 of step ^I log in as {account}$, error=parameter type `+
+			`transformed "admin" to string, which can not be passed as int`, results[1].Steps[0].Error)
+	})
+	t.Run("should reject invalid parameter types", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterParameterType("money", `\d+`, parseMoney))
+
+		require.EqualError(t, executor.RegisterParameterType("money", `\d+`, parseMoney),
+			"parameter type {money} is registered more than once")
+		require.EqualError(t, executor.RegisterParameterType("int", `\d+`, parseMoney),
+			"parameter type {int} is built in")
+		require.EqualError(t, executor.RegisterParameterType("sku", `([0-9a-f-]+)`, parseMoney),
+			"regular expression of parameter type {sku} can not have capture groups, use (?:...)")
+		require.EqualError(t, executor.RegisterParameterType("sku", `[0-9a-f-]+`, nil),
+			"transform function of parameter type {sku} can not be nil")
+	})
+	t.Run("should reject steps using parameter types which are not registered", func(t *testing.T) {
+		executor := NewStepExecutor()
+
+		require.EqualError(t, executor.RegisterStep(`^I pay {money}$`, func(string) {}),
+			"step ^I pay {money}$ uses parameter type {money}, which is not registered")
+	})
+}
@@ -0,0 +1,141 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+type (
+	// instances are the values created by the providers for a scenario. Each value is created once, when the
+	// first step of the scenario needs it.
+	instances struct {
+		providers map[reflect.Type]reflect.Value
+		values    map[reflect.Type]reflect.Value
+	}
+
+	instancesKey struct{}
+)
+
+// Provide registers a function creating the value of its result type for step functions declaring a parameter
+// of that type in addition to the parameters of the captured arguments, such as func() *http.Client. The function
+// may take the context of the scenario and may return an error as its second result. It is called once per
+// scenario, so the steps of a scenario share the value.
+func (c *StepExecutor) Provide(provider any) error {
+	function := reflect.ValueOf(provider)
+	if err := validateProvider(function); err != nil {
+		return err
+	}
+	provided := function.Type().Out(0)
+	if _, ok := c.providers[provided]; ok {
+		return fmt.Errorf("type %s is provided more than once", provided)
+	}
+	c.providers[provided] = function
+
+	return nil
+}
+
+// ValidateProviders returns an error for every provider of the config which can not be registered
+func ValidateProviders(config *models.Config) []error {
+	problems := make([]error, 0)
+	if config == nil {
+		return problems
+	}
+	provided := make(map[reflect.Type]bool)
+	for _, provider := range config.Providers {
+		function := reflect.ValueOf(provider)
+		if err := validateProvider(function); err != nil {
+			problems = append(problems, err)
+		} else if provided[function.Type().Out(0)] {
+			problems = append(problems, fmt.Errorf("type %s is provided more than once", function.Type().Out(0)))
+		} else {
+			provided[function.Type().Out(0)] = true
+		}
+	}
+
+	return problems
+}
+
+func validateProvider(function reflect.Value) error {
+	if function.Kind() != reflect.Func {
+		return fmt.Errorf("provider must be a function, got %s", function.Kind())
+	}
+	functionType := function.Type()
+	if functionType.NumIn() > 1 || (functionType.NumIn() == 1 && functionType.In(0) != contextType) {
+		return fmt.Errorf("provider %s can only take a context.Context", functionType)
+	}
+	if functionType.NumOut() == 0 || functionType.NumOut() > 2 ||
+		(functionType.NumOut() == 2 && functionType.Out(1) != errorType) {
+		return fmt.Errorf("provider %s must return a value and optionally an error", functionType)
+	}
+	if provided := functionType.Out(0); isArgumentType(provided) || isTableType(provided) ||
+		provided == contextType || provided == errorType {
+		return fmt.Errorf("provider %s can not provide %s, step arguments are passed as it", functionType, provided)
+	}
+
+	return nil
+}
+
+func contextWithInstances(ctx context.Context, providers map[reflect.Type]reflect.Value) context.Context {
+	return context.WithValue(ctx, instancesKey{}, &instances{
+		providers: providers,
+		values:    make(map[reflect.Type]reflect.Value),
+	})
+}
+
+func instancesFrom(ctx context.Context) *instances {
+	if instances, ok := ctx.Value(instancesKey{}).(*instances); ok {
+		return instances
+	}
+
+	return &instances{}
+}
+
+func (i *instances) provides(target reflect.Type) bool {
+	_, ok := i.providers[target]
+
+	return ok
+}
+
+// resolve returns the value of the type created by its provider for the scenario
+func (i *instances) resolve(ctx context.Context, target reflect.Type) (reflect.Value, error) {
+	if value, ok := i.values[target]; ok {
+		return value, nil
+	}
+
+	provider := i.providers[target]
+	in := make([]reflect.Value, 0, 1)
+	if provider.Type().NumIn() == 1 {
+		in = append(in, reflect.ValueOf(ctx))
+	}
+	out := provider.Call(in)
+	if len(out) == 2 && !out[1].IsNil() {
+		return reflect.Value{}, out[1].Interface().(error)
+	}
+	i.values[target] = out[0]
+
+	return out[0], nil
+}
+
+// isArgumentType reports whether captured arguments can be converted to the type
+func isArgumentType(target reflect.Type) bool {
+	if target == timeType || target == durationType || target == uuidType || target == ipType || target == versionType {
+		return true
+	}
+	switch target.Kind() {
+	case reflect.Slice:
+		return isArgumentType(target.Elem())
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+
+	return false
+}
+
+func isTableType(target reflect.Type) bool {
+	return target == tableType || target == rowMapType || target == rowMapsType
+}
@@ -0,0 +1,123 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	basket struct {
+		apples int
+	}
+
+	client struct {
+		name string
+	}
+)
+
+func TestStepExecutor_Provide(t *testing.T) {
+	t.Run("should pass provided values shared by the steps of a scenario", func(t *testing.T) {
+		created := 0
+		executor := NewStepExecutor()
+		require.Nil(t, executor.Provide(func() *basket {
+			created++
+			return &basket{}
+		}))
+		executor.SetConfig(&models.Config{Providers: []any{func(ctx context.Context) (*client, error) {
+			return &client{name: "shop"}, nil
+		}}})
+		require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(b *basket, count int, c *client) {
+			b.apples = count
+			require.Equal(t, "shop", c.name)
+		}))
+		apples := 0
+		require.Nil(t, executor.RegisterStep(`^I eat {int} apple$`, func(ctx context.Context, count int, b *basket) {
+			b.apples -= count
+			apples = b.apples
+		}))
+
+		results, err := executor.Execute(parseDocument(t, appleFeature))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status)
+		require.Equal(t, 2, apples)
+		require.Equal(t, 1, created)
+	})
+	t.Run("should fail the step if the provider fails", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.Provide(func() (*basket, error) {
+			return nil, errors.New("no basket")
+		}))
+		require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(b *basket, count int) {}))
+
+		results, err := executor.Execute(parseDocument(t, appleFeature))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusFailed, results[0].Status)
+		require.Equal(t, "could not provide *executor.basket to step ^I have {int} apples$, error=no basket",
+			results[0].Steps[0].Error)
+	})
+	t.Run("should reject providers which can not be registered", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.Provide(func() *basket { return nil }))
+
+		require.EqualError(t, executor.Provide(func() *basket { return nil }),
+			"type *executor.basket is provided more than once")
+		require.EqualError(t, executor.Provide(func() string { return "" }),
+			"provider func() string can not provide string, step arguments are passed as it")
+		require.EqualError(t, executor.Provide(func(int) *client { return nil }),
+			"provider func(int) *executor.client can only take a context.Context")
+		require.EqualError(t, executor.Provide(&client{}), "provider must be a function, got ptr")
+	})
+	t.Run("should return error for providers of the config whose type is already provided", func(t *testing.T) {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.Provide(func() *basket { return nil }))
+
+		executor.SetConfig(&models.Config{Providers: []any{
+			func() *basket { return nil },
+			func() *client { return nil },
+			func() *client { return nil },
+			func() {},
+		}})
+
+		require.EqualError(t, executor.ConfigErrors(), "type *executor.basket is provided more than once")
+	})
+	t.Run("should replace the providers of the config set before", func(t *testing.T) {
+		executor := NewStepExecutor()
+		executor.SetConfig(&models.Config{Providers: []any{func() *client { return &client{name: "old"} }}})
+		executor.SetConfig(&models.Config{Providers: []any{func() *client { return &client{name: "new"} }}})
+		require.Nil(t, executor.ConfigErrors())
+		name := ""
+		require.Nil(t, executor.RegisterStep(`^I shop$`, func(c *client) {
+			name = c.name
+		}))
+
+		results, err := executor.Execute(parseDocument(t, `Feature: Shop
+
+  Scenario: Shop
+    Given I shop
+`))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, results[0].Status, results[0].Steps)
+		require.Equal(t, "new", name)
+	})
+}
+
+func TestValidateProviders(t *testing.T) {
+	t.Run("should return the problems of the providers of the config", func(t *testing.T) {
+		problems := ValidateProviders(&models.Config{Providers: []any{
+			func() *basket { return nil },
+			func() *basket { return nil },
+			func() {},
+		}})
+
+		require.Len(t, problems, 2)
+		require.EqualError(t, problems[0], "type *executor.basket is provided more than once")
+		require.EqualError(t, problems[1], "provider func() must return a value and optionally an error")
+	})
+}
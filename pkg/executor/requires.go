@@ -0,0 +1,71 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+var (
+	// Requirements check the preconditions of @requires(kind:value) tags by their kind, such as
+	// @requires(env:STAGING_URL) or @requires(cmd:docker). A check returns the reason the precondition is not
+	// met, or an empty string if it is met. Other kinds can be added before the run.
+	Requirements = map[string]func(value string) string{
+		"env": func(name string) string {
+			if len(os.Getenv(name)) == 0 {
+				return fmt.Sprintf("environment variable %s is not set", name)
+			}
+
+			return ""
+		},
+		"cmd": func(name string) string {
+			if _, err := exec.LookPath(name); err != nil {
+				return fmt.Sprintf("command %s is not found", name)
+			}
+
+			return ""
+		},
+	}
+
+	requiresTag = regexp.MustCompile(`^@requires\((\w+):(.+)\)$`)
+)
+
+// checkRequirements checks the preconditions of the @requires tags in order. It returns a skipped hook result
+// with the reason of the first precondition which is not met, a failed hook result for a tag with an unknown kind
+// or nil if every precondition is met.
+func checkRequirements(tags []string) *models.HookResult {
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, "@requires(") {
+			continue
+		}
+		match := requiresTag.FindStringSubmatch(tag)
+		if match == nil {
+			return &models.HookResult{
+				Name:   tag,
+				Status: models.StatusFailed,
+				Error:  fmt.Sprintf("tag %s must be written as @requires(kind:value)", tag),
+			}
+		}
+		check, ok := Requirements[match[1]]
+		if !ok {
+			return &models.HookResult{
+				Name:   tag,
+				Status: models.StatusFailed,
+				Error:  fmt.Sprintf("precondition kind %s of tag %s is unknown", match[1], tag),
+			}
+		}
+		if reason := check(match[2]); len(reason) > 0 {
+			return &models.HookResult{
+				Name:   tag,
+				Status: models.StatusSkipped,
+				Reason: reason,
+			}
+		}
+	}
+
+	return nil
+}
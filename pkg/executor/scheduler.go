@@ -0,0 +1,132 @@
+package executor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+type (
+	// scheduler runs jobs with at most concurrency of them at the same time. A job with the tag of a resource
+	// class runs only while fewer jobs of the class than its limit are running.
+	scheduler struct {
+		concurrency int
+		limits      map[string]int
+	}
+)
+
+func newScheduler(config *models.Config) *scheduler {
+	s := &scheduler{
+		concurrency: 1,
+		limits:      make(map[string]int),
+	}
+	if config == nil {
+		return s
+	}
+	if config.Concurrency > 1 {
+		s.concurrency = config.Concurrency
+	}
+	for tag, limit := range config.ResourceLimits {
+		if limit > 0 {
+			s.limits[resourceTag(tag)] = limit
+		}
+	}
+
+	return s
+}
+
+// ValidateResourceLimits returns an error for the concurrency and every resource limit of the config which is
+// not positive
+func ValidateResourceLimits(config *models.Config) []error {
+	problems := make([]error, 0)
+	if config == nil {
+		return problems
+	}
+	if config.Concurrency < 0 {
+		problems = append(problems, fmt.Errorf("concurrency %d can not be negative", config.Concurrency))
+	}
+	tags := make([]string, 0, len(config.ResourceLimits))
+	for tag := range config.ResourceLimits {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		if limit := config.ResourceLimits[tag]; limit < 1 {
+			problems = append(problems, fmt.Errorf("limit %d of resource class %s must be at least 1", limit,
+				resourceTag(tag)))
+		}
+	}
+
+	return problems
+}
+
+// run calls job with the index of every job and the worker running it, and waits for them to finish. Workers are
+// numbered from 0 to the concurrency, and a worker runs one job at a time. tags returns the tags of the job with
+// the index.
+func (s *scheduler) run(count int, tags func(int) []string, job func(i, worker int)) {
+	if s.concurrency <= 1 {
+		for i := 0; i < count; i++ {
+			job(i, 0)
+		}
+
+		return
+	}
+
+	workers := make(chan int, s.concurrency)
+	for worker := 0; worker < s.concurrency; worker++ {
+		workers <- worker
+	}
+	classes := make(map[string]chan struct{}, len(s.limits))
+	for tag, limit := range s.limits {
+		classes[tag] = make(chan struct{}, limit)
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// the slots of the resource classes are taken in the order of their tags before a worker of the run,
+			// so a job waiting for its class does not keep other jobs from running
+			acquired := make([]chan struct{}, 0)
+			for _, tag := range s.classesOf(tags(i)) {
+				classes[tag] <- struct{}{}
+				acquired = append(acquired, classes[tag])
+			}
+			worker := <-workers
+			defer func() {
+				workers <- worker
+				for _, class := range acquired {
+					<-class
+				}
+			}()
+
+			job(i, worker)
+		}()
+	}
+	wg.Wait()
+}
+
+// classesOf returns the sorted resource classes of the tags without duplicates
+func (s *scheduler) classesOf(tags []string) []string {
+	classes := make([]string, 0)
+	added := make(map[string]bool)
+	for _, tag := range tags {
+		// a tag of both the feature and the scenario takes one slot of its class
+		if _, ok := s.limits[tag]; ok && !added[tag] {
+			classes = append(classes, tag)
+			added[tag] = true
+		}
+	}
+	sort.Strings(classes)
+
+	return classes
+}
+
+// resourceTag returns the tag of the resource class, which can be configured with or without @
+func resourceTag(tag string) string {
+	return "@" + strings.TrimPrefix(tag, "@")
+}
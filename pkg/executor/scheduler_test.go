@@ -0,0 +1,120 @@
+package executor
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+// peak records the highest number of jobs running at the same time
+type peak struct {
+	mutex   sync.Mutex
+	running int
+	highest int
+}
+
+// track runs a job counted by the peaks
+func track(peaks ...*peak) {
+	for _, p := range peaks {
+		p.mutex.Lock()
+		p.running++
+		p.highest = max(p.highest, p.running)
+		p.mutex.Unlock()
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	for _, p := range peaks {
+		p.mutex.Lock()
+		p.running--
+		p.mutex.Unlock()
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	t.Run("should run jobs up to the concurrency at the same time", func(t *testing.T) {
+		all := &peak{}
+
+		newScheduler(&models.Config{Concurrency: 3}).run(10, func(int) []string {
+			return nil
+		}, func(int, int) {
+			track(all)
+		})
+
+		require.Equal(t, 3, all.highest)
+	})
+
+	t.Run("should run jobs of a resource class up to its limit at the same time", func(t *testing.T) {
+		all, heavy := &peak{}, &peak{}
+		tags := func(i int) []string {
+			if i%2 == 0 {
+				return []string{"@heavy", "@heavy"}
+			}
+
+			return []string{"@light"}
+		}
+
+		newScheduler(&models.Config{Concurrency: 4, ResourceLimits: map[string]int{"heavy": 1}}).run(12, tags,
+			func(i, _ int) {
+				if i%2 == 0 {
+					track(all, heavy)
+				} else {
+					track(all)
+				}
+			})
+
+		require.Equal(t, 1, heavy.highest)
+		require.LessOrEqual(t, all.highest, 4)
+	})
+
+	t.Run("should run a job at a time on every worker", func(t *testing.T) {
+		mutex := sync.Mutex{}
+		running := make(map[int]bool)
+		used := make(map[int]bool)
+
+		newScheduler(&models.Config{Concurrency: 3}).run(12, func(int) []string {
+			return nil
+		}, func(_, worker int) {
+			mutex.Lock()
+			require.False(t, running[worker])
+			running[worker], used[worker] = true, true
+			mutex.Unlock()
+			time.Sleep(time.Millisecond)
+			mutex.Lock()
+			running[worker] = false
+			mutex.Unlock()
+		})
+
+		for worker := range used {
+			require.Contains(t, []int{0, 1, 2}, worker)
+		}
+	})
+
+	t.Run("should run jobs in order without concurrency", func(t *testing.T) {
+		order := make([]int, 0)
+
+		newScheduler(nil).run(3, func(int) []string {
+			return nil
+		}, func(i, _ int) {
+			order = append(order, i)
+		})
+
+		require.Equal(t, []int{0, 1, 2}, order)
+	})
+}
+
+func TestValidateResourceLimits(t *testing.T) {
+	t.Run("should return error for limits which are not positive", func(t *testing.T) {
+		problems := ValidateResourceLimits(&models.Config{
+			Concurrency:    -1,
+			ResourceLimits: map[string]int{"@gpu": 0, "heavy": 2},
+		})
+
+		require.Len(t, problems, 2)
+		require.EqualError(t, problems[0], "concurrency -1 can not be negative")
+		require.EqualError(t, problems[1], "limit 0 of resource class @gpu must be at least 1")
+	})
+}
@@ -0,0 +1,142 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	messages "github.com/cucumber/messages/go/v21"
+	"github.com/denizgursoy/cacik/pkg/converter"
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+var (
+	gherkinKeywords = []string{"Given ", "When ", "Then ", "And ", "But ", "* "}
+)
+
+type (
+	// Session runs steps programmatically, such as in an exploratory script, and records them so that the
+	// session can be saved as a draft feature file
+	Session struct {
+		executor    *StepExecutor
+		ctx         context.Context
+		logger      *models.Logger
+		attachments *models.Attachments
+		scenario    *models.ScenarioResult
+	}
+)
+
+// NewSession starts a session with its own scenario data, logger, attachments and world like a scenario
+func (c *StepExecutor) NewSession(ctx context.Context, name string) *Session {
+	logger := models.NewLogger()
+	attachments := models.NewAttachments()
+	ctx = models.ContextWithData(ctx, models.NewData())
+	ctx = models.ContextWithLogger(ctx, logger)
+	ctx = models.ContextWithAttachments(ctx, attachments)
+	ctx = contextWithInstances(ctx, c.providers)
+	ctx = c.contextWithWorld(ctx)
+	ctx = converter.ContextWithSettings(ctx, c.settings)
+
+	return &Session{
+		executor:    c,
+		ctx:         ctx,
+		logger:      logger,
+		attachments: attachments,
+		scenario: &models.ScenarioResult{
+			Name:   name,
+			Status: models.StatusPassed,
+			Steps:  make([]*models.StepResult, 0),
+		},
+	}
+}
+
+// RunStep runs the step definition matching the text. The text can start with a Gherkin keyword, such as
+// "Given I have 3 apples", which is kept in the recorded scenario. The attachments and logs of the step are in its
+// result, the last step of Scenario.
+func (s *Session) RunStep(text string) error {
+	return s.run(text, nil)
+}
+
+// RunStepWithTable runs the step with the rows as its data table
+func (s *Session) RunStepWithTable(text string, rows [][]string) error {
+	table := &messages.PickleTable{Rows: make([]*messages.PickleTableRow, 0, len(rows))}
+	for _, row := range rows {
+		cells := make([]*messages.PickleTableCell, 0, len(row))
+		for _, cell := range row {
+			cells = append(cells, &messages.PickleTableCell{Value: cell})
+		}
+		table.Rows = append(table.Rows, &messages.PickleTableRow{Cells: cells})
+	}
+
+	return s.run(text, &messages.PickleStepArgument{DataTable: table})
+}
+
+// RunStepWithDocString runs the step with the content as its doc string
+func (s *Session) RunStepWithDocString(text, content string) error {
+	return s.run(text, &messages.PickleStepArgument{DocString: &messages.PickleDocString{Content: content}})
+}
+
+func (s *Session) run(text string, argument *messages.PickleStepArgument) error {
+	keyword, text := SplitKeyword(text)
+	step := &messages.PickleStep{Text: text, Argument: argument}
+
+	logged := len(s.logger.Entries())
+	result, ctx := s.executor.executeStep(s.ctx, step, nil, s.scenario)
+	s.ctx = ctx
+	// the attachments and logs of the step are recorded like the ones of the steps of scenarios
+	result.Attachments = s.attachments.Take()
+	if entries := s.logger.Entries(); len(entries) > logged {
+		result.Logs = slices.Clone(entries[logged:])
+	}
+	s.scenario.Logs = s.logger.Entries()
+	result.Keyword = keyword
+	describeStep(result, step, nil)
+	s.scenario.Steps = append(s.scenario.Steps, result)
+	if result.Status != models.StatusPassed {
+		s.scenario.Status = result.Status
+
+		return fmt.Errorf("step %q %s: %s", text, result.Status, result.Error)
+	}
+
+	return nil
+}
+
+// Context returns the context the next step of the session is run with, carrying the data store of the session
+func (s *Session) Context() context.Context {
+	return s.ctx
+}
+
+// Scenario returns the result of the steps run so far
+func (s *Session) Scenario() *models.ScenarioResult {
+	return s.scenario
+}
+
+// Feature returns the recorded steps as a draft feature file with the feature name
+func (s *Session) Feature(name string) string {
+	return fmt.Sprintf("Feature: %s\n\n  %s", name, strings.ReplaceAll(strings.TrimSuffix(s.scenario.Gherkin(),
+		"\n"), "\n", "\n  ")+"\n")
+}
+
+// WriteFeature writes the draft feature file to the path
+func (s *Session) WriteFeature(path, name string) error {
+	if err := os.WriteFile(path, []byte(s.Feature(name)), 0o644); err != nil {
+		return fmt.Errorf("could not write feature file %s, error=%w", path, err)
+	}
+
+	return nil
+}
+
+// SplitKeyword splits the Gherkin keyword, such as "Given ", from the start of the text, using "* " if the text has
+// none
+func SplitKeyword(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	for _, keyword := range gherkinKeywords {
+		if strings.HasPrefix(text, keyword) {
+			return keyword, strings.TrimSpace(text[len(keyword):])
+		}
+	}
+
+	return "* ", text
+}
@@ -0,0 +1,73 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession(t *testing.T) {
+	newExecutor := func(t *testing.T) *StepExecutor {
+		executor := NewStepExecutor()
+		require.Nil(t, executor.RegisterStep(`^I have {int} apples$`, func(ctx context.Context, count int) {
+			models.DataFrom(ctx).Set("apples", count)
+		}))
+		require.Nil(t, executor.RegisterStep(`^I sell them to:$`, func(ctx context.Context, table models.Table) {}))
+
+		return executor
+	}
+
+	t.Run("should run steps and record them as a feature", func(t *testing.T) {
+		session := newExecutor(t).NewSession(context.Background(), "Sell apples")
+
+		require.Nil(t, session.RunStep("Given I have 3 apples"))
+		require.Nil(t, session.RunStepWithTable("When I sell them to:", [][]string{{"name"}, {"Ada"}}))
+
+		require.Equal(t, `Feature: Apples
+
+  Scenario: Sell apples
+    Given I have 3 apples
+    When I sell them to:
+      | name |
+      | Ada  |
+`, session.Feature("Apples"))
+		path := filepath.Join(t.TempDir(), "apples.feature")
+		require.Nil(t, session.WriteFeature(path, "Apples"))
+		content, err := os.ReadFile(path)
+		require.Nil(t, err)
+		require.Equal(t, session.Feature("Apples"), string(content))
+	})
+	t.Run("should return error for undefined steps", func(t *testing.T) {
+		session := newExecutor(t).NewSession(context.Background(), "Eat apples")
+
+		err := session.RunStep("I eat 3 apples")
+
+		require.EqualError(t, err, `step "I eat 3 apples" undefined: step "I eat 3 apples" is not defined, `+
+			`did you mean ^I have {int} apples$?`)
+		require.Equal(t, models.StatusUndefined, session.Scenario().Status)
+		require.Equal(t, "* ", session.Scenario().Steps[0].Keyword)
+	})
+	t.Run("should record the logs and attachments of the steps", func(t *testing.T) {
+		executor := newExecutor(t)
+		require.Nil(t, executor.RegisterStep(`^I take a photo$`, func(ctx context.Context) {
+			models.LoggerFrom(ctx).Log("smile")
+			models.Attach(ctx, "photo", "image/png", []byte("png"))
+		}))
+		session := executor.NewSession(context.Background(), "Take photos")
+
+		require.Nil(t, session.RunStep("Given I have 3 apples"))
+		require.Nil(t, session.RunStep("When I take a photo"))
+
+		steps := session.Scenario().Steps
+		require.Empty(t, steps[0].Logs)
+		require.Equal(t, []string{"smile"}, steps[1].Logs)
+		require.Len(t, steps[1].Attachments, 1)
+		require.Equal(t, "photo", steps[1].Attachments[0].Name)
+		require.Equal(t, []byte("png"), steps[1].Attachments[0].Data)
+		require.Equal(t, []string{"smile"}, session.Scenario().Logs)
+	})
+}
@@ -0,0 +1,102 @@
+package executor
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	stringCapture = `"([^"]*)"`
+	intCapture    = `(-?\d+)`
+	floatCapture  = `(-?\d+\.\d+)`
+)
+
+var (
+	snippetArgumentPattern = regexp.MustCompile(`"[^"]*"|-?\b\d+\.\d+\b|-?\b\d+\b`)
+)
+
+// GenerateSnippet creates a step function skeleton with a @cacik comment matching the step text.
+// Quoted strings, integers and decimals in the text are replaced with capture groups and typed parameters. The
+// definition is written between backticks, or as a double-quoted string if the text has a backtick.
+func GenerateSnippet(text string) string {
+	pattern := &strings.Builder{}
+	parameters := make([]string, 0)
+	nameParts := make([]string, 0)
+
+	last := 0
+	for _, location := range snippetArgumentPattern.FindAllStringIndex(text, -1) {
+		literal := text[last:location[0]]
+		pattern.WriteString(regexp.QuoteMeta(literal))
+		nameParts = append(nameParts, literal)
+
+		argument := text[location[0]:location[1]]
+		parameterName := fmt.Sprintf("arg%d", len(parameters)+1)
+		switch {
+		case strings.HasPrefix(argument, `"`):
+			pattern.WriteString(stringCapture)
+			parameters = append(parameters, parameterName+" string")
+		case strings.Contains(argument, "."):
+			pattern.WriteString(floatCapture)
+			parameters = append(parameters, parameterName+" float64")
+		default:
+			pattern.WriteString(intCapture)
+			parameters = append(parameters, parameterName+" int")
+		}
+		last = location[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(text[last:]))
+	nameParts = append(nameParts, text[last:])
+
+	functionName := snippetFunctionName(strings.Join(nameParts, " "))
+	parameters = append([]string{"ctx context.Context"}, parameters...)
+
+	snippet := &strings.Builder{}
+	snippet.WriteString(fmt.Sprintf("// %s\n", functionName))
+	definition := "`^" + pattern.String() + "$`"
+	// a definition with a backtick can not be written between backticks, so it is written as a Go string
+	if strings.Contains(pattern.String(), "`") {
+		definition = strconv.Quote("^" + pattern.String() + "$")
+	}
+	snippet.WriteString(fmt.Sprintf("// @cacik %s\n", definition))
+	snippet.WriteString(fmt.Sprintf("func %s(%s) (context.Context, error) {\n", functionName, strings.Join(parameters, ", ")))
+	snippet.WriteString("\treturn ctx, nil\n")
+	snippet.WriteString("}\n")
+
+	return snippet.String()
+}
+
+// GenerateSnippets creates one snippet for each distinct step text
+func GenerateSnippets(texts []string) []string {
+	snippets := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		snippet := GenerateSnippet(text)
+		if !seen[snippet] {
+			seen[snippet] = true
+			snippets = append(snippets, snippet)
+		}
+	}
+
+	return snippets
+}
+
+func snippetFunctionName(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	name := &strings.Builder{}
+	for _, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		name.WriteString(string(runes))
+	}
+	if name.Len() == 0 || !unicode.IsLetter([]rune(name.String())[0]) {
+		return "Step" + name.String()
+	}
+
+	return name.String()
+}
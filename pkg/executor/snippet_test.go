@@ -0,0 +1,38 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSnippet(t *testing.T) {
+	t.Run("should create step function with typed parameters", func(t *testing.T) {
+		expected := "// IHaveApplesIn\n" +
+			"// @cacik `^I have (-?\\d+) apples in \"([^\"]*)\"$`\n" +
+			"func IHaveApplesIn(ctx context.Context, arg1 int, arg2 string) (context.Context, error) {\n" +
+			"\treturn ctx, nil\n" +
+			"}\n"
+
+		require.Equal(t, expected, GenerateSnippet(`I have 3 apples in "basket"`))
+	})
+
+	t.Run("should escape regular expression characters and detect decimals", func(t *testing.T) {
+		snippet := GenerateSnippet("the price is 2.5 (USD)")
+
+		require.Contains(t, snippet, "// @cacik `^the price is (-?\\d+\\.\\d+) \\(USD\\)$`")
+		require.Contains(t, snippet, "func ThePriceIsUSD(ctx context.Context, arg1 float64)")
+	})
+
+	t.Run("should write a definition with a backtick as a double-quoted string", func(t *testing.T) {
+		snippet := GenerateSnippet("I run `go test` with 2 workers")
+
+		require.Contains(t, snippet, `// @cacik "^I run `+"`go test`"+` with (-?\\d+) workers$"`)
+	})
+
+	t.Run("should create one snippet for steps differing only in arguments", func(t *testing.T) {
+		snippets := GenerateSnippets([]string{"I have 3 apples", "I have 5 apples"})
+
+		require.Len(t, snippets, 1)
+	})
+}
@@ -0,0 +1,651 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"regexp"
+	"strconv"
+	"time"
+
+	messages "github.com/cucumber/messages/go/v21"
+	"github.com/denizgursoy/cacik/pkg/converter"
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/denizgursoy/cacik/pkg/pattern"
+	"github.com/google/uuid"
+)
+
+var (
+	anyType      = reflect.TypeOf((*any)(nil)).Elem()
+	argsType     = reflect.TypeOf(models.Args{})
+	contextType  = reflect.TypeOf((*context.Context)(nil)).Elem()
+	durationType = reflect.TypeOf(time.Duration(0))
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+	ipType       = reflect.TypeOf(net.IP{})
+	rowMapType   = reflect.TypeOf(map[string]string{})
+	rowMapsType  = reflect.TypeOf([]map[string]string{})
+	tableType    = reflect.TypeOf(models.Table{})
+	timeType     = reflect.TypeOf(time.Time{})
+	uuidType     = reflect.TypeOf(uuid.UUID{})
+	versionType  = reflect.TypeOf(converter.Version{})
+)
+
+type (
+	StepDefinition struct {
+		Definition string
+		Function   any
+		// Type is the type of the steps the definition is registered for, such as messages.PickleStepType_CONTEXT
+		// for RegisterGiven, or messages.PickleStepType_UNKNOWN if it is registered for steps of every type
+		Type messages.PickleStepType
+		// Location is where the step is defined, the step function unless it is set with SetStepLocation
+		Location *models.SourceLocation
+		pattern  *regexp.Regexp
+		// captures are the capture groups of the definition and transformers the transformers of the custom
+		// parameter types by the index of their capture group
+		captures     []pattern.Capture
+		transformers []func(string) (any, error)
+		signature    *signature
+	}
+
+	// signature is the reflection metadata of a step function, computed once when the step is defined instead of
+	// every time the step is called
+	signature struct {
+		function reflect.Value
+		// in is the number of parameters of the function
+		in           int
+		takesContext bool
+		// parameters are the parameters of the function following its optional context parameter
+		parameters []parameter
+		results    []reflect.Type
+	}
+
+	parameter struct {
+		// index is the position of the parameter in the parameters of the function
+		index  int
+		target reflect.Type
+		// argument is whether the parameter is of a type captured arguments are converted to and table whether
+		// it is of a type data tables are converted to. Neither can be provided.
+		argument bool
+		table    bool
+		// list is whether the captured argument is split into the items of the slice type
+		list bool
+	}
+)
+
+// NewStepDefinition creates the step definition for the regular expression. The {type} parameters of the
+// definition, such as {int}, are replaced with the regular expressions of their types.
+func NewStepDefinition(definition string, function any) (*StepDefinition, error) {
+	return newStepDefinition(definition, function, nil)
+}
+
+// newStepDefinition creates the step definition whose parameters may also be of the custom parameter types
+func newStepDefinition(definition string, function any, parameterTypes map[string]*parameterType) (*StepDefinition, error) {
+	if reflect.ValueOf(function).Kind() != reflect.Func {
+		return nil, fmt.Errorf("step %s must be a function, got %T", definition, function)
+	}
+	signature, err := newSignature(definition, reflect.ValueOf(function))
+	if err != nil {
+		return nil, err
+	}
+	types := make(pattern.Types, len(parameterTypes))
+	for name, parameterType := range parameterTypes {
+		types[name] = parameterType.regex
+	}
+	for _, name := range pattern.CustomTypeNames(definition) {
+		if _, ok := types[name]; !ok {
+			return nil, fmt.Errorf("step %s uses parameter type {%s}, which is not registered", definition, name)
+		}
+	}
+	compiled, err := regexp.Compile(pattern.TransformWithTypes(definition, types))
+	if err != nil {
+		return nil, fmt.Errorf("step %s is not a valid regular expression, error=%w", definition, err)
+	}
+
+	captures, err := pattern.CapturesWithTypes(definition, types)
+	if err != nil {
+		return nil, fmt.Errorf("step %s is not a valid regular expression, error=%w", definition, err)
+	}
+	transformers := make([]func(string) (any, error), len(captures))
+	for i, capture := range captures {
+		if parameterType, ok := parameterTypes[capture.Type]; ok && !capture.Regexp {
+			transformers[i] = parameterType.transform
+		}
+	}
+
+	return &StepDefinition{
+		Definition:   definition,
+		Function:     function,
+		Location:     functionLocation(function),
+		pattern:      compiled,
+		captures:     captures,
+		transformers: transformers,
+		signature:    signature,
+	}, nil
+}
+
+// newSignature inspects the parameters and the results of the step function
+func newSignature(definition string, function reflect.Value) (*signature, error) {
+	functionType := function.Type()
+	if functionType.IsVariadic() {
+		return nil, fmt.Errorf("step %s can not be variadic", definition)
+	}
+	s := &signature{
+		function:   function,
+		in:         functionType.NumIn(),
+		parameters: make([]parameter, 0, functionType.NumIn()),
+		results:    make([]reflect.Type, 0, functionType.NumOut()),
+	}
+	for i := 0; i < functionType.NumOut(); i++ {
+		out := functionType.Out(i)
+		if out != contextType && out != errorType {
+			return nil, fmt.Errorf("step %s can only return context.Context and error, got %s", definition, out)
+		}
+		s.results = append(s.results, out)
+	}
+	for i := 0; i < functionType.NumIn(); i++ {
+		target := functionType.In(i)
+		if target == contextType {
+			if i > 0 {
+				return nil, fmt.Errorf("step %s can only take context.Context as its first parameter", definition)
+			}
+			s.takesContext = true
+
+			continue
+		}
+		s.parameters = append(s.parameters, parameter{
+			index:    i,
+			target:   target,
+			argument: isArgumentType(target),
+			table:    isTableType(target),
+			list:     target.Kind() == reflect.Slice && target != ipType && isArgumentType(target.Elem()),
+		})
+	}
+
+	return s, nil
+}
+
+// validateArguments checks that the parameters of the step function which can not be provided are passed the
+// captured arguments and an optional data table or doc string, as they are when the step is called
+func (s *StepDefinition) validateArguments() error {
+	parameters := s.signature.parameters
+	if len(parameters) > 0 && parameters[0].target == argsType {
+		// the arguments are passed by their names and converted when they are read
+		return nil
+	}
+	for i, capture := range s.captures {
+		if (i < len(s.transformers) && s.transformers[i] != nil) || (capture.Type == "json" && !capture.Regexp) {
+			// the parameters passed the values of custom parameter types and JSON can not be told apart from
+			// provided parameters
+			return nil
+		}
+	}
+
+	captured, expected := s.pattern.NumSubexp(), 0
+	for _, parameter := range parameters {
+		if parameter.argument || parameter.table {
+			expected++
+		}
+	}
+	if expected == captured+1 {
+		// the last parameter is passed the data table or the doc string
+		expected--
+	}
+	if expected != captured {
+		return fmt.Errorf("step %s captures %d arguments but the function has %d parameters for them",
+			s.Definition, captured, expected)
+	}
+
+	return nil
+}
+
+// Match returns the captured groups of the text if the step definition matches it
+func (s *StepDefinition) Match(text string) ([]string, bool) {
+	submatch := s.pattern.FindStringSubmatch(text)
+	if submatch == nil {
+		return nil, false
+	}
+
+	return submatch[1:], true
+}
+
+// Arguments returns the groups of the text captured by the step definition with their offsets in the text, or nil
+// if the step definition does not match it. Optional groups which did not capture are left out.
+func (s *StepDefinition) Arguments(text string) []*models.StepArgument {
+	locations := s.pattern.FindStringSubmatchIndex(text)
+	if locations == nil {
+		return nil
+	}
+
+	arguments := make([]*models.StepArgument, 0, len(locations)/2-1)
+	for i := 1; i < len(locations)/2; i++ {
+		start, end := locations[2*i], locations[2*i+1]
+		if start < 0 {
+			continue
+		}
+		argument := &models.StepArgument{Value: text[start:end], Start: start, End: end}
+		if i <= len(s.captures) {
+			capture := s.captures[i-1]
+			argument.Name = capture.Name
+			if !capture.Regexp {
+				argument.Type = capture.Type
+			}
+		}
+		arguments = append(arguments, argument)
+	}
+
+	return arguments
+}
+
+// Call invokes the step function with the context and the captured groups converted to the parameter types.
+// A models.Args parameter following the context takes every captured group by its name instead. If the step has
+// a data table, it is converted to the trailing parameter of the function. If it has a doc string,
+// it is passed as is to a trailing string parameter, or unmarshalled as JSON into the trailing parameter of other
+// types, such as a map[string]any or a struct. Parameters of types
+// registered with StepExecutor.Provide are passed the values of their providers for the scenario.
+// It returns the context returned by the step function, or ctx if the function does not return a context, so the
+// following steps and hooks of the scenario are passed the context of the step.
+func (s *StepDefinition) Call(ctx context.Context, arguments []string,
+	stepArgument *messages.PickleStepArgument) (context.Context, error) {
+	in := make([]reflect.Value, s.signature.in)
+	if s.signature.takesContext {
+		in[0] = reflect.ValueOf(ctx)
+	}
+
+	// parameters are the parameters passed the captured groups and the data table
+	parameters := make([]parameter, 0, len(s.signature.parameters))
+	provided := instancesFrom(ctx)
+	for _, parameter := range s.signature.parameters {
+		if parameter.argument || parameter.table || !provided.provides(parameter.target) {
+			parameters = append(parameters, parameter)
+
+			continue
+		}
+		value, err := provided.resolve(ctx, parameter.target)
+		if err != nil {
+			return ctx, fmt.Errorf("could not provide %s to step %s, error=%w", parameter.target, s.Definition, err)
+		}
+		in[parameter.index] = value
+	}
+
+	settings := converter.SettingsFrom(ctx)
+	if len(parameters) > 0 && parameters[0].target == argsType {
+		args, err := s.convertArgs(settings, arguments)
+		if err != nil {
+			return ctx, err
+		}
+		in[parameters[0].index] = reflect.ValueOf(args)
+		parameters, arguments = parameters[1:], nil
+	}
+
+	hasTable := stepArgument != nil && stepArgument.DataTable != nil
+	if hasTable && len(parameters) == len(arguments)+1 {
+		last := parameters[len(parameters)-1]
+		value, err := convertTable(stepArgument.DataTable, last.target)
+		if err != nil {
+			return ctx, fmt.Errorf("could not convert data table of step %s, error=%w", s.Definition, err)
+		}
+		in[last.index] = value
+		parameters = parameters[:len(parameters)-1]
+	}
+	hasDocString := stepArgument != nil && stepArgument.DocString != nil
+	if hasDocString && len(parameters) == len(arguments)+1 {
+		last := parameters[len(parameters)-1]
+		value, err := convertDocString(stepArgument.DocString.Content, last.target)
+		if err != nil {
+			return ctx, fmt.Errorf("could not convert doc string of step %s, error=%w", s.Definition, err)
+		}
+		in[last.index] = value
+		parameters = parameters[:len(parameters)-1]
+	}
+
+	if len(parameters) != len(arguments) {
+		return ctx, fmt.Errorf("step %s expects %d arguments but %d captured", s.Definition, len(parameters),
+			len(arguments))
+	}
+
+	trace := traceFrom(ctx)
+	for i, argument := range arguments {
+		span := trace.begin(TraceCategoryConversion, fmt.Sprintf("convert argument %d", i+1))
+		value, err := s.convertArgument(settings, i, argument, parameters[i])
+		span.end(map[string]string{"argument": argument, "type": parameters[i].target.String()})
+		if err != nil {
+			return ctx, fmt.Errorf("could not convert argument %d of step %s, error=%w", i+1, s.Definition, err)
+		}
+		in[parameters[i].index] = value
+	}
+
+	var err error
+	for i, out := range s.signature.function.Call(in) {
+		if out.IsNil() {
+			continue
+		}
+		switch s.signature.results[i] {
+		case contextType:
+			ctx = out.Interface().(context.Context)
+		case errorType:
+			err = out.Interface().(error)
+		}
+	}
+
+	return ctx, err
+}
+
+// ConvertArguments converts the captured groups to the types of the parameters of the step function following
+// its optional context parameter
+func (s *StepDefinition) ConvertArguments(arguments []string) ([]any, error) {
+	if len(s.signature.parameters) < len(arguments) {
+		return nil, fmt.Errorf("step %s expects %d arguments but %d captured", s.Definition,
+			len(s.signature.parameters), len(arguments))
+	}
+
+	converted := make([]any, 0, len(arguments))
+	for i, argument := range arguments {
+		value, err := s.convertArgument(converter.Settings{}, i, argument, s.signature.parameters[i])
+		if err != nil {
+			return nil, fmt.Errorf("could not convert argument %d of step %s, error=%w", i+1, s.Definition, err)
+		}
+		converted = append(converted, value.Interface())
+	}
+
+	return converted, nil
+}
+
+// argTypes are the types the arguments of the parameter types are converted to in models.Args
+var argTypes = map[string]reflect.Type{
+	"int":      reflect.TypeOf(0),
+	"ordinal":  reflect.TypeOf(0),
+	"float":    reflect.TypeOf(0.0),
+	"number":   reflect.TypeOf(0.0),
+	"percent":  reflect.TypeOf(0.0),
+	"duration": durationType,
+	"date":     timeType,
+	"uuid":     uuidType,
+	"ip":       ipType,
+	"semver":   versionType,
+	"json":     anyType,
+}
+
+// convertArgs converts the captured groups to models.Args keyed by the names of the groups or their positions
+func (s *StepDefinition) convertArgs(settings converter.Settings, arguments []string) (models.Args, error) {
+	args := make(models.Args, len(arguments))
+	for i, argument := range arguments {
+		key := strconv.Itoa(i + 1)
+		target := reflect.TypeOf("")
+		if i < len(s.captures) {
+			if len(s.captures[i].Name) > 0 {
+				key = s.captures[i].Name
+			}
+			if argType, ok := argTypes[s.captures[i].Type]; ok && !s.captures[i].Regexp {
+				target = argType
+			}
+		}
+		if i < len(s.transformers) && s.transformers[i] != nil {
+			target = anyType
+		}
+
+		value, err := s.convertArgument(settings, i, argument, parameter{target: target})
+		if err != nil {
+			return nil, fmt.Errorf("could not convert argument %s of step %s, error=%w", key, s.Definition, err)
+		}
+		args[key] = value.Interface()
+	}
+
+	return args, nil
+}
+
+// convertArgument converts the argument captured by the group with the index with the transformer of its custom
+// parameter type or to the type of the parameter. Arguments captured by {json} are unmarshalled into the target type
+// unless it is a string.
+func (s *StepDefinition) convertArgument(settings converter.Settings, index int, argument string,
+	parameter parameter) (reflect.Value, error) {
+	target := parameter.target
+	if index >= len(s.transformers) || s.transformers[index] == nil {
+		parameterType := ""
+		if index < len(s.captures) && !s.captures[index].Regexp {
+			parameterType = s.captures[index].Type
+		}
+		if parameterType == "json" && target.Kind() != reflect.String {
+			return convertJSON(argument, target)
+		}
+		if parameter.list {
+			return convertList(settings, argument, parameterType, target)
+		}
+
+		return convertCaptured(settings, argument, parameterType, target)
+	}
+
+	transformed, err := s.transformers[index](argument)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if transformed == nil {
+		return reflect.Zero(target), nil
+	}
+	value := reflect.ValueOf(transformed)
+	if !value.Type().AssignableTo(target) {
+		return reflect.Value{}, fmt.Errorf("parameter type transformed %q to %s, which can not be passed as %s",
+			argument, value.Type(), target)
+	}
+
+	return value, nil
+}
+
+// convertCaptured converts the argument captured by the parameter type. Percentages and ordinals are converted to
+// their value, such as 0.25 for 25% and 2 for 2nd, unless the target is a string.
+func convertCaptured(settings converter.Settings, argument string, parameterType string,
+	target reflect.Type) (reflect.Value, error) {
+	var (
+		number float64
+		err    error
+	)
+	switch {
+	case target.Kind() == reflect.String:
+		return convert(settings, argument, target)
+	case parameterType == "percent":
+		number, err = converter.ParsePercent(argument)
+	case parameterType == "ordinal":
+		var ordinal int
+		ordinal, err = converter.ParseOrdinal(argument)
+		number = float64(ordinal)
+	default:
+		return convert(settings, argument, target)
+	}
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	return convertNumber(number, target)
+}
+
+// convertNumber converts the number to the numeric target type if it fits the type
+func convertNumber(number float64, target reflect.Type) (reflect.Value, error) {
+	value := reflect.New(target).Elem()
+	switch target.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if value.OverflowFloat(number) {
+			return value, fmt.Errorf("%v does not fit %s", number, target)
+		}
+		value.SetFloat(number)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if number != float64(int64(number)) || value.OverflowInt(int64(number)) {
+			return value, fmt.Errorf("%v can not be converted to %s", number, target)
+		}
+		value.SetInt(int64(number))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if number < 0 || number != float64(uint64(number)) || value.OverflowUint(uint64(number)) {
+			return value, fmt.Errorf("%v can not be converted to %s", number, target)
+		}
+		value.SetUint(uint64(number))
+	default:
+		return value, errors.New("unsupported parameter type " + target.String())
+	}
+
+	return value, nil
+}
+
+// convert converts the argument to the target type, reading {now} and calendar durations from the clock of the
+// settings
+func convert(settings converter.Settings, argument string, target reflect.Type) (reflect.Value, error) {
+	value := reflect.New(target).Elem()
+
+	if target == timeType {
+		parsed, err := settings.ParseDate(argument)
+		if err != nil {
+			return value, err
+		}
+		value.Set(reflect.ValueOf(parsed))
+
+		return value, nil
+	}
+	if target == durationType {
+		parsed, err := settings.ParseDuration(argument)
+		if err != nil {
+			return value, err
+		}
+		value.SetInt(int64(parsed))
+
+		return value, nil
+	}
+	if target == uuidType {
+		parsed, err := converter.ParseUUID(argument)
+		if err != nil {
+			return value, err
+		}
+		value.Set(reflect.ValueOf(parsed))
+
+		return value, nil
+	}
+	if target == ipType {
+		parsed, err := converter.ParseIP(argument)
+		if err != nil {
+			return value, err
+		}
+		value.Set(reflect.ValueOf(parsed))
+
+		return value, nil
+	}
+	if target == versionType {
+		parsed, err := converter.ParseVersion(argument)
+		if err != nil {
+			return value, err
+		}
+		value.Set(reflect.ValueOf(parsed))
+
+		return value, nil
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		value.SetString(argument)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := converter.ParseInt(argument, target.Bits())
+		if err != nil {
+			return value, err
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := converter.ParseUint(argument, target.Bits())
+		if err != nil {
+			return value, err
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := converter.ParseFloat(argument, target.Bits())
+		if err != nil {
+			return value, err
+		}
+		value.SetFloat(parsed)
+	case reflect.Bool:
+		parsed, err := converter.ParseBool(argument)
+		if err != nil {
+			return value, err
+		}
+		value.SetBool(parsed)
+	default:
+		return value, errors.New("unsupported parameter type " + target.String())
+	}
+
+	return value, nil
+}
+
+// convertDocString passes the doc string as is to string parameters and unmarshals it as JSON into the others
+func convertDocString(content string, target reflect.Type) (reflect.Value, error) {
+	if target.Kind() == reflect.String {
+		return reflect.ValueOf(content).Convert(target), nil
+	}
+
+	return convertJSON(content, target)
+}
+
+// convertJSON unmarshals the JSON text into a new value of the target type
+func convertJSON(text string, target reflect.Type) (reflect.Value, error) {
+	value := reflect.New(target)
+	if err := converter.ParseJSON(text, value.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+
+	return value.Elem(), nil
+}
+
+// convertList converts the items of the list captured by the parameter type, such as "a, b, c", to the slice type
+func convertList(settings converter.Settings, argument string, parameterType string,
+	target reflect.Type) (reflect.Value, error) {
+	items := converter.SplitList(argument, parameterType)
+	value := reflect.MakeSlice(target, 0, len(items))
+	for i, item := range items {
+		converted, err := convert(settings, item, target.Elem())
+		if err != nil {
+			return value, fmt.Errorf("could not convert item %d of the list, error=%w", i+1, err)
+		}
+		value = reflect.Append(value, converted)
+	}
+
+	return value, nil
+}
+
+// convertTable converts a data table to models.Table, a two column data table to map[string]string and a data
+// table with a header row to []map[string]string with a map for each row keyed by the header
+func convertTable(table *messages.PickleTable, target reflect.Type) (reflect.Value, error) {
+	rows := make([][]string, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		cells := make([]string, 0, len(row.Cells))
+		for _, cell := range row.Cells {
+			cells = append(cells, cell.Value)
+		}
+		rows = append(rows, cells)
+	}
+
+	switch target {
+	case tableType:
+		return reflect.ValueOf(models.NewTable(rows)), nil
+	case rowMapType:
+		values := make(map[string]string, len(rows))
+		for i, row := range rows {
+			if len(row) != 2 {
+				return reflect.Value{}, fmt.Errorf("row %d has %d columns, map[string]string needs a two column table", i+1, len(row))
+			}
+			values[row[0]] = row[1]
+		}
+
+		return reflect.ValueOf(values), nil
+	case rowMapsType:
+		values := make([]map[string]string, 0)
+		if len(rows) == 0 {
+			return reflect.ValueOf(values), nil
+		}
+		header := rows[0]
+		for _, row := range rows[1:] {
+			value := make(map[string]string, len(header))
+			for i, column := range header {
+				value[column] = row[i]
+			}
+			values = append(values, value)
+		}
+
+		return reflect.ValueOf(values), nil
+	default:
+		return reflect.Value{}, errors.New("unsupported data table parameter type " + target.String())
+	}
+}
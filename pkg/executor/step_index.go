@@ -0,0 +1,78 @@
+package executor
+
+import (
+	"regexp/syntax"
+	"slices"
+)
+
+type (
+	// stepIndex finds the step definitions which may match a step text without running their regular expressions.
+	// Definitions anchored at the start of the text with a literal, such as ^I have (\d+) apples$, are kept in a
+	// trie of their literals, so only the definitions whose literal starts the text are candidates. The others are
+	// candidates for every text.
+	stepIndex struct {
+		root *prefixNode
+	}
+
+	prefixNode struct {
+		children map[byte]*prefixNode
+		// steps are the positions of the definitions whose literal prefix ends at the node
+		steps []int
+	}
+)
+
+func newStepIndex() *stepIndex {
+	return &stepIndex{root: &prefixNode{}}
+}
+
+// add adds the definition registered at the position with its regular expression
+func (i *stepIndex) add(position int, expression string) {
+	node := i.root
+	prefix := literalPrefix(expression)
+	for j := 0; j < len(prefix); j++ {
+		if node.children == nil {
+			node.children = make(map[byte]*prefixNode)
+		}
+		child, ok := node.children[prefix[j]]
+		if !ok {
+			child = &prefixNode{}
+			node.children[prefix[j]] = child
+		}
+		node = child
+	}
+	node.steps = append(node.steps, position)
+}
+
+// candidates returns the positions of the definitions which may match the text in the order of registration
+func (i *stepIndex) candidates(text string) []int {
+	candidates := slices.Clone(i.root.steps)
+	node := i.root
+	for j := 0; j < len(text); j++ {
+		if node = node.children[text[j]]; node == nil {
+			break
+		}
+		candidates = append(candidates, node.steps...)
+	}
+	slices.Sort(candidates)
+
+	return candidates
+}
+
+// literalPrefix returns the literal every match of the regular expression starts the text with, or an empty
+// string if the expression is not anchored at the start or does not start with a case-sensitive literal
+func literalPrefix(expression string) string {
+	parsed, err := syntax.Parse(expression, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+	parsed = parsed.Simplify()
+	if parsed.Op != syntax.OpConcat || len(parsed.Sub) < 2 || parsed.Sub[0].Op != syntax.OpBeginText {
+		return ""
+	}
+	literal := parsed.Sub[1]
+	if literal.Op != syntax.OpLiteral || literal.Flags&syntax.FoldCase != 0 {
+		return ""
+	}
+
+	return string(literal.Rune)
+}
@@ -0,0 +1,62 @@
+package executor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiteralPrefix(t *testing.T) {
+	t.Run("should return the literal anchored at the start of the expression", func(t *testing.T) {
+		for expression, prefix := range map[string]string{
+			`^I have (\d+) apples$`:        "I have ",
+			`^hello$`:                      "hello",
+			`^(?:a|an) apple$`:             "a",
+			`^the user "([^"]*)" logs in$`: `the user "`,
+			`I have (\d+) apples`:          "",
+			`(?i)^hello$`:                  "",
+			`^(\d+) apples$`:               "",
+			`^(`:                           "",
+		} {
+			require.Equal(t, prefix, literalPrefix(expression), expression)
+		}
+	})
+}
+
+func TestStepIndex(t *testing.T) {
+	t.Run("should return the steps whose literal starts the text and the unindexed steps in order", func(t *testing.T) {
+		index := newStepIndex()
+		index.add(0, `^I have (\d+) apples$`)
+		index.add(1, `(\d+) apples`)
+		index.add(2, `^I eat (\d+) apples$`)
+		index.add(3, `^I have (\d+) pears$`)
+		index.add(4, `^I (\w+) it$`)
+
+		require.Equal(t, []int{0, 1, 3, 4}, index.candidates("I have 3 apples"))
+		require.Equal(t, []int{1, 2, 4}, index.candidates("I eat 3 apples"))
+		require.Equal(t, []int{1}, index.candidates("You have 3 apples"))
+		require.Equal(t, []int{1}, index.candidates(""))
+	})
+}
+
+func TestStepExecutor_MatchStep_Index(t *testing.T) {
+	t.Run("should match the first registered step among thousands", func(t *testing.T) {
+		executor := NewStepExecutor()
+		for i := 0; i < 2000; i++ {
+			require.Nil(t, executor.RegisterStep(fmt.Sprintf(`^step %d has (\d+) apples$`, i), func(int) {}))
+		}
+		require.Nil(t, executor.RegisterStep(`(\d+) pears$`, func(int) {}))
+		require.Nil(t, executor.RegisterStep(`^step 7 has (\d+) pears$`, func(int) {}))
+
+		definition, arguments := executor.MatchStep("step 1999 has 3 apples")
+		require.Equal(t, `^step 1999 has (\d+) apples$`, definition.Definition)
+		require.Equal(t, []string{"3"}, arguments)
+
+		definition, _ = executor.MatchStep("step 7 has 3 pears")
+		require.Equal(t, `(\d+) pears$`, definition.Definition)
+
+		definition, _ = executor.MatchStep("step 2000 has 3 apples")
+		require.Nil(t, definition)
+	})
+}
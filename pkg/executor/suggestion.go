@@ -0,0 +1,105 @@
+package executor
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	// suggestionCount is the number of step definitions suggested for an undefined step
+	suggestionCount = 3
+)
+
+var (
+	// definitionNormalizations replace the parameters of step definitions with the placeholders
+	// models.NormalizeError replaces the values of step texts with, so definitions and texts can be compared
+	definitionNormalizations = []struct {
+		pattern     *regexp.Regexp
+		replacement string
+	}{
+		{regexp.MustCompile(`^\^|\$$`), ""},
+		{regexp.MustCompile(`\{string\}|"\([^)]*\)"`), `"<string>"`},
+		{regexp.MustCompile(`'\([^)]*\)'`), `'<string>'`},
+		{regexp.MustCompile(`\{(int|float|number)\}|\([^)]*\\d[^)]*\)`), "<n>"},
+		{regexp.MustCompile(`\\(.)`), "$1"},
+		{regexp.MustCompile(`\s+`), " "},
+	}
+)
+
+// undefinedFailure returns the failure of the undefined step with the step definitions closest to its text
+func (c *StepExecutor) undefinedFailure(text string) *models.Failure {
+	failure := &models.Failure{Message: fmt.Sprintf("step %q is not defined", text)}
+	suggestions := c.suggestions(text)
+	if len(suggestions) > 0 {
+		failure.Suggestions = suggestions
+		failure.Message += ", did you mean " + strings.Join(suggestions, " or ") + "?"
+	}
+
+	return failure
+}
+
+// suggestions returns up to suggestionCount step definitions whose normalized pattern is closest to the normalized
+// text by Levenshtein distance, closest first. Definitions differing from the text in more than half of the
+// characters are left out.
+func (c *StepExecutor) suggestions(text string) []string {
+	type candidate struct {
+		definition string
+		distance   int
+	}
+
+	normalized := []rune(strings.ToLower(models.NormalizeError(text)))
+	candidates := make([]candidate, 0, len(c.steps))
+	for _, step := range c.steps {
+		pattern := []rune(normalizeDefinition(step.Definition))
+		distance := levenshtein(normalized, pattern)
+		if distance*2 <= max(len(normalized), len(pattern)) {
+			candidates = append(candidates, candidate{definition: step.Definition, distance: distance})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	suggestions := make([]string, 0, suggestionCount)
+	for _, candidate := range candidates[:min(len(candidates), suggestionCount)] {
+		suggestions = append(suggestions, candidate.definition)
+	}
+
+	return suggestions
+}
+
+// normalizeDefinition returns the step definition without its anchors and with its parameters replaced with
+// placeholders, such as I have <n> apples for ^I have {int} apples$
+func normalizeDefinition(definition string) string {
+	for _, normalization := range definitionNormalizations {
+		definition = normalization.pattern.ReplaceAllString(definition, normalization.replacement)
+	}
+
+	return strings.ToLower(strings.TrimSpace(definition))
+}
+
+// levenshtein returns the number of rune insertions, deletions and substitutions turning a into b
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitution := previous[j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
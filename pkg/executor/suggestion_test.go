@@ -0,0 +1,32 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeDefinition(t *testing.T) {
+	t.Run("should replace the parameters with the placeholders of normalized step texts", func(t *testing.T) {
+		for definition, normalized := range map[string]string{
+			`^I have {int} apples$`:           "i have <n> apples",
+			`^I have (\d+) apples$`:           "i have <n> apples",
+			`^I open basket {string}$`:        `i open basket "<string>"`,
+			`^the user "([^"]*)" logs in\.$`:  `the user "<string>" logs in.`,
+			`^I   wait  {float} seconds$`:     "i wait <n> seconds",
+			`^the basket is {word} and full$`: "the basket is {word} and full",
+		} {
+			require.Equal(t, normalized, normalizeDefinition(definition), definition)
+		}
+	})
+}
+
+func TestLevenshtein(t *testing.T) {
+	t.Run("should count the edits turning one text into the other", func(t *testing.T) {
+		require.Equal(t, 0, levenshtein([]rune("apples"), []rune("apples")))
+		require.Equal(t, 1, levenshtein([]rune("apple"), []rune("apples")))
+		require.Equal(t, 3, levenshtein([]rune("kitten"), []rune("sitting")))
+		require.Equal(t, 5, levenshtein([]rune(""), []rune("pears")))
+		require.Equal(t, 1, levenshtein([]rune("şeker"), []rune("seker")))
+	})
+}
@@ -0,0 +1,154 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	TraceCategoryScenario   = "scenario"
+	TraceCategoryHook       = "hook"
+	TraceCategoryStep       = "step"
+	TraceCategoryConversion = "conversion"
+)
+
+type (
+	// Trace records the scenarios, hooks, steps and argument conversions of a run with their durations and the
+	// goroutines they ran on, so the order of scenarios executed at the same time can be inspected. It is saved in
+	// the Chrome trace event format, which Perfetto and chrome://tracing open.
+	Trace struct {
+		mutex  sync.Mutex
+		start  time.Time
+		events []TraceEvent
+	}
+
+	// TraceEvent is a complete event of the Chrome trace event format. Timestamp and Duration are in microseconds
+	// and the thread of an event is the goroutine it ran on.
+	TraceEvent struct {
+		Name      string            `json:"name"`
+		Category  string            `json:"cat"`
+		Phase     string            `json:"ph"`
+		Timestamp int64             `json:"ts"`
+		Duration  int64             `json:"dur"`
+		Process   int               `json:"pid"`
+		Thread    uint64            `json:"tid"`
+		Args      map[string]string `json:"args,omitempty"`
+	}
+
+	// traceFile is the JSON object format of Chrome trace files
+	traceFile struct {
+		TraceEvents     []TraceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}
+
+	// span is an event of a trace which has started
+	span struct {
+		trace *Trace
+		event TraceEvent
+		start time.Time
+	}
+
+	traceKey struct{}
+)
+
+func NewTrace() *Trace {
+	return &Trace{
+		start:  time.Now(),
+		events: make([]TraceEvent, 0),
+	}
+}
+
+// ContextWithTrace returns a copy of the context whose hooks, steps and conversions are recorded in the trace
+func ContextWithTrace(ctx context.Context, trace *Trace) context.Context {
+	if trace == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, traceKey{}, trace)
+}
+
+// traceFrom returns the trace of the context, or nil if it is not traced
+func traceFrom(ctx context.Context) *Trace {
+	trace, _ := ctx.Value(traceKey{}).(*Trace)
+
+	return trace
+}
+
+// Events returns the recorded events in the order they finished
+func (t *Trace) Events() []TraceEvent {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	events := make([]TraceEvent, len(t.events))
+	copy(events, t.events)
+
+	return events
+}
+
+// Save writes the recorded events as a Chrome trace file to the path
+func (t *Trace) Save(path string) error {
+	content, err := json.Marshal(traceFile{TraceEvents: t.Events(), DisplayTimeUnit: "ms"})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("could not write trace to %s, error=%w", path, err)
+	}
+
+	return nil
+}
+
+// begin starts an event of the current goroutine. It returns nil if the trace is nil, so untraced runs do not
+// record anything.
+func (t *Trace) begin(category, name string) *span {
+	if t == nil {
+		return nil
+	}
+
+	return &span{
+		trace: t,
+		start: time.Now(),
+		event: TraceEvent{
+			Name:     name,
+			Category: category,
+			Phase:    "X",
+			Process:  1,
+			Thread:   goroutineID(),
+		},
+	}
+}
+
+// end records the event with its duration and the arguments describing it
+func (s *span) end(args map[string]string) {
+	if s == nil {
+		return
+	}
+	s.event.Timestamp = s.start.Sub(s.trace.start).Microseconds()
+	s.event.Duration = time.Since(s.start).Microseconds()
+	s.event.Args = args
+
+	s.trace.mutex.Lock()
+	s.trace.events = append(s.trace.events, s.event)
+	s.trace.mutex.Unlock()
+}
+
+// goroutineID returns the id of the current goroutine, read from the first line of its stack trace, such as
+// "goroutine 7 [running]:"
+func goroutineID() uint64 {
+	buffer := make([]byte, 64)
+	buffer = buffer[:runtime.Stack(buffer, false)]
+	buffer = bytes.TrimPrefix(buffer, []byte("goroutine "))
+	if index := bytes.IndexByte(buffer, ' '); index > 0 {
+		buffer = buffer[:index]
+	}
+	id, _ := strconv.ParseUint(string(buffer), 10, 64)
+
+	return id
+}
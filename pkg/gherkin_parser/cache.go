@@ -0,0 +1,152 @@
+package gherkin_parser
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"sync"
+
+	messages "github.com/cucumber/messages/go/v21"
+)
+
+type (
+	// DocumentCache keeps the documents parsed from feature files with the hash of their content, so a file is
+	// parsed again only if it changes. It is safe for concurrent use.
+	DocumentCache struct {
+		mutex     sync.Mutex
+		documents map[documentKey]cachedDocument
+	}
+
+	// documentKey is the path of a feature file in its file system, which is nil for the files on the disk
+	documentKey struct {
+		fsys any
+		path string
+	}
+
+	cachedDocument struct {
+		hash     [sha256.Size]byte
+		document *messages.GherkinDocument
+	}
+)
+
+var defaultCache = NewDocumentCache()
+
+// NewDocumentCache creates an empty document cache
+func NewDocumentCache() *DocumentCache {
+	return &DocumentCache{documents: make(map[documentKey]cachedDocument)}
+}
+
+// ParseFile returns the document of the feature file with its Uri set to the path. The file is read every time,
+// but it is parsed only if its content changed since it was parsed last. The documents are shared by the callers,
+// so they must not be changed.
+func (c *DocumentCache) ParseFile(path string) (*messages.GherkinDocument, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read file %s, error=%w", path, err)
+	}
+
+	return c.parse(documentKey{path: path}, source)
+}
+
+// ParseFS returns the document of the feature file at the path in the file system, such as an embed.FS, like
+// ParseFile
+func (c *DocumentCache) ParseFS(fsys fs.FS, path string) (*messages.GherkinDocument, error) {
+	source, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read file %s, error=%w", path, err)
+	}
+	identity, ok := fileSystemIdentity(fsys)
+	if !ok {
+		return parseSource(path, source)
+	}
+
+	return c.parse(documentKey{fsys: identity, path: path}, source)
+}
+
+func (c *DocumentCache) parse(key documentKey, source []byte) (*messages.GherkinDocument, error) {
+	hash := sha256.Sum256(source)
+
+	c.mutex.Lock()
+	cached, ok := c.documents[key]
+	c.mutex.Unlock()
+	if ok && cached.hash == hash {
+		return cached.document, nil
+	}
+
+	document, err := parseSource(key.path, source)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	c.documents[key] = cachedDocument{hash: hash, document: document}
+	c.mutex.Unlock()
+
+	return document, nil
+}
+
+// parseSource parses the content of the feature file at the path
+func parseSource(path string, source []byte) (*messages.GherkinDocument, error) {
+	document, err := ParseGherkinFile(bytes.NewReader(source))
+	if err != nil {
+		return nil, fmt.Errorf("gherkin parse error in file %s, error=%w", path, err)
+	}
+	document.Uri = path
+
+	return document, nil
+}
+
+// fileSystemIdentity returns a comparable value identifying the file system, such as the file system itself or the
+// pointer of a fstest.MapFS. It returns false for file systems which can not be identified, whose files are not
+// cached.
+func fileSystemIdentity(fsys fs.FS) (any, bool) {
+	value := reflect.ValueOf(fsys)
+	if value.Comparable() {
+		return fsys, true
+	}
+	switch value.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Func:
+		return value.Pointer(), true
+	default:
+		return nil, false
+	}
+}
+
+// ParseDir returns the documents of the feature files in the directories in the order of SearchFeatureFilesIn
+func (c *DocumentCache) ParseDir(directories []string) ([]*messages.GherkinDocument, error) {
+	files, err := SearchFeatureFilesIn(directories)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]*messages.GherkinDocument, 0, len(files))
+	for _, file := range files {
+		document, err := c.ParseFile(file)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+
+	return documents, nil
+}
+
+// ParseFileCached parses the feature file with the cache shared by the process, see DocumentCache.ParseFile
+func ParseFileCached(path string) (*messages.GherkinDocument, error) {
+	return defaultCache.ParseFile(path)
+}
+
+// ParseFSCached parses the feature file in the file system with the cache shared by the process, see
+// DocumentCache.ParseFS
+func ParseFSCached(fsys fs.FS, path string) (*messages.GherkinDocument, error) {
+	return defaultCache.ParseFS(fsys, path)
+}
+
+// ParseDirCached parses the feature files in the directories with the cache shared by the process, so runs
+// repeated in the same process, such as reruns and dry runs, do not parse unchanged files again
+func ParseDirCached(directories []string) ([]*messages.GherkinDocument, error) {
+	return defaultCache.ParseDir(directories)
+}
@@ -0,0 +1,81 @@
+package gherkin_parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentCache_ParseFile(t *testing.T) {
+	t.Run("should return the same document until the file changes", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "basket.feature")
+		require.Nil(t, os.WriteFile(path, []byte("Feature: Basket\n"), 0o644))
+		cache := NewDocumentCache()
+
+		first, err := cache.ParseFile(path)
+		require.Nil(t, err)
+		second, err := cache.ParseFile(path)
+		require.Nil(t, err)
+
+		require.Same(t, first, second)
+		require.Equal(t, path, first.Uri)
+		require.Equal(t, "Basket", first.Feature.Name)
+
+		require.Nil(t, os.WriteFile(path, []byte("Feature: Fruit basket\n"), 0o644))
+		changed, err := cache.ParseFile(path)
+
+		require.Nil(t, err)
+		require.NotSame(t, first, changed)
+		require.Equal(t, "Fruit basket", changed.Feature.Name)
+	})
+
+	t.Run("should return error if the file is not valid gherkin", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "invalid.feature")
+		require.Nil(t, os.WriteFile(path, []byte("Given an apple\n"), 0o644))
+
+		_, err := NewDocumentCache().ParseFile(path)
+
+		require.ErrorContains(t, err, "gherkin parse error in file "+path)
+	})
+}
+
+func TestDocumentCache_ParseFS(t *testing.T) {
+	t.Run("should keep the documents of every file system apart", func(t *testing.T) {
+		directory := t.TempDir()
+		require.Nil(t, os.WriteFile(filepath.Join(directory, "basket.feature"), []byte("Feature: Basket\n"), 0o644))
+		embedded := fstest.MapFS{"basket.feature": {Data: []byte("Feature: Embedded basket\n")}}
+		disk := os.DirFS(directory)
+		cache := NewDocumentCache()
+
+		fromDisk, err := cache.ParseFS(disk, "basket.feature")
+		require.Nil(t, err)
+		fromMap, err := cache.ParseFS(embedded, "basket.feature")
+		require.Nil(t, err)
+		again, err := cache.ParseFS(disk, "basket.feature")
+		require.Nil(t, err)
+
+		require.Equal(t, "Basket", fromDisk.Feature.Name)
+		require.Equal(t, "Embedded basket", fromMap.Feature.Name)
+		require.Same(t, fromDisk, again)
+	})
+}
+
+func TestParseDirCached(t *testing.T) {
+	t.Run("should return the documents of all feature files in a directory", func(t *testing.T) {
+		documents, err := ParseDirCached([]string{"testdata"})
+
+		require.Nil(t, err)
+		uris := make([]string, 0, len(documents))
+		for _, document := range documents {
+			uris = append(uris, document.Uri)
+		}
+		require.Equal(t, []string{
+			"testdata/feature-source-1/source-one.feature",
+			"testdata/feature-source-2/source-two.feature",
+			"testdata/source-three.feature",
+		}, uris)
+	})
+}
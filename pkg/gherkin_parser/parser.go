@@ -40,6 +40,30 @@ func SearchFeatureFilesIn(directories []string) ([]string, error) {
 	return featureFiles, nil
 }
 
+// SearchFeatureFilesInFS returns the feature files under the roots of the file system, such as an embed.FS. The
+// roots are slash separated paths in the file system, "." is its root.
+func SearchFeatureFilesInFS(fsys fs.FS, roots []string) ([]string, error) {
+	featureFiles := make([]string, 0)
+
+	for _, root := range roots {
+		err := fs.WalkDir(fsys, root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), FeatureExtension) {
+				featureFiles = append(featureFiles, path)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return featureFiles, nil
+}
+
 func ParseGherkinFile(reader io.Reader) (*messages.GherkinDocument, error) {
 	id := (&messages.Incrementing{}).NewId
 	document, err := gherkin.ParseGherkinDocument(reader, id)
@@ -35,3 +35,15 @@ func TestSearchFeatureFilesIn(t *testing.T) {
 		require.Equal(t, expectedFiles, actualFiles)
 	})
 }
+
+func TestSearchFeatureFilesInFS(t *testing.T) {
+	t.Run("should return all feature files under the roots of the file system", func(t *testing.T) {
+		actualFiles, err := SearchFeatureFilesInFS(os.DirFS("testdata"), []string{"feature-source-2", "feature-source-1"})
+
+		require.Nil(t, err)
+		require.Equal(t, []string{
+			"feature-source-2/source-two.feature",
+			"feature-source-1/source-one.feature",
+		}, actualFiles)
+	})
+}
@@ -0,0 +1,139 @@
+package linter
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+
+	gherkin "github.com/cucumber/gherkin/go/v26"
+	messages "github.com/cucumber/messages/go/v21"
+	"github.com/denizgursoy/cacik/pkg/gherkin_parser"
+	"github.com/denizgursoy/cacik/pkg/pattern"
+)
+
+type (
+	// StepContract is a step definition with the keys of the scenario data its step function reads and writes
+	StepContract struct {
+		Definition string
+		Reads      []string
+		Writes     []string
+	}
+
+	// contract is a step contract with its compiled definition
+	contract struct {
+		*StepContract
+		pattern *regexp.Regexp
+	}
+)
+
+// LintDataFlowDirectories checks the data flow of the scenarios of every feature file in the directories with
+// LintDataFlow
+func LintDataFlowDirectories(directories []string, steps []*StepContract, written []string) ([]Issue, error) {
+	files, err := gherkin_parser.SearchFeatureFilesIn(directories)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]Issue, 0)
+	for _, file := range files {
+		source, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not read file %s, error=%w", file, err)
+		}
+		issues = append(issues, LintDataFlow(file, source, steps, written)...)
+	}
+
+	return issues, nil
+}
+
+// LintDataFlow reports the steps of the feature file source which read a key of the scenario data that no earlier
+// step of their scenario or its background writes, so the step only works after another scenario wrote the key.
+// written are the keys written by hooks before every scenario. Steps matching no definition are left out and a
+// source which can not be parsed has no data flow issues, Lint reports its errors.
+func LintDataFlow(uri string, source []byte, steps []*StepContract, written []string) []Issue {
+	l := &linter{uri: uri, issues: make([]Issue, 0)}
+	document, err := gherkin_parser.ParseGherkinFile(bytes.NewReader(source))
+	if err != nil || document.Feature == nil {
+		return l.issues
+	}
+
+	contracts := compileContracts(steps)
+	lines := stepLines(document)
+	reported := make(map[string]bool)
+	for _, pickle := range gherkin.Pickles(*document, uri, (&messages.Incrementing{}).NewId) {
+		available := slices.Clone(written)
+		for _, step := range pickle.Steps {
+			index := slices.IndexFunc(contracts, func(c *contract) bool {
+				return c.pattern.MatchString(step.Text)
+			})
+			if index < 0 {
+				continue
+			}
+			line := lines[step.AstNodeIds[0]]
+			for _, key := range contracts[index].Reads {
+				// a step of a scenario outline is reported once for all of its examples
+				issue := fmt.Sprintf("%d:%s", line, key)
+				if !slices.Contains(available, key) && !reported[issue] {
+					reported[issue] = true
+					l.add(line, SeverityError, "step %q of scenario %q reads data %q which no earlier step writes",
+						step.Text, pickle.Name, key)
+				}
+			}
+			available = append(available, contracts[index].Writes...)
+		}
+	}
+
+	slices.SortStableFunc(l.issues, func(a, b Issue) int {
+		return a.Line - b.Line
+	})
+
+	return l.issues
+}
+
+// compileContracts compiles the definitions of the steps. Parameter types registered at runtime are matched as
+// any text and definitions which can not be compiled are left out.
+func compileContracts(steps []*StepContract) []*contract {
+	contracts := make([]*contract, 0, len(steps))
+	for _, step := range steps {
+		// parameter types registered at runtime are not known here, they are matched as any text
+		compiled, err := regexp.Compile(pattern.TransformWithTypes(step.Definition, pattern.AnyTextTypes(step.Definition)))
+		if err != nil {
+			continue
+		}
+		contracts = append(contracts, &contract{StepContract: step, pattern: compiled})
+	}
+
+	return contracts
+}
+
+// stepLines returns the lines of the steps in the document by their AST node id
+func stepLines(document *messages.GherkinDocument) map[string]int64 {
+	lines := make(map[string]int64)
+	addSteps := func(steps []*messages.Step) {
+		for _, step := range steps {
+			lines[step.Id] = step.Location.Line
+		}
+	}
+	for _, child := range document.Feature.Children {
+		if child.Background != nil {
+			addSteps(child.Background.Steps)
+		}
+		if child.Scenario != nil {
+			addSteps(child.Scenario.Steps)
+		}
+		if child.Rule != nil {
+			for _, ruleChild := range child.Rule.Children {
+				if ruleChild.Background != nil {
+					addSteps(ruleChild.Background.Steps)
+				}
+				if ruleChild.Scenario != nil {
+					addSteps(ruleChild.Scenario.Steps)
+				}
+			}
+		}
+	}
+
+	return lines
+}
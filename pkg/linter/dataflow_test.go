@@ -0,0 +1,55 @@
+package linter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintDataFlow(t *testing.T) {
+	steps := []*StepContract{
+		{Definition: `^I open a basket$`, Writes: []string{"basket"}},
+		{Definition: `^I add {int} {fruit}$`, Reads: []string{"basket", "user"}, Writes: []string{"basket"}},
+		{Definition: `^I pay$`, Reads: []string{"basket"}},
+	}
+
+	t.Run("should report steps reading data no earlier step writes", func(t *testing.T) {
+		issues := LintDataFlow("a.feature", []byte(`Feature: Basket
+
+  Background:
+    Given I log in
+
+  Scenario: Buy apples
+    Given I open a basket
+    When I add 3 apples
+    Then I pay
+
+  Scenario Outline: Buy pears
+    When I add <count> pears
+    Then I pay
+
+    Examples:
+      | count |
+      | 1     |
+      | 2     |
+`), steps, []string{"user"})
+
+		require.Equal(t, []Issue{{
+			Uri:      "a.feature",
+			Line:     12,
+			Severity: SeverityError,
+			Message:  `step "I add 1 pears" of scenario "Buy pears" reads data "basket" which no earlier step writes`,
+		}}, issues)
+	})
+	t.Run("should report data written only by hooks which are not declared", func(t *testing.T) {
+		issues := LintDataFlow("a.feature", []byte(`Feature: Basket
+
+  Scenario: Buy apples
+    Given I open a basket
+    When I add 3 apples
+`), steps, nil)
+
+		require.Len(t, issues, 1)
+		require.Contains(t, issues[0].Message, `reads data "user"`)
+	})
+}
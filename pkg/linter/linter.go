@@ -0,0 +1,248 @@
+// Package linter reports problems of feature files which parse but are likely mistakes, such as duplicate
+// scenario names or Examples tables not matching their scenario outline.
+package linter
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	messages "github.com/cucumber/messages/go/v21"
+	"github.com/denizgursoy/cacik/pkg/gherkin_parser"
+)
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+var (
+	parseErrorPattern  = regexp.MustCompile(`^\((\d+):\d+\): (.*)$`)
+	placeholderPattern = regexp.MustCompile(`<([^<>]+)>`)
+)
+
+type (
+	Severity string
+
+	Issue struct {
+		Uri      string
+		Line     int
+		Severity Severity
+		Message  string
+	}
+
+	linter struct {
+		uri    string
+		issues []Issue
+	}
+)
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s", i.Uri, i.Line, i.Severity, i.Message)
+}
+
+// HasErrors reports whether any of the issues is an error
+func HasErrors(issues []Issue) bool {
+	return slices.ContainsFunc(issues, func(issue Issue) bool {
+		return issue.Severity == SeverityError
+	})
+}
+
+// LintDirectories lints every feature file in the directories
+func LintDirectories(directories []string) ([]Issue, error) {
+	files, err := gherkin_parser.SearchFeatureFilesIn(directories)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]Issue, 0)
+	for _, file := range files {
+		source, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not read file %s, error=%w", file, err)
+		}
+		issues = append(issues, Lint(file, source)...)
+	}
+
+	return issues, nil
+}
+
+// Lint returns the issues of the feature file source in the order of their lines
+func Lint(uri string, source []byte) []Issue {
+	l := &linter{uri: uri, issues: make([]Issue, 0)}
+
+	trailingTags := l.checkTrailingTags(source)
+	document, err := gherkin_parser.ParseGherkinFile(bytes.NewReader(source))
+	if err != nil {
+		l.addParseErrors(err, trailingTags)
+	}
+	if document != nil && document.Feature != nil {
+		l.checkChildren(document.Feature.Children)
+	}
+
+	slices.SortStableFunc(l.issues, func(a, b Issue) int {
+		return a.Line - b.Line
+	})
+
+	return l.issues
+}
+
+func (l *linter) add(line int64, severity Severity, format string, arguments ...any) {
+	l.issues = append(l.issues, Issue{
+		Uri:      l.uri,
+		Line:     int(line),
+		Severity: severity,
+		Message:  fmt.Sprintf(format, arguments...),
+	})
+}
+
+func (l *linter) addParseErrors(err error, trailingTags bool) {
+	for _, line := range strings.Split(err.Error(), "\n") {
+		match := parseErrorPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		if trailingTags && strings.HasPrefix(match[2], "unexpected end of file") {
+			continue
+		}
+		number, _ := strconv.ParseInt(match[1], 10, 64)
+		l.add(number, SeverityError, "%s", match[2])
+	}
+}
+
+// checkTrailingTags reports tags at the end of the file which are not followed by a scenario
+func (l *linter) checkTrailingTags(source []byte) bool {
+	lastLine, lastNumber := "", 0
+	scanner := bufio.NewScanner(bytes.NewReader(source))
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) > 0 && !strings.HasPrefix(line, "#") {
+			lastLine, lastNumber = line, number
+		}
+	}
+	if !strings.HasPrefix(lastLine, "@") {
+		return false
+	}
+	l.add(int64(lastNumber), SeverityError, "tags %s are not followed by a scenario", lastLine)
+
+	return true
+}
+
+func (l *linter) checkChildren(children []*messages.FeatureChild) {
+	names := make(map[string]int64)
+	var column int64
+	for _, child := range children {
+		if child.Rule != nil {
+			l.checkRuleChildren(child.Rule.Children, names)
+			continue
+		}
+		if child.Background != nil {
+			l.checkSteps(child.Background.Steps)
+		}
+		if child.Scenario == nil {
+			continue
+		}
+		if column == 0 {
+			column = child.Scenario.Location.Column
+		} else if child.Scenario.Location.Column != column {
+			l.add(child.Scenario.Location.Line, SeverityWarning,
+				"scenario %q is indented differently than the first scenario", child.Scenario.Name)
+		}
+		l.checkScenario(child.Scenario, names)
+	}
+}
+
+func (l *linter) checkRuleChildren(children []*messages.RuleChild, names map[string]int64) {
+	for _, child := range children {
+		if child.Background != nil {
+			l.checkSteps(child.Background.Steps)
+		}
+		if child.Scenario != nil {
+			l.checkScenario(child.Scenario, names)
+		}
+	}
+}
+
+func (l *linter) checkScenario(scenario *messages.Scenario, names map[string]int64) {
+	line := scenario.Location.Line
+	if first, ok := names[scenario.Name]; ok {
+		l.add(line, SeverityError, "scenario name %q is already used at line %d", scenario.Name, first)
+	} else {
+		names[scenario.Name] = line
+	}
+
+	if len(scenario.Steps) == 0 {
+		l.add(line, SeverityError, "scenario %q has no steps", scenario.Name)
+	}
+	l.checkSteps(scenario.Steps)
+	l.checkExamples(scenario)
+}
+
+// checkSteps reports steps indented differently than the first step
+func (l *linter) checkSteps(steps []*messages.Step) {
+	for _, step := range steps {
+		if step.Location.Column != steps[0].Location.Column {
+			l.add(step.Location.Line, SeverityWarning, "step %q is indented differently than the first step",
+				strings.TrimSpace(step.Keyword)+" "+step.Text)
+		}
+	}
+}
+
+// checkExamples reports placeholders of the scenario outline missing from its Examples tables and columns of the
+// Examples tables not used by the outline
+func (l *linter) checkExamples(scenario *messages.Scenario) {
+	if len(scenario.Examples) == 0 {
+		return
+	}
+
+	placeholders := make([]string, 0)
+	addPlaceholders := func(text string) {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+			if !slices.Contains(placeholders, match[1]) {
+				placeholders = append(placeholders, match[1])
+			}
+		}
+	}
+	addPlaceholders(scenario.Name)
+	for _, step := range scenario.Steps {
+		addPlaceholders(step.Text)
+		if step.DocString != nil {
+			addPlaceholders(step.DocString.Content)
+		}
+		if step.DataTable != nil {
+			for _, row := range step.DataTable.Rows {
+				for _, cell := range row.Cells {
+					addPlaceholders(cell.Value)
+				}
+			}
+		}
+	}
+
+	for _, examples := range scenario.Examples {
+		if examples.TableHeader == nil {
+			l.add(examples.Location.Line, SeverityError, "examples of scenario %q have no table", scenario.Name)
+			continue
+		}
+		columns := make([]string, 0, len(examples.TableHeader.Cells))
+		for _, cell := range examples.TableHeader.Cells {
+			columns = append(columns, cell.Value)
+		}
+		for _, placeholder := range placeholders {
+			if !slices.Contains(columns, placeholder) {
+				l.add(examples.Location.Line, SeverityError, "examples of scenario %q have no column %q",
+					scenario.Name, placeholder)
+			}
+		}
+		for _, column := range columns {
+			if !slices.Contains(placeholders, column) {
+				l.add(examples.TableHeader.Location.Line, SeverityWarning,
+					"column %q of the examples is not used by scenario %q", column, scenario.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package linter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	t.Run("should return no issue for a valid feature file", func(t *testing.T) {
+		issues := Lint("a.feature", []byte(`Feature: Apples
+
+  Scenario Outline: Eat <count> apples
+    Given I have <count> apples
+
+    Examples:
+      | count |
+      | 1     |
+`))
+
+		require.Empty(t, issues)
+		require.False(t, HasErrors(issues))
+	})
+	t.Run("should report problems of scenarios with their lines", func(t *testing.T) {
+		issues := Lint("a.feature", []byte(`Feature: Apples
+
+  Scenario: Eat apples
+    Given I have 3 apples
+      When I eat 1 apple
+
+  Scenario: Eat apples
+    Given I have 3 apples
+
+  Scenario: Nothing
+
+  Scenario Outline: Sell apples
+    Given I sell <count> apples
+
+    Examples:
+      | amount |
+      | 1      |
+`))
+
+		messages := make([]string, 0)
+		for _, issue := range issues {
+			messages = append(messages, issue.String())
+		}
+		require.Equal(t, []string{
+			`a.feature:5: warning: step "When I eat 1 apple" is indented differently than the first step`,
+			`a.feature:7: error: scenario name "Eat apples" is already used at line 3`,
+			`a.feature:10: error: scenario "Nothing" has no steps`,
+			`a.feature:15: error: examples of scenario "Sell apples" have no column "count"`,
+			`a.feature:16: warning: column "amount" of the examples is not used by scenario "Sell apples"`,
+		}, messages)
+		require.True(t, HasErrors(issues))
+	})
+	t.Run("should report tags not followed by a scenario", func(t *testing.T) {
+		issues := Lint("a.feature", []byte(`Feature: Apples
+
+  Scenario: Eat apples
+    Given I have 3 apples
+
+  @wip
+`))
+
+		require.Equal(t, []Issue{{Uri: "a.feature", Line: 6, Severity: SeverityError,
+			Message: "tags @wip are not followed by a scenario"}}, issues)
+	})
+	t.Run("should report parse errors", func(t *testing.T) {
+		issues := Lint("a.feature", []byte(`Feature: Apples
+
+  Scenario Outline: Eat <count> apples
+    Given I have <count> apples
+
+    Examples:
+      | count | name |
+      | 1     |
+`))
+
+		require.Equal(t, []Issue{{Uri: "a.feature", Line: 8, Severity: SeverityError,
+			Message: "inconsistent cell count within the table"}}, issues)
+	})
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/converter"
+)
+
+type (
+	// Args are the arguments captured by a step definition. A step function receives them instead of positional
+	// parameters if its first parameter after the context is models.Args. Arguments of named groups, such as
+	// (?P<count>\d+), are keyed by the name of the group and the others by their position starting from "1".
+	// Arguments of parameter types are converted to their type, such as int for {int}, and the others are strings.
+	Args map[string]any
+)
+
+// Get returns the argument with the name
+func (a Args) Get(name string) (any, error) {
+	value, ok := a[name]
+	if !ok {
+		return nil, fmt.Errorf("step does not capture argument %q", name)
+	}
+
+	return value, nil
+}
+
+// GetString returns the argument with the name as it is written in the step if it is a string, or formatted with
+// fmt otherwise
+func (a Args) GetString(name string) (string, error) {
+	value, err := a.Get(name)
+	if err != nil {
+		return "", err
+	}
+	if text, ok := value.(string); ok {
+		return text, nil
+	}
+
+	return fmt.Sprint(value), nil
+}
+
+func (a Args) GetInt(name string) (int, error) {
+	return getArg(a, name, func(value string) (int, error) {
+		parsed, err := converter.ParseInt(value, 0)
+
+		return int(parsed), err
+	})
+}
+
+func (a Args) GetFloat(name string) (float64, error) {
+	return getArg(a, name, func(value string) (float64, error) {
+		return converter.ParseFloat(value, 64)
+	})
+}
+
+func (a Args) GetBool(name string) (bool, error) {
+	return getArg(a, name, converter.ParseBool)
+}
+
+func (a Args) GetDuration(name string) (time.Duration, error) {
+	return getArg(a, name, converter.ParseDuration)
+}
+
+func (a Args) GetTime(name string) (time.Time, error) {
+	return getArg(a, name, converter.ParseDate)
+}
+
+// getArg returns the argument with the name if it has the type T, or parses it if it is a string
+func getArg[T any](a Args, name string, parse func(string) (T, error)) (T, error) {
+	var zero T
+	value, err := a.Get(name)
+	if err != nil {
+		return zero, err
+	}
+	switch typed := value.(type) {
+	case T:
+		return typed, nil
+	case string:
+		parsed, err := parse(typed)
+		if err != nil {
+			return zero, fmt.Errorf("argument %q: could not convert %q, error=%w", name, typed, err)
+		}
+
+		return parsed, nil
+	default:
+		return zero, fmt.Errorf("argument %q is %T, not %T", name, value, zero)
+	}
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArgs_Get(t *testing.T) {
+	args := Args{"count": 3, "price": "2.5", "active": "true", "timeout": "1m", "room": "kitchen"}
+
+	t.Run("should return typed values and parse strings", func(t *testing.T) {
+		count, err := args.GetInt("count")
+		require.Nil(t, err)
+		require.Equal(t, 3, count)
+
+		price, err := args.GetFloat("price")
+		require.Nil(t, err)
+		require.Equal(t, 2.5, price)
+
+		active, err := args.GetBool("active")
+		require.Nil(t, err)
+		require.True(t, active)
+
+		timeout, err := args.GetDuration("timeout")
+		require.Nil(t, err)
+		require.Equal(t, time.Minute, timeout)
+
+		count, err = args.GetInt("room")
+		require.EqualError(t, err, `argument "room": could not convert "kitchen", error=strconv.ParseInt: `+
+			`parsing "kitchen": invalid syntax`)
+		require.Zero(t, count)
+	})
+
+	t.Run("should format values as strings", func(t *testing.T) {
+		count, err := args.GetString("count")
+		require.Nil(t, err)
+		require.Equal(t, "3", count)
+	})
+
+	t.Run("should return error for arguments which are not captured or have another type", func(t *testing.T) {
+		_, err := args.Get("color")
+		require.EqualError(t, err, `step does not capture argument "color"`)
+
+		_, err = args.GetTime("count")
+		require.EqualError(t, err, `argument "count" is int, not time.Time`)
+	})
+}
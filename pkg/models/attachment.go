@@ -0,0 +1,54 @@
+package models
+
+import "context"
+
+type (
+	Attachment struct {
+		Name      string `json:"name"`
+		MediaType string `json:"mediaType"`
+		Data      []byte `json:"data"`
+	}
+
+	// Attachments collects the attachments of a scenario until the executor assigns them to a step or the scenario
+	Attachments struct {
+		items []*Attachment
+	}
+
+	attachmentsKey struct{}
+)
+
+func NewAttachments() *Attachments {
+	return &Attachments{
+		items: make([]*Attachment, 0),
+	}
+}
+
+func (a *Attachments) Add(attachment *Attachment) {
+	a.items = append(a.items, attachment)
+}
+
+// Take returns the collected attachments and clears the collection
+func (a *Attachments) Take() []*Attachment {
+	items := a.items
+	a.items = make([]*Attachment, 0)
+
+	return items
+}
+
+// ContextWithAttachments returns a copy of the context carrying the attachment collection
+func ContextWithAttachments(ctx context.Context, attachments *Attachments) context.Context {
+	return context.WithValue(ctx, attachmentsKey{}, attachments)
+}
+
+// Attach attaches data such as a screenshot, a log or a JSON payload to the running step or hook.
+// Attachments made in a step or its step hooks are stored on the StepResult, the others on the ScenarioResult.
+// It does nothing if the context does not belong to a scenario.
+func Attach(ctx context.Context, name string, mediaType string, data []byte) {
+	if attachments, ok := ctx.Value(attachmentsKey{}).(*Attachments); ok {
+		attachments.Add(&Attachment{
+			Name:      name,
+			MediaType: mediaType,
+			Data:      data,
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+type (
+	// Data is a key value store created for each scenario. Hooks and steps of the scenario share the same store,
+	// so a BeforeScenario hook can seed values which are used by the steps. It is safe for concurrent use, such as
+	// by goroutines started by a step.
+	Data struct {
+		mutex  sync.RWMutex
+		values map[string]any
+	}
+
+	dataKey struct{}
+)
+
+func NewData() *Data {
+	return &Data{
+		values: make(map[string]any),
+	}
+}
+
+func (d *Data) Get(key string) (any, bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	value, ok := d.values[key]
+
+	return value, ok
+}
+
+func (d *Data) Set(key string, value any) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.values[key] = value
+}
+
+// GetOrSet returns the value of the key if it is set. Otherwise it sets the value and returns it. loaded reports
+// whether the value was set before.
+func (d *Data) GetOrSet(key string, value any) (actual any, loaded bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if existing, ok := d.values[key]; ok {
+		return existing, true
+	}
+	d.values[key] = value
+
+	return value, false
+}
+
+func (d *Data) Delete(key string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	delete(d.values, key)
+}
+
+// Keys returns the sorted keys of the store
+func (d *Data) Keys() []string {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	keys := make([]string, 0, len(d.values))
+	for key := range d.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// CopyTo copies the values of the store to the map
+func (d *Data) CopyTo(values map[string]any) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	for key, value := range d.values {
+		values[key] = value
+	}
+}
+
+// GetAs returns the value of the key in the data store of the scenario the context belongs to if it has the type T
+func GetAs[T any](ctx context.Context, key string) (T, bool) {
+	value, _ := DataFrom(ctx).Get(key)
+	typed, ok := value.(T)
+
+	return typed, ok
+}
+
+// SetAs sets the value of the key in the data store of the scenario the context belongs to
+func SetAs[T any](ctx context.Context, key string, value T) {
+	DataFrom(ctx).Set(key, value)
+}
+
+// ContextWithData returns a copy of the context carrying the data store
+func ContextWithData(ctx context.Context, data *Data) context.Context {
+	return context.WithValue(ctx, dataKey{}, data)
+}
+
+// DataFrom returns the data store of the scenario the context belongs to.
+// It returns an empty store if the context does not belong to a scenario.
+func DataFrom(ctx context.Context) *Data {
+	if data, ok := ctx.Value(dataKey{}).(*Data); ok {
+		return data
+	}
+
+	return NewData()
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestData(t *testing.T) {
+	t.Run("should set values only once with GetOrSet", func(t *testing.T) {
+		data := NewData()
+
+		actual, loaded := data.GetOrSet("basket", 1)
+		require.Equal(t, 1, actual)
+		require.False(t, loaded)
+
+		actual, loaded = data.GetOrSet("basket", 2)
+		require.Equal(t, 1, actual)
+		require.True(t, loaded)
+	})
+	t.Run("should list, copy and delete values", func(t *testing.T) {
+		data := NewData()
+		data.Set("user", "deniz")
+		data.Set("basket", 1)
+
+		require.Equal(t, []string{"basket", "user"}, data.Keys())
+		values := map[string]any{"other": true}
+		data.CopyTo(values)
+		require.Equal(t, map[string]any{"other": true, "user": "deniz", "basket": 1}, values)
+
+		data.Delete("user")
+		_, ok := data.Get("user")
+		require.False(t, ok)
+	})
+	t.Run("should be safe for concurrent use", func(t *testing.T) {
+		data := NewData()
+		wg := sync.WaitGroup{}
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				key := fmt.Sprintf("key%d", i%5)
+				data.Set(key, i)
+				data.GetOrSet(key, i)
+				data.Get(key)
+				data.Keys()
+			}()
+		}
+		wg.Wait()
+
+		require.Len(t, data.Keys(), 5)
+	})
+}
+
+func TestGetAs(t *testing.T) {
+	t.Run("should return values of the type", func(t *testing.T) {
+		ctx := ContextWithData(context.Background(), NewData())
+		SetAs(ctx, "count", 3)
+
+		count, ok := GetAs[int](ctx, "count")
+		require.True(t, ok)
+		require.Equal(t, 3, count)
+
+		_, ok = GetAs[string](ctx, "count")
+		require.False(t, ok)
+
+		_, ok = GetAs[int](ctx, "missing")
+		require.False(t, ok)
+	})
+}
+
+func TestWorldFrom(t *testing.T) {
+	type world struct{ items int }
+
+	t.Run("should return the world of the type or its zero value", func(t *testing.T) {
+		ctx := ContextWithWorld(context.Background(), &world{items: 2})
+
+		require.Equal(t, 2, WorldFrom[*world](ctx).items)
+		require.Nil(t, WorldFrom[*world](context.Background()))
+		require.Empty(t, WorldFrom[string](ctx))
+	})
+}
@@ -0,0 +1,23 @@
+package models
+
+type (
+	// Diagnostics are the allocations and goroutines of a scenario. They are measured for the whole program, so
+	// they include the scenarios executed at the same time if the run is concurrent.
+	Diagnostics struct {
+		// Allocations is the number of heap objects allocated during the scenario
+		Allocations int64 `json:"allocations"`
+		// AllocatedBytes is the number of bytes allocated during the scenario, including the freed ones
+		AllocatedBytes int64 `json:"allocatedBytes"`
+		// HeapBytes is the change of the bytes of live heap objects, negative if the heap shrank
+		HeapBytes int64 `json:"heapBytes"`
+		// GoroutinesBefore and GoroutinesAfter are the numbers of goroutines before the scenario started and after
+		// it finished
+		GoroutinesBefore int `json:"goroutinesBefore"`
+		GoroutinesAfter  int `json:"goroutinesAfter"`
+	}
+)
+
+// LeakedGoroutines returns the number of goroutines started by the scenario which were still running after it
+func (d *Diagnostics) LeakedGoroutines() int {
+	return max(0, d.GoroutinesAfter-d.GoroutinesBefore)
+}
@@ -0,0 +1,182 @@
+package models
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	normalizations = []struct {
+		pattern     *regexp.Regexp
+		replacement string
+	}{
+		{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<uuid>"},
+		{regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b`), "<hex>"},
+		{regexp.MustCompile(`"[^"]*"`), `"<string>"`},
+		{regexp.MustCompile(`'[^']*'`), `'<string>'`},
+		{regexp.MustCompile(`\d+(\.\d+)*`), "<n>"},
+		{regexp.MustCompile(`\s+`), " "},
+	}
+)
+
+type (
+	// Failure describes why a step failed, so reports can render the compared values, the stack and the location of
+	// the failure instead of the message only
+	Failure struct {
+		Message string `json:"message"`
+		// Expected and Actual are the values compared by a failed assertion, see Comparison
+		Expected string `json:"expected,omitempty"`
+		Actual   string `json:"actual,omitempty"`
+		// Stack is the stack trace of the step function if it panicked, from the panic to the step function
+		Stack string `json:"stack,omitempty"`
+		// Attachments are the attachments of the error, see FailureAttachments
+		Attachments []*Attachment `json:"attachments,omitempty"`
+		// Location is where the step failed: the code which panicked, or the step function otherwise
+		Location *SourceLocation `json:"location,omitempty"`
+		// Suggestions are the step definitions closest to the text of an undefined step, closest first
+		Suggestions []string `json:"suggestions,omitempty"`
+	}
+
+	// SourceLocation is a line of a go file, such as the line of a step function
+	SourceLocation struct {
+		File string `json:"file"`
+		Line int    `json:"line"`
+	}
+
+	// Comparison is implemented by errors of assertions comparing an expected and an actual value, such as the ones
+	// of assert.Equal, whose values are copied to the Failure of the step returning them
+	Comparison interface {
+		error
+		Expected() string
+		Actual() string
+	}
+
+	// FailureAttachments is implemented by errors carrying attachments describing the failure, such as a screenshot
+	// taken when an element was not found, which are copied to the Failure of the step returning them
+	FailureAttachments interface {
+		error
+		Attachments() []*Attachment
+	}
+
+	// FailureGroup is a set of failed scenarios with the same normalized error
+	FailureGroup struct {
+		Error     string
+		Scenarios []*ScenarioResult
+	}
+
+	stepResultKey struct{}
+)
+
+// NewFailure returns the failure of the error with the values of the Comparison and the attachments of the
+// FailureAttachments it wraps
+func NewFailure(err error) *Failure {
+	failure := &Failure{Message: err.Error()}
+	var comparison Comparison
+	if errors.As(err, &comparison) {
+		failure.Expected, failure.Actual = comparison.Expected(), comparison.Actual()
+	}
+	var attachments FailureAttachments
+	if errors.As(err, &attachments) {
+		failure.Attachments = attachments.Attachments()
+	}
+
+	return failure
+}
+
+// Fail sets the failure of the step and its message as the Error
+func (s *StepResult) Fail(failure *Failure) {
+	s.Failure, s.Error = failure, failure.Message
+}
+
+// ContextWithStepResult returns a copy of the context carrying the result of the step, which the executor passes to
+// the AfterStep hooks
+func ContextWithStepResult(ctx context.Context, result *StepResult) context.Context {
+	return context.WithValue(ctx, stepResultKey{}, result)
+}
+
+// StepResultFrom returns the result of the step an AfterStep hook runs after, so the hook can inspect its Failure.
+// It returns nil in other hooks and in steps.
+func StepResultFrom(ctx context.Context) *StepResult {
+	result, _ := ctx.Value(stepResultKey{}).(*StepResult)
+
+	return result
+}
+
+// NormalizeError replaces the variable parts of an error message such as numbers, UUIDs and quoted values with
+// placeholders, so the same failure in different scenarios produces the same message
+func NormalizeError(message string) string {
+	for _, normalization := range normalizations {
+		message = normalization.pattern.ReplaceAllString(message, normalization.replacement)
+	}
+
+	return strings.TrimSpace(message)
+}
+
+// FailedStep returns the first failed or undefined step of the scenario
+func (s *ScenarioResult) FailedStep() *StepResult {
+	for _, step := range s.Steps {
+		if step.Status == StatusFailed || step.Status == StatusUndefined {
+			return step
+		}
+	}
+
+	return nil
+}
+
+// FailureMessage returns the error of the failed step, or of the first failed hook if no step failed
+func (s *ScenarioResult) FailureMessage() string {
+	if step := s.FailedStep(); step != nil {
+		return step.Error
+	}
+	for _, hook := range s.Hooks {
+		if hook.Status == StatusFailed {
+			return hook.Error
+		}
+	}
+
+	return ""
+}
+
+// FailureFingerprint returns a stable identifier of the failure of the scenario built from the normalized error
+// and the pattern of the failing step definition, so external systems can deduplicate failures across runs.
+// It returns an empty string if the scenario did not fail.
+func (s *ScenarioResult) FailureFingerprint() string {
+	if s.Status != StatusFailed && s.Status != StatusUndefined {
+		return ""
+	}
+	definition := ""
+	if step := s.FailedStep(); step != nil {
+		definition = step.Definition
+	}
+
+	sum := sha1.Sum([]byte(NormalizeError(s.FailureMessage()) + "\x00" + definition))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// FailureGroups groups the failed scenarios by their normalized error, largest group first
+func (r *RunResult) FailureGroups() []*FailureGroup {
+	groups := make([]*FailureGroup, 0)
+	byError := make(map[string]*FailureGroup)
+	for _, scenario := range r.FailedScenarios() {
+		normalized := NormalizeError(scenario.FailureMessage())
+		group, ok := byError[normalized]
+		if !ok {
+			group = &FailureGroup{Error: normalized}
+			byError[normalized] = group
+			groups = append(groups, group)
+		}
+		group.Scenarios = append(group.Scenarios, scenario)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return len(groups[i].Scenarios) > len(groups[j].Scenarios)
+	})
+
+	return groups
+}
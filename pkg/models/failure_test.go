@@ -0,0 +1,68 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeError(t *testing.T) {
+	t.Run("should replace variable parts of the message", func(t *testing.T) {
+		normalized := NormalizeError(`dial tcp 127.0.0.1:5432: user "deniz" id 3f2504e0-4f89-11d3-9a0c-0305e82c3301   at 0xc000123`)
+
+		require.Equal(t, `dial tcp <n>:<n>: user "<string>" id <uuid> at <hex>`, normalized)
+	})
+}
+
+func TestRunResult_FailureGroups(t *testing.T) {
+	t.Run("should group failed scenarios by normalized error", func(t *testing.T) {
+		failed := func(name, message string) *ScenarioResult {
+			return &ScenarioResult{
+				Name:   name,
+				Status: StatusFailed,
+				Steps:  []*StepResult{{Status: StatusFailed, Error: message}},
+			}
+		}
+		result := &RunResult{
+			Scenarios: []*ScenarioResult{
+				failed("a", "expected 3 apples"),
+				{Name: "b", Status: StatusPassed},
+				failed("c", "connection refused to port 8080"),
+				failed("d", "connection refused to port 9090"),
+			},
+		}
+
+		groups := result.FailureGroups()
+
+		require.Len(t, groups, 2)
+		require.Equal(t, "connection refused to port <n>", groups[0].Error)
+		require.Len(t, groups[0].Scenarios, 2)
+		require.Equal(t, "expected <n> apples", groups[1].Error)
+	})
+}
+
+func TestScenarioResult_FailureFingerprint(t *testing.T) {
+	failed := func(definition, message string) *ScenarioResult {
+		return &ScenarioResult{
+			Status: StatusFailed,
+			Steps:  []*StepResult{{Status: StatusFailed, Definition: definition, Error: message}},
+		}
+	}
+
+	t.Run("should be equal for the same failure with different values", func(t *testing.T) {
+		first := failed(`^I have (\d+) apples$`, "expected 3 apples")
+		second := failed(`^I have (\d+) apples$`, "expected 5 apples")
+
+		require.NotEmpty(t, first.FailureFingerprint())
+		require.Equal(t, first.FailureFingerprint(), second.FailureFingerprint())
+	})
+	t.Run("should differ for different step definitions", func(t *testing.T) {
+		first := failed(`^I have (\d+) apples$`, "expected 3 apples")
+		second := failed(`^I eat (\d+) apples$`, "expected 3 apples")
+
+		require.NotEqual(t, first.FailureFingerprint(), second.FailureFingerprint())
+	})
+	t.Run("should be empty for passed scenarios", func(t *testing.T) {
+		require.Empty(t, (&ScenarioResult{Status: StatusPassed}).FailureFingerprint())
+	})
+}
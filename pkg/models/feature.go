@@ -4,9 +4,40 @@ import "context"
 
 type (
 	Config struct {
-		BeforeAll  func(ctx context.Context) error
-		AfterAll   func(ctx context.Context) error
-		AfterStep  func(ctx context.Context) error
-		BeforeStep func(ctx context.Context) error
+		BeforeAll      func(ctx context.Context) error
+		AfterAll       func(ctx context.Context) error
+		BeforeScenario func(ctx context.Context) error
+		AfterScenario  func(ctx context.Context) error
+		AfterStep      func(ctx context.Context) error
+		BeforeStep     func(ctx context.Context) error
+		// Hooks are additional hook sets executed after the hooks above
+		Hooks []*Hooks
+		// Providers are functions creating the values of the parameters of step functions which are not
+		// captured from the step text, such as func() *http.Client, see StepExecutor.Provide
+		Providers []any
+		// Concurrency is the number of scenarios of a feature file executed at the same time, 1 if it is not set.
+		// Steps and hooks of scenarios executed at the same time must not share state without synchronization.
+		Concurrency int
+		// ResourceLimits limit the number of scenarios with the tag of a resource class, such as @heavy or @gpu,
+		// executed at the same time. Scenarios waiting for their resource class do not keep others from running.
+		ResourceLimits map[string]int
+		// World creates the world of every scenario before its hooks run, a value holding the state of the scenario
+		// which its hooks and steps read with cacik.World, such as func() any { return &CheckoutWorld{} }
+		World func() any
+		// FailFast skips the scenarios which have not started after a scenario fails
+		FailFast bool
+		// Language is the language whose month names {date} accepts in addition to English, such as "de" for
+		// "3. März 2024", see converter.RegisterMonthNames. Every registered language is accepted if it is empty.
+		Language string
+	}
+
+	Hooks struct {
+		// Tags is a tag expression such as `@db and not @slow`. The hooks only run for scenarios matching it.
+		// Empty Tags matches every scenario.
+		Tags           string
+		BeforeScenario func(ctx context.Context) error
+		AfterScenario  func(ctx context.Context) error
+		BeforeStep     func(ctx context.Context) error
+		AfterStep      func(ctx context.Context) error
 	}
 )
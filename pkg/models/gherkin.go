@@ -0,0 +1,39 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Gherkin returns the scenario as Gherkin text with the values of its Examples row already substituted, so a
+// failing case can be pasted into a feature file or a bug report
+func (s *ScenarioResult) Gherkin() string {
+	builder := &strings.Builder{}
+	builder.WriteString(fmt.Sprintf("Scenario: %s\n", s.Name))
+
+	for _, step := range s.Steps {
+		keyword := step.Keyword
+		if len(keyword) == 0 {
+			keyword = "* "
+		}
+		builder.WriteString(fmt.Sprintf("  %s%s\n", keyword, step.Text))
+
+		if len(step.DataTable) > 0 {
+			table := NewTable(step.DataTable)
+			widths := columnWidths(table)
+			for _, row := range table.Rows {
+				builder.WriteString("   ")
+				writeTableRow(builder, "", row.Cells, widths)
+			}
+		}
+		if step.DocString != nil {
+			builder.WriteString(fmt.Sprintf("    \"\"\"%s\n", step.DocString.MediaType))
+			for _, line := range strings.Split(step.DocString.Content, "\n") {
+				builder.WriteString(fmt.Sprintf("    %s\n", line))
+			}
+			builder.WriteString("    \"\"\"\n")
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScenarioResult_Gherkin(t *testing.T) {
+	t.Run("should write steps with their tables and doc strings", func(t *testing.T) {
+		scenario := &ScenarioResult{
+			Name: "Buy apples",
+			Steps: []*StepResult{
+				{Keyword: "Given ", Text: "the basket", DataTable: [][]string{{"fruit", "count"}, {"apple", "3"}}},
+				{Keyword: "When ", Text: "I send", DocString: &DocString{MediaType: "json", Content: "{\n  \"a\": 1\n}"}},
+				{Keyword: "Then ", Text: "I have 3 apples"},
+			},
+		}
+
+		expected := "Scenario: Buy apples\n" +
+			"  Given the basket\n" +
+			"    | fruit | count |\n" +
+			"    | apple | 3     |\n" +
+			"  When I send\n" +
+			"    \"\"\"json\n" +
+			"    {\n" +
+			"      \"a\": 1\n" +
+			"    }\n" +
+			"    \"\"\"\n" +
+			"  Then I have 3 apples\n"
+
+		require.Equal(t, expected, scenario.Gherkin())
+	})
+}
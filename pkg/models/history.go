@@ -0,0 +1,115 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// HistoryRuns is the number of the newest runs kept in a history directory
+	HistoryRuns = 20
+)
+
+type (
+	// RunSummary is the outcome of a run kept in a history directory, so reports can show how runs change over time
+	RunSummary struct {
+		StartedAt time.Time     `json:"startedAt"`
+		Duration  time.Duration `json:"duration"`
+		Scenarios int           `json:"scenarios"`
+		Passed    int           `json:"passed"`
+		Failed    int           `json:"failed"`
+		Undefined int           `json:"undefined"`
+		Skipped   int           `json:"skipped"`
+	}
+)
+
+// Summary returns the number of scenarios of the run by status
+func (r *RunResult) Summary() *RunSummary {
+	return &RunSummary{
+		StartedAt: r.StartedAt,
+		Duration:  r.Duration,
+		Scenarios: len(r.Scenarios),
+		Passed:    r.Count(StatusPassed),
+		Failed:    r.Count(StatusFailed),
+		Undefined: r.Count(StatusUndefined),
+		Skipped:   r.Count(StatusSkipped),
+	}
+}
+
+// AppendHistory writes the summary of the run to a new file in the history directory, creating the directory if it
+// does not exist. The files of the runs before the newest HistoryRuns runs are removed.
+func AppendHistory(directory string, result *RunResult) error {
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return fmt.Errorf("could not create history directory %s, error=%w", directory, err)
+	}
+	content, err := json.Marshal(result.Summary())
+	if err != nil {
+		return err
+	}
+
+	// runs starting at the same time, such as the shards of a CI job, get files with different random suffixes
+	file, err := os.CreateTemp(directory, result.StartedAt.UTC().Format("20060102-150405")+"-*.json")
+	if err != nil {
+		return fmt.Errorf("could not create history file in %s, error=%w", directory, err)
+	}
+	defer file.Close()
+	if _, err := file.Write(content); err != nil {
+		return fmt.Errorf("could not write history file %s, error=%w", file.Name(), err)
+	}
+
+	paths, err := historyFiles(directory)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths[:max(0, len(paths)-HistoryRuns)] {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("could not remove history file %s, error=%w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// LoadHistory reads the summaries of the newest HistoryRuns runs written to the history directory by AppendHistory,
+// oldest first. A directory which does not exist has no history.
+func LoadHistory(directory string) ([]*RunSummary, error) {
+	paths, err := historyFiles(directory)
+	if err != nil {
+		return nil, err
+	}
+	paths = paths[max(0, len(paths)-HistoryRuns):]
+
+	history := make([]*RunSummary, 0, len(paths))
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read history file %s, error=%w", path, err)
+		}
+		summary := &RunSummary{}
+		if err := json.Unmarshal(content, summary); err != nil {
+			return nil, fmt.Errorf("could not parse history file %s, error=%w", path, err)
+		}
+		history = append(history, summary)
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].StartedAt.Before(history[j].StartedAt)
+	})
+
+	return history, nil
+}
+
+// historyFiles returns the files of the history directory oldest first. The names of the files start with the time
+// their run started, so they are in the order of their names.
+func historyFiles(directory string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(directory, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
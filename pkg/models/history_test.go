@@ -0,0 +1,86 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendHistory(t *testing.T) {
+	t.Run("should load the summaries of the runs oldest first", func(t *testing.T) {
+		directory := filepath.Join(t.TempDir(), "history")
+		startedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+		latest := &RunResult{StartedAt: startedAt.Add(time.Hour), Duration: time.Second, Scenarios: []*ScenarioResult{
+			{Status: StatusPassed}, {Status: StatusFailed}, {Status: StatusSkipped},
+		}}
+		earliest := &RunResult{StartedAt: startedAt, Duration: 2 * time.Second}
+
+		require.Nil(t, AppendHistory(directory, latest))
+		require.Nil(t, AppendHistory(directory, earliest))
+		require.Nil(t, AppendHistory(directory, earliest))
+		history, err := LoadHistory(directory)
+
+		require.Nil(t, err)
+		require.Equal(t, []*RunSummary{earliest.Summary(), earliest.Summary(), {
+			StartedAt: startedAt.Add(time.Hour),
+			Duration:  time.Second,
+			Scenarios: 3,
+			Passed:    1,
+			Failed:    1,
+			Skipped:   1,
+		}}, history)
+	})
+
+	t.Run("should keep the newest runs only", func(t *testing.T) {
+		directory := filepath.Join(t.TempDir(), "history")
+		startedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+		for i := 0; i < HistoryRuns+5; i++ {
+			require.Nil(t, AppendHistory(directory, &RunResult{StartedAt: startedAt.Add(time.Duration(i) * time.Hour)}))
+		}
+		history, err := LoadHistory(directory)
+
+		require.Nil(t, err)
+		require.Len(t, history, HistoryRuns)
+		require.Equal(t, startedAt.Add(5*time.Hour), history[0].StartedAt)
+		paths, err := filepath.Glob(filepath.Join(directory, "*.json"))
+		require.Nil(t, err)
+		require.Len(t, paths, HistoryRuns)
+	})
+
+	t.Run("should load the newest runs only", func(t *testing.T) {
+		directory := t.TempDir()
+		startedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+		for i := 0; i < HistoryRuns+5; i++ {
+			content := fmt.Sprintf(`{"startedAt": %q}`, startedAt.Add(time.Duration(i)*time.Minute).Format(time.RFC3339))
+			name := fmt.Sprintf("20240101-10%02d00-run.json", i)
+			require.Nil(t, os.WriteFile(filepath.Join(directory, name), []byte(content), 0o644))
+		}
+
+		history, err := LoadHistory(directory)
+
+		require.Nil(t, err)
+		require.Len(t, history, HistoryRuns)
+		require.Equal(t, startedAt.Add(5*time.Minute), history[0].StartedAt)
+	})
+
+	t.Run("should not have history if the directory does not exist", func(t *testing.T) {
+		history, err := LoadHistory(filepath.Join(t.TempDir(), "history"))
+
+		require.Nil(t, err)
+		require.Empty(t, history)
+	})
+
+	t.Run("should return error if a summary can not be parsed", func(t *testing.T) {
+		directory := t.TempDir()
+		require.Nil(t, os.WriteFile(filepath.Join(directory, "run.json"), []byte("{"), 0o644))
+
+		_, err := LoadHistory(directory)
+
+		require.ErrorContains(t, err, "could not parse history file")
+	})
+}
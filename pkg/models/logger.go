@@ -0,0 +1,50 @@
+package models
+
+import (
+	"context"
+	"fmt"
+)
+
+type (
+	// Logger collects the messages logged by the hooks and the steps of a scenario.
+	// The messages are stored in ScenarioResult.Logs.
+	Logger struct {
+		entries []string
+	}
+
+	loggerKey struct{}
+)
+
+func NewLogger() *Logger {
+	return &Logger{
+		entries: make([]string, 0),
+	}
+}
+
+func (l *Logger) Log(args ...any) {
+	l.entries = append(l.entries, fmt.Sprint(args...))
+}
+
+func (l *Logger) Logf(format string, args ...any) {
+	l.entries = append(l.entries, fmt.Sprintf(format, args...))
+}
+
+// Entries returns the logged messages in order
+func (l *Logger) Entries() []string {
+	return l.entries
+}
+
+// ContextWithLogger returns a copy of the context carrying the logger
+func ContextWithLogger(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFrom returns the logger of the scenario the context belongs to.
+// It returns a new logger if the context does not belong to a scenario.
+func LoggerFrom(ctx context.Context) *Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*Logger); ok {
+		return logger
+	}
+
+	return NewLogger()
+}
@@ -0,0 +1,259 @@
+package models
+
+import "time"
+
+const (
+	StatusPassed    Status = "passed"
+	StatusFailed    Status = "failed"
+	StatusSkipped   Status = "skipped"
+	StatusUndefined Status = "undefined"
+)
+
+const (
+	// SkipCauseTag is the cause of scenarios tagged with @skip
+	SkipCauseTag SkipCause = "tag"
+	// SkipCausePrecondition is the cause of scenarios whose @requires preconditions are not met
+	SkipCausePrecondition SkipCause = "precondition"
+	// SkipCauseHook is the cause of scenarios skipped by a BeforeScenario hook, and of the steps of scenarios whose
+	// hooks failed
+	SkipCauseHook SkipCause = "hook"
+	// SkipCauseFailFast is the cause of scenarios not executed because a scenario failed before them in a run with
+	// FailFast
+	SkipCauseFailFast SkipCause = "fail-fast"
+	// SkipCausePending is the cause of steps returning ErrPending and of their scenarios
+	SkipCausePending SkipCause = "pending"
+	// SkipCausePreviousStep is the cause of steps following a step which did not pass
+	SkipCausePreviousStep SkipCause = "previous-step"
+)
+
+type (
+	Status string
+
+	// SkipCause tells apart the causes of skipped scenarios and steps in reports, the Reason explains it
+	SkipCause string
+
+	HookResult struct {
+		// Name is the fully qualified name of the hook function
+		Name     string        `json:"name"`
+		Status   Status        `json:"status"`
+		Duration time.Duration `json:"duration"`
+		Error    string        `json:"error,omitempty"`
+		// Reason is set if the hook skipped the scenario
+		Reason string `json:"reason,omitempty"`
+	}
+
+	StepResult struct {
+		// Keyword is the Gherkin keyword of the step including the trailing space, such as "Given "
+		Keyword string `json:"keyword"`
+		Text    string `json:"text"`
+		// Line is the line of the step in the feature file
+		Line int `json:"line,omitempty"`
+		// Definition is the pattern of the step definition matching the step
+		Definition string        `json:"definition,omitempty"`
+		Status     Status        `json:"status"`
+		Duration   time.Duration `json:"duration"`
+		// Slow is set if the step took longer than the slow step threshold of the run
+		Slow bool `json:"slow,omitempty"`
+		// Error is the message of the Failure, kept for reports and tools reading the message only
+		Error string `json:"error,omitempty"`
+		// Failure describes why the step failed or is undefined
+		Failure     *Failure      `json:"failure,omitempty"`
+		Attachments []*Attachment `json:"attachments,omitempty"`
+		// Logs are the messages logged by the step and its step hooks, which are in the Logs of the scenario too
+		Logs      []string   `json:"logs,omitempty"`
+		DataTable [][]string `json:"dataTable,omitempty"`
+		DocString *DocString `json:"docString,omitempty"`
+		// Arguments are the parameters of the text captured by the step definition, so reports can highlight them
+		Arguments []*StepArgument `json:"arguments,omitempty"`
+		// SkipCause and Reason are set if the step was skipped
+		SkipCause SkipCause `json:"skipCause,omitempty"`
+		Reason    string    `json:"reason,omitempty"`
+		// Warnings describe problems of a step which did not fail it, such as using a Then definition for a Given step
+		Warnings []string `json:"warnings,omitempty"`
+	}
+
+	// StepArgument is a parameter of a step text captured by a group of the step definition. Start and End are
+	// the byte offsets of the value in the text.
+	StepArgument struct {
+		Value string `json:"value"`
+		Start int    `json:"start"`
+		End   int    `json:"end"`
+		// Name is the name of a named group, such as count for (?P<count>\d+)
+		Name string `json:"name,omitempty"`
+		// Type is the parameter type of the group, such as int for {int}, and empty for regular expression groups
+		Type string `json:"type,omitempty"`
+	}
+
+	DocString struct {
+		MediaType string `json:"mediaType,omitempty"`
+		Content   string `json:"content"`
+	}
+
+	ScenarioResult struct {
+		Name string `json:"name"`
+		Uri  string `json:"uri"`
+		// Feature is the name of the feature of the scenario
+		Feature string `json:"feature,omitempty"`
+		// Line is the line of the scenario in the feature file, or the line of the Examples row for scenario outlines
+		Line int `json:"line,omitempty"`
+		// ScenarioLine is the line of the scenario or the scenario outline in the feature file
+		ScenarioLine int `json:"scenarioLine,omitempty"`
+		// Examples are the values of the Examples row of scenario outlines in the order of the columns
+		Examples []ExampleValue `json:"examples,omitempty"`
+		// Description is the free text below the scenario name and FeatureDescription the one below the feature name
+		Description        string `json:"description,omitempty"`
+		FeatureDescription string `json:"featureDescription,omitempty"`
+		// Tags are the tags of the scenario including the ones inherited from its feature, rule and examples
+		Tags   []string `json:"tags,omitempty"`
+		Status Status   `json:"status"`
+		// SkipCause and Reason explain why the scenario was skipped
+		SkipCause SkipCause `json:"skipCause,omitempty"`
+		Reason    string    `json:"reason,omitempty"`
+		// Fingerprint identifies the failure of the scenario, see FailureFingerprint
+		Fingerprint string `json:"fingerprint,omitempty"`
+		// StartedAt is when the scenario started, and Worker the number of the worker executing it when scenarios
+		// are executed at the same time, from 0 to the concurrency
+		StartedAt time.Time     `json:"startedAt"`
+		Worker    int           `json:"worker,omitempty"`
+		Duration  time.Duration `json:"duration"`
+		Steps     []*StepResult `json:"steps"`
+		// Hooks contains the results of every hook executed for the scenario in execution order
+		Hooks []*HookResult `json:"hooks,omitempty"`
+		// Diagnostics are set if the run measures the allocations and goroutines of scenarios
+		Diagnostics *Diagnostics `json:"diagnostics,omitempty"`
+		// Logs contains the messages logged with the scenario Logger by its hooks and steps
+		Logs []string `json:"logs,omitempty"`
+		// Attachments contains the attachments made by scenario hooks
+		Attachments []*Attachment `json:"attachments,omitempty"`
+	}
+
+	// ExampleValue is the value of a column of the Examples row a scenario outline was executed with
+	ExampleValue struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	// FeatureResult is the result of the scenarios of a feature file executed in a run. It is passed to reporters
+	// when the scenarios of the feature finish, and RunResult.Features groups the scenarios of a run by feature.
+	FeatureResult struct {
+		Name        string            `json:"name"`
+		Uri         string            `json:"uri"`
+		Description string            `json:"description,omitempty"`
+		Scenarios   []*ScenarioResult `json:"scenarios"`
+	}
+
+	RunResult struct {
+		StartedAt time.Time     `json:"startedAt"`
+		Duration  time.Duration `json:"duration"`
+		// Tags are the user tags the run was filtered with
+		Tags []string `json:"tags,omitempty"`
+		// Meta describes the environment of the run
+		Meta      *RunMeta          `json:"meta,omitempty"`
+		Scenarios []*ScenarioResult `json:"scenarios"`
+		// Hooks contains the results of BeforeAll and AfterAll hooks
+		Hooks []*HookResult `json:"hooks,omitempty"`
+	}
+
+	// RunMeta describes where and how a run was executed, so its result can be reproduced
+	RunMeta struct {
+		GoVersion string `json:"goVersion"`
+		OS        string `json:"os"`
+		Arch      string `json:"arch"`
+		Hostname  string `json:"hostname,omitempty"`
+		// CacikVersion is the version of the cacik module the run was built with, (devel) for local builds
+		CacikVersion string `json:"cacikVersion,omitempty"`
+		// TagExpression is the expression of the user tags the scenarios were filtered with, such as @smoke or @fast
+		TagExpression string `json:"tagExpression,omitempty"`
+		// CI are the variables of the CI environment identifying the build, such as GITHUB_SHA
+		CI map[string]string `json:"ci,omitempty"`
+	}
+)
+
+// Count returns the number of scenarios with the status
+func (r *RunResult) Count(status Status) int {
+	count := 0
+	for _, scenario := range r.Scenarios {
+		if scenario.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Features returns the scenarios of the run grouped by their feature files in the order the features were executed
+func (r *RunResult) Features() []*FeatureResult {
+	features := make([]*FeatureResult, 0)
+	byUri := make(map[string]*FeatureResult)
+	for _, scenario := range r.Scenarios {
+		feature, ok := byUri[scenario.Uri]
+		if !ok {
+			feature = &FeatureResult{
+				Name:        scenario.Feature,
+				Uri:         scenario.Uri,
+				Description: scenario.FeatureDescription,
+				Scenarios:   make([]*ScenarioResult, 0),
+			}
+			byUri[scenario.Uri] = feature
+			features = append(features, feature)
+		}
+		feature.Scenarios = append(feature.Scenarios, scenario)
+	}
+
+	return features
+}
+
+// Status returns the status of the feature: failed if a scenario failed, undefined if a scenario has undefined
+// steps, passed if a scenario passed and skipped otherwise
+func (f *FeatureResult) Status() Status {
+	status := StatusSkipped
+	for _, scenario := range f.Scenarios {
+		switch {
+		case scenario.Status == StatusFailed:
+			return StatusFailed
+		case scenario.Status == StatusUndefined:
+			status = StatusUndefined
+		case scenario.Status == StatusPassed && status == StatusSkipped:
+			status = StatusPassed
+		}
+	}
+
+	return status
+}
+
+// FailedScenarios returns the scenarios which failed or have undefined steps
+func (r *RunResult) FailedScenarios() []*ScenarioResult {
+	failed := make([]*ScenarioResult, 0)
+	for _, scenario := range r.Scenarios {
+		if scenario.Status == StatusFailed || scenario.Status == StatusUndefined {
+			failed = append(failed, scenario)
+		}
+	}
+
+	return failed
+}
+
+// UndefinedSteps returns the texts of all steps without a matching step definition
+func (r *RunResult) UndefinedSteps() []string {
+	texts := make([]string, 0)
+	for _, scenario := range r.Scenarios {
+		for _, step := range scenario.Steps {
+			if step.Status == StatusUndefined {
+				texts = append(texts, step.Text)
+			}
+		}
+	}
+
+	return texts
+}
+
+// Passed reports whether no scenario failed and no BeforeAll or AfterAll hook failed
+func (r *RunResult) Passed() bool {
+	for _, hook := range r.Hooks {
+		if hook.Status == StatusFailed {
+			return false
+		}
+	}
+
+	return len(r.FailedScenarios()) == 0
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// Save writes the result to the file as JSON
+func (r *RunResult) Save(path string) error {
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("could not save run result to %s, error=%w", path, err)
+	}
+
+	return nil
+}
+
+// LoadRunResult reads a result written by RunResult.Save
+func LoadRunResult(path string) (*RunResult, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read run result %s, error=%w", path, err)
+	}
+
+	result := &RunResult{}
+	if err := json.Unmarshal(content, result); err != nil {
+		return nil, fmt.Errorf("could not parse run result %s, error=%w", path, err)
+	}
+
+	return result, nil
+}
+
+// MergeRunResults merges the results of several runs, such as the shards of a CI job, into one result.
+// The merged run starts with the earliest run and ends with the latest one.
+func MergeRunResults(results ...*RunResult) *RunResult {
+	merged := &RunResult{
+		Tags:      make([]string, 0),
+		Scenarios: make([]*ScenarioResult, 0),
+		Hooks:     make([]*HookResult, 0),
+	}
+
+	var finishedAt time.Time
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		if merged.StartedAt.IsZero() || result.StartedAt.Before(merged.StartedAt) {
+			merged.StartedAt = result.StartedAt
+		}
+		if end := result.StartedAt.Add(result.Duration); end.After(finishedAt) {
+			finishedAt = end
+		}
+		for _, tag := range result.Tags {
+			if !slices.Contains(merged.Tags, tag) {
+				merged.Tags = append(merged.Tags, tag)
+			}
+		}
+		// the shards of a CI job share its environment, so the first one describes the merged run
+		if merged.Meta == nil {
+			merged.Meta = result.Meta
+		}
+		merged.Scenarios = append(merged.Scenarios, result.Scenarios...)
+		merged.Hooks = append(merged.Hooks, result.Hooks...)
+	}
+	if !finishedAt.IsZero() {
+		merged.Duration = finishedAt.Sub(merged.StartedAt)
+	}
+
+	return merged
+}
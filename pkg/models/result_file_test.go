@@ -0,0 +1,67 @@
+package models
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunResult_Save(t *testing.T) {
+	t.Run("should load the saved result", func(t *testing.T) {
+		result := &RunResult{
+			StartedAt: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+			Duration:  time.Second,
+			Tags:      []string{"smoke"},
+			Scenarios: []*ScenarioResult{
+				{
+					Name:   "Eat apples",
+					Uri:    "a.feature",
+					Status: StatusFailed,
+					Steps: []*StepResult{
+						{
+							Text:        "I have 3 apples",
+							Status:      StatusFailed,
+							Error:       "no apples",
+							Attachments: []*Attachment{{Name: "log", MediaType: "text/plain", Data: []byte("log")}},
+						},
+					},
+				},
+			},
+		}
+		path := filepath.Join(t.TempDir(), "run.json")
+
+		require.Nil(t, result.Save(path))
+		loaded, err := LoadRunResult(path)
+
+		require.Nil(t, err)
+		require.Equal(t, result, loaded)
+	})
+}
+
+func TestMergeRunResults(t *testing.T) {
+	t.Run("should merge scenarios and cover the time of all runs", func(t *testing.T) {
+		start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+		first := &RunResult{
+			StartedAt: start.Add(time.Second),
+			Duration:  2 * time.Second,
+			Tags:      []string{"smoke"},
+			Scenarios: []*ScenarioResult{{Name: "first", Status: StatusPassed}},
+		}
+		second := &RunResult{
+			StartedAt: start,
+			Duration:  time.Second,
+			Tags:      []string{"smoke", "db"},
+			Scenarios: []*ScenarioResult{{Name: "second", Status: StatusFailed}},
+		}
+
+		merged := MergeRunResults(first, second)
+
+		require.Equal(t, start, merged.StartedAt)
+		require.Equal(t, 3*time.Second, merged.Duration)
+		require.Equal(t, []string{"smoke", "db"}, merged.Tags)
+		require.Len(t, merged.Scenarios, 2)
+		require.Equal(t, 1, merged.Count(StatusFailed))
+	})
+}
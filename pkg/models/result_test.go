@@ -0,0 +1,23 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunResult_Features(t *testing.T) {
+	t.Run("should group the scenarios by their feature files in the order of execution", func(t *testing.T) {
+		login := &ScenarioResult{Name: "Log in", Uri: "login.feature", Feature: "Login", FeatureDescription: "Users"}
+		basket := &ScenarioResult{Name: "Open basket", Uri: "basket.feature", Feature: "Basket"}
+		logout := &ScenarioResult{Name: "Log out", Uri: "login.feature", Feature: "Login", FeatureDescription: "Users"}
+		result := &RunResult{Scenarios: []*ScenarioResult{login, basket, logout}}
+
+		features := result.Features()
+
+		require.Equal(t, []*FeatureResult{
+			{Name: "Login", Uri: "login.feature", Description: "Users", Scenarios: []*ScenarioResult{login, logout}},
+			{Name: "Basket", Uri: "basket.feature", Scenarios: []*ScenarioResult{basket}},
+		}, features)
+	})
+}
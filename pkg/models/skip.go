@@ -0,0 +1,37 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPending is returned from a step, optionally wrapped with the reason, to mark it as pending. The step and the
+// rest of the scenario are skipped instead of failing.
+var ErrPending = errors.New("step is pending")
+
+type (
+	// SkipError is returned from a BeforeScenario hook to skip the scenario instead of failing it
+	SkipError struct {
+		Reason string
+	}
+)
+
+func (s *SkipError) Error() string {
+	return fmt.Sprintf("scenario skipped: %s", s.Reason)
+}
+
+// SkipScenario returns an error which marks the scenario as skipped with the reason when returned from a
+// BeforeScenario hook
+func SkipScenario(reason string) error {
+	return &SkipError{Reason: reason}
+}
+
+// IsSkip reports whether the error requests skipping the scenario and returns the reason
+func IsSkip(err error) (string, bool) {
+	var skipError *SkipError
+	if errors.As(err, &skipError) {
+		return skipError.Reason, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,198 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/converter"
+)
+
+type (
+	// Table is the data table of a step. A step function receives it if its trailing parameter is models.Table.
+	Table struct {
+		Rows []Row
+	}
+
+	Row struct {
+		// Index is the zero based position of the row in the table
+		Index  int
+		Cells  []string
+		header []string
+	}
+)
+
+// NewTable creates a table whose first row is the header
+func NewTable(rows [][]string) Table {
+	table := Table{
+		Rows: make([]Row, 0, len(rows)),
+	}
+	var header []string
+	if len(rows) > 0 {
+		header = rows[0]
+	}
+	for i, cells := range rows {
+		table.Rows = append(table.Rows, Row{
+			Index:  i,
+			Cells:  cells,
+			header: header,
+		})
+	}
+
+	return table
+}
+
+// Header returns the cells of the first row
+func (t Table) Header() []string {
+	if len(t.Rows) == 0 {
+		return nil
+	}
+
+	return t.Rows[0].Cells
+}
+
+// SkipHeader returns the rows after the header
+func (t Table) SkipHeader() []Row {
+	if len(t.Rows) == 0 {
+		return nil
+	}
+
+	return t.Rows[1:]
+}
+
+// Column returns the cells of the column with the header name, excluding the header.
+// It returns nil if there is no such column.
+func (t Table) Column(name string) []string {
+	index := slices.Index(t.Header(), name)
+	if index < 0 {
+		return nil
+	}
+
+	cells := make([]string, 0, len(t.SkipHeader()))
+	for _, row := range t.SkipHeader() {
+		if index < len(row.Cells) {
+			cells = append(cells, row.Cells[index])
+		} else {
+			cells = append(cells, "")
+		}
+	}
+
+	return cells
+}
+
+// Transpose returns a table whose rows are the columns of the table,
+// so the first column of a vertical table becomes the header
+func (t Table) Transpose() Table {
+	columns := 0
+	for _, row := range t.Rows {
+		columns = max(columns, len(row.Cells))
+	}
+
+	rows := make([][]string, columns)
+	for i := range rows {
+		rows[i] = make([]string, len(t.Rows))
+		for j, row := range t.Rows {
+			if i < len(row.Cells) {
+				rows[i][j] = row.Cells[i]
+			}
+		}
+	}
+
+	return NewTable(rows)
+}
+
+// Filter returns a table with the header and the rows after the header for which the predicate returns true
+func (t Table) Filter(predicate func(row Row) bool) Table {
+	if len(t.Rows) == 0 {
+		return NewTable(nil)
+	}
+
+	rows := [][]string{t.Header()}
+	for _, row := range t.SkipHeader() {
+		if predicate(row) {
+			rows = append(rows, row.Cells)
+		}
+	}
+
+	return NewTable(rows)
+}
+
+// MapRows converts every row after the header with the mapper. It stops at the first error.
+func MapRows[T any](table Table, mapper func(row Row) (T, error)) ([]T, error) {
+	values := make([]T, 0, len(table.SkipHeader()))
+	for _, row := range table.SkipHeader() {
+		value, err := mapper(row)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// Get returns the cell in the column with the header name
+func (r Row) Get(column string) (string, error) {
+	index := slices.Index(r.header, column)
+	if index < 0 || index >= len(r.Cells) {
+		return "", fmt.Errorf("row %d does not have column %q", r.Index+1, column)
+	}
+
+	return r.Cells[index], nil
+}
+
+func (r Row) GetInt(column string) (int, error) {
+	value, err := r.Get(column)
+	if err != nil {
+		return 0, err
+	}
+	parsed, err := converter.ParseInt(value, 0)
+	if err != nil {
+		return 0, r.conversionError(column, value, err)
+	}
+
+	return int(parsed), nil
+}
+
+func (r Row) GetFloat(column string) (float64, error) {
+	value, err := r.Get(column)
+	if err != nil {
+		return 0, err
+	}
+	parsed, err := converter.ParseFloat(value, 64)
+	if err != nil {
+		return 0, r.conversionError(column, value, err)
+	}
+
+	return parsed, nil
+}
+
+func (r Row) GetBool(column string) (bool, error) {
+	value, err := r.Get(column)
+	if err != nil {
+		return false, err
+	}
+	parsed, err := converter.ParseBool(value)
+	if err != nil {
+		return false, r.conversionError(column, value, err)
+	}
+
+	return parsed, nil
+}
+
+func (r Row) GetTime(column string) (time.Time, error) {
+	value, err := r.Get(column)
+	if err != nil {
+		return time.Time{}, err
+	}
+	parsed, err := converter.ParseTime(value)
+	if err != nil {
+		return time.Time{}, r.conversionError(column, value, err)
+	}
+
+	return parsed, nil
+}
+
+func (r Row) conversionError(column, value string, err error) error {
+	return fmt.Errorf("row %d column %q: could not convert %q, error=%w", r.Index+1, column, value, err)
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DiffTables compares the tables row by row. It reports whether they are equal and returns a readable diff
+// where rows only in expected are prefixed with "-" and rows only in actual with "+".
+func DiffTables(expected, actual Table) (string, bool) {
+	widths := columnWidths(expected, actual)
+	builder := &strings.Builder{}
+	equal := true
+
+	for i := 0; i < max(len(expected.Rows), len(actual.Rows)); i++ {
+		switch {
+		case i >= len(actual.Rows):
+			equal = false
+			writeTableRow(builder, "-", expected.Rows[i].Cells, widths)
+		case i >= len(expected.Rows):
+			equal = false
+			writeTableRow(builder, "+", actual.Rows[i].Cells, widths)
+		case slices.Equal(expected.Rows[i].Cells, actual.Rows[i].Cells):
+			writeTableRow(builder, " ", expected.Rows[i].Cells, widths)
+		default:
+			equal = false
+			writeTableRow(builder, "-", expected.Rows[i].Cells, widths)
+			writeTableRow(builder, "+", actual.Rows[i].Cells, widths)
+		}
+	}
+
+	return builder.String(), equal
+}
+
+func columnWidths(tables ...Table) []int {
+	widths := make([]int, 0)
+	for _, table := range tables {
+		for _, row := range table.Rows {
+			for i, cell := range row.Cells {
+				if i >= len(widths) {
+					widths = append(widths, 0)
+				}
+				widths[i] = max(widths[i], len([]rune(cell)))
+			}
+		}
+	}
+
+	return widths
+}
+
+func writeTableRow(builder *strings.Builder, prefix string, cells []string, widths []int) {
+	builder.WriteString(prefix)
+	builder.WriteString(" |")
+	for i, cell := range cells {
+		builder.WriteString(fmt.Sprintf(" %-*s |", widths[i], cell))
+	}
+	builder.WriteString("\n")
+}
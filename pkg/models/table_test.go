@@ -0,0 +1,92 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRow_Get(t *testing.T) {
+	table := NewTable([][]string{
+		{"name", "count", "price", "active", "date"},
+		{"apple", "3", "2.5", "true", "2024-02-29"},
+		{"pear", "many", "x", "maybe", "tomorrow"},
+	})
+
+	t.Run("should convert cells to typed values", func(t *testing.T) {
+		row := table.SkipHeader()[0]
+
+		count, err := row.GetInt("count")
+		require.Nil(t, err)
+		require.Equal(t, 3, count)
+
+		price, err := row.GetFloat("price")
+		require.Nil(t, err)
+		require.Equal(t, 2.5, price)
+
+		active, err := row.GetBool("active")
+		require.Nil(t, err)
+		require.True(t, active)
+
+		date, err := row.GetTime("date")
+		require.Nil(t, err)
+		require.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), date)
+	})
+
+	t.Run("should attribute conversion errors to row and column", func(t *testing.T) {
+		row := table.SkipHeader()[1]
+
+		_, err := row.GetInt("count")
+		require.ErrorContains(t, err, `row 3 column "count": could not convert "many"`)
+
+		_, err = row.GetBool("active")
+		require.ErrorContains(t, err, `row 3 column "active"`)
+
+		_, err = row.GetFloat("missing")
+		require.ErrorContains(t, err, `row 3 does not have column "missing"`)
+	})
+}
+
+func TestTable_Transformations(t *testing.T) {
+	table := NewTable([][]string{
+		{"name", "price"},
+		{"apple", "3"},
+		{"pear", "4"},
+		{"plum", "1"},
+	})
+
+	t.Run("should return cells of a column", func(t *testing.T) {
+		require.Equal(t, []string{"3", "4", "1"}, table.Column("price"))
+		require.Nil(t, table.Column("missing"))
+	})
+
+	t.Run("should transpose the table", func(t *testing.T) {
+		transposed := table.Transpose()
+
+		require.Equal(t, []string{"name", "apple", "pear", "plum"}, transposed.Header())
+		require.Equal(t, []string{"price", "3", "4", "1"}, transposed.SkipHeader()[0].Cells)
+	})
+
+	t.Run("should filter rows and keep the header", func(t *testing.T) {
+		filtered := table.Filter(func(row Row) bool {
+			price, _ := row.GetInt("price")
+			return price > 2
+		})
+
+		require.Equal(t, []string{"name", "price"}, filtered.Header())
+		require.Equal(t, []string{"apple", "pear"}, filtered.Column("name"))
+		price, err := filtered.SkipHeader()[1].GetInt("price")
+		require.Nil(t, err)
+		require.Equal(t, 4, price)
+	})
+
+	t.Run("should map rows", func(t *testing.T) {
+		prices, err := MapRows(table, func(row Row) (int, error) {
+			return row.GetInt("price")
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, []int{3, 4, 1}, prices)
+	})
+}
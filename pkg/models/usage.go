@@ -0,0 +1,94 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+type (
+	// StepUsage lists the step texts of a run matched by a step definition
+	StepUsage struct {
+		Definition string `json:"definition"`
+		// Site is the file and line the step definition is registered at
+		Site  string   `json:"site,omitempty"`
+		Texts []string `json:"texts"`
+	}
+
+	// StepTiming is the time spent in the steps matched by a step definition
+	StepTiming struct {
+		Definition string        `json:"definition"`
+		Count      int           `json:"count"`
+		Total      time.Duration `json:"total"`
+	}
+)
+
+// Unused reports whether no step of the run matched the step definition
+func (u *StepUsage) Unused() bool {
+	return len(u.Texts) == 0
+}
+
+// StepUsage returns the usage of every step definition in the order of definitions. The distinct texts of the
+// steps matched by a definition are sorted. Steps of scenarios which were not executed, for example because
+// they were filtered out by tags, are not counted.
+func (r *RunResult) StepUsage(definitions []string) []*StepUsage {
+	texts := make(map[string]map[string]bool)
+	for _, scenario := range r.Scenarios {
+		for _, step := range scenario.Steps {
+			if len(step.Definition) == 0 {
+				continue
+			}
+			if _, ok := texts[step.Definition]; !ok {
+				texts[step.Definition] = make(map[string]bool)
+			}
+			texts[step.Definition][step.Text] = true
+		}
+	}
+
+	usages := make([]*StepUsage, 0, len(definitions))
+	for _, definition := range definitions {
+		usage := &StepUsage{Definition: definition, Texts: make([]string, 0, len(texts[definition]))}
+		for text := range texts[definition] {
+			usage.Texts = append(usage.Texts, text)
+		}
+		sort.Strings(usage.Texts)
+		usages = append(usages, usage)
+	}
+
+	return usages
+}
+
+// Mean returns the average duration of the steps
+func (t *StepTiming) Mean() time.Duration {
+	if t.Count == 0 {
+		return 0
+	}
+
+	return t.Total / time.Duration(t.Count)
+}
+
+// StepTimings returns the time spent in the steps of every step definition of the run, longest total first. Steps
+// without a definition, such as undefined steps, are not counted.
+func (r *RunResult) StepTimings() []*StepTiming {
+	timings := make([]*StepTiming, 0)
+	byDefinition := make(map[string]*StepTiming)
+	for _, scenario := range r.Scenarios {
+		for _, step := range scenario.Steps {
+			if len(step.Definition) == 0 {
+				continue
+			}
+			timing, ok := byDefinition[step.Definition]
+			if !ok {
+				timing = &StepTiming{Definition: step.Definition}
+				byDefinition[step.Definition] = timing
+				timings = append(timings, timing)
+			}
+			timing.Count++
+			timing.Total += step.Duration
+		}
+	}
+	sort.SliceStable(timings, func(i, j int) bool {
+		return timings[i].Total > timings[j].Total
+	})
+
+	return timings
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunResult_StepUsage(t *testing.T) {
+	t.Run("should list the distinct texts matched by each definition", func(t *testing.T) {
+		result := &RunResult{Scenarios: []*ScenarioResult{
+			{Steps: []*StepResult{
+				{Text: "I have 3 apples", Definition: `^I have (\d+) apples$`},
+				{Text: "I eat it", Status: StatusUndefined},
+			}},
+			{Steps: []*StepResult{
+				{Text: "I have 1 apples", Definition: `^I have (\d+) apples$`},
+				{Text: "I have 3 apples", Definition: `^I have (\d+) apples$`},
+			}},
+		}}
+
+		usages := result.StepUsage([]string{`^I have (\d+) apples$`, `^I sell apples$`})
+
+		require.Equal(t, []*StepUsage{
+			{Definition: `^I have (\d+) apples$`, Texts: []string{"I have 1 apples", "I have 3 apples"}},
+			{Definition: `^I sell apples$`, Texts: []string{}},
+		}, usages)
+		require.False(t, usages[0].Unused())
+		require.True(t, usages[1].Unused())
+	})
+}
+
+func TestRunResult_StepTimings(t *testing.T) {
+	t.Run("should add up the durations of the steps of every definition", func(t *testing.T) {
+		result := &RunResult{Scenarios: []*ScenarioResult{
+			{Steps: []*StepResult{
+				{Text: "I have 3 apples", Definition: `^I have (\d+) apples$`, Duration: time.Second},
+				{Text: "I eat it", Status: StatusUndefined, Duration: time.Minute},
+				{Text: "I sell apples", Definition: `^I sell apples$`, Duration: 5 * time.Second},
+			}},
+			{Steps: []*StepResult{
+				{Text: "I have 1 apples", Definition: `^I have (\d+) apples$`, Duration: 2 * time.Second},
+			}},
+		}}
+
+		timings := result.StepTimings()
+
+		require.Equal(t, []*StepTiming{
+			{Definition: `^I sell apples$`, Count: 1, Total: 5 * time.Second},
+			{Definition: `^I have (\d+) apples$`, Count: 2, Total: 3 * time.Second},
+		}, timings)
+		require.Equal(t, 1500*time.Millisecond, timings[1].Mean())
+	})
+}
@@ -0,0 +1,20 @@
+package models
+
+import "context"
+
+type (
+	worldKey struct{}
+)
+
+// ContextWithWorld returns a copy of the context carrying the world of the scenario
+func ContextWithWorld(ctx context.Context, world any) context.Context {
+	return context.WithValue(ctx, worldKey{}, world)
+}
+
+// WorldFrom returns the world created by the World factory of the config for the scenario the context belongs to.
+// It returns the zero value of T if the scenario has no world or its world is not a T.
+func WorldFrom[T any](ctx context.Context) T {
+	world, _ := ctx.Value(worldKey{}).(T)
+
+	return world
+}
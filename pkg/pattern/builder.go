@@ -0,0 +1,109 @@
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type (
+	// Builder composes a step definition from literal texts and parameter types. Literal texts are escaped, so
+	// the built definition matches them exactly. Parameter types are written as {type} placeholders like in a
+	// written definition, so they are resolved and converted when the step is registered.
+	Builder struct {
+		parts []string
+		err   error
+	}
+)
+
+func NewBuilder() *Builder {
+	return &Builder{
+		parts: make([]string, 0),
+	}
+}
+
+// Literal adds a text which must appear as it is
+func (b *Builder) Literal(text string) *Builder {
+	b.parts = append(b.parts, regexp.QuoteMeta(text))
+
+	return b
+}
+
+// Optional adds a text which may be missing, such as the s of apples
+func (b *Builder) Optional(text string) *Builder {
+	b.parts = append(b.parts, "(?:"+regexp.QuoteMeta(text)+")?")
+
+	return b
+}
+
+// Alternatives adds one of the texts without capturing it
+func (b *Builder) Alternatives(texts ...string) *Builder {
+	quoted := make([]string, 0, len(texts))
+	for _, text := range texts {
+		quoted = append(quoted, regexp.QuoteMeta(text))
+	}
+	b.parts = append(b.parts, "(?:"+strings.Join(quoted, "|")+")")
+
+	return b
+}
+
+// Parameter adds the parameter type with the name, capturing its value as an argument of the step function. The
+// name is a built-in type or a custom type registered with the executor before the step.
+func (b *Builder) Parameter(name string) *Builder {
+	if _, ok := ParameterRegex(name); !ok && !typeNamePattern.MatchString(name) && b.err == nil {
+		b.err = fmt.Errorf("invalid parameter type name %q", name)
+	}
+	b.parts = append(b.parts, "{"+name+"}")
+
+	return b
+}
+
+func (b *Builder) Int() *Builder {
+	return b.Parameter("int")
+}
+
+func (b *Builder) Float() *Builder {
+	return b.Parameter("float")
+}
+
+func (b *Builder) Word() *Builder {
+	return b.Parameter("word")
+}
+
+// String adds a double-quoted text capturing the text between the quotes
+func (b *Builder) String() *Builder {
+	return b.Parameter("string")
+}
+
+func (b *Builder) Duration() *Builder {
+	return b.Parameter("duration")
+}
+
+// Date adds a date such as 2024-03-02 or a written date with a month name registered to the converter
+func (b *Builder) Date() *Builder {
+	return b.Parameter("date")
+}
+
+// Any adds a text of any length
+func (b *Builder) Any() *Builder {
+	return b.Parameter("")
+}
+
+// Build returns the step definition anchored to the whole step text
+func (b *Builder) Build() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+
+	return "^" + strings.Join(b.parts, "") + "$", nil
+}
+
+// MustBuild works like Build and panics if an invalid parameter type name is used
+func (b *Builder) MustBuild() string {
+	definition, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+
+	return definition
+}
@@ -0,0 +1,179 @@
+// Package pattern converts the {type} parameters of step definitions to regular expressions and builds step
+// definitions programmatically.
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/denizgursoy/cacik/pkg/converter"
+)
+
+const captureNamePrefix = "cacik_"
+
+type (
+	// Capture describes a capture group of a step definition
+	Capture struct {
+		// Type is the name of the parameter type of the group, such as int, or empty for {}
+		Type string
+		// Regexp is true if the group is written as a regular expression instead of a parameter type
+		Regexp bool
+		// Name is the name of a group written as a named regular expression group, such as count for
+		// (?P<count>\d+)
+		Name string
+	}
+
+	// Types maps the names of custom parameter types, such as money, to their regular expressions
+	Types map[string]string
+)
+
+var (
+	// builtInTypes are the regular expressions of the parameter types with exactly one capture group each
+	builtInTypes = map[string]string{
+		"word":     `(\S+)`,
+		"string":   `"([^"]*)"`,
+		"duration": `(` + converter.DurationPattern + `)`,
+		"uuid":     `(` + converter.UUIDPattern + `)`,
+		"ip":       `(` + converter.IPPattern + `)`,
+		"semver":   `(` + converter.VersionPattern + `)`,
+		// JSON is quoted with single quotes as it contains double quotes, such as '{"id": 1}'
+		"json": `'([^']*)'`,
+		"":     `(.*)`,
+	}
+
+	parameterPattern = regexp.MustCompile(`\{(\w*)\}`)
+	typeNamePattern  = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+)
+
+// ParameterRegex returns the regular expression of the parameter type with the name
+func ParameterRegex(name string) (string, bool) {
+	switch name {
+	case "date":
+		// the date pattern contains the month names registered to the converter
+		return `(` + converter.DatePattern() + `)`, true
+	case "int":
+		// the number patterns contain the separators of the number format of the converter
+		return `(` + converter.IntegerPattern() + `)`, true
+	case "float":
+		return `(` + converter.FloatPattern() + `)`, true
+	case "number":
+		return `(` + converter.NumberPattern() + `)`, true
+	case "percent":
+		return `(` + converter.PercentPattern() + `)`, true
+	case "ordinal":
+		return `(` + converter.OrdinalPattern() + `)`, true
+	}
+	regex, ok := builtInTypes[name]
+
+	return regex, ok
+}
+
+// ValidateType checks that a custom parameter type with the name and the regular expression can be added to Types.
+// The name must start with a letter and must not be a built-in type. The regular expression must not have capture
+// groups, as the whole match is the captured argument; groups can be written as (?:...).
+func ValidateType(name, regex string) error {
+	if !typeNamePattern.MatchString(name) {
+		return fmt.Errorf("parameter type name %q must start with a letter and contain only letters, digits and _", name)
+	}
+	if _, ok := ParameterRegex(name); ok {
+		return fmt.Errorf("parameter type {%s} is built in", name)
+	}
+	compiled, err := regexp.Compile(regex)
+	if err != nil {
+		return fmt.Errorf("regular expression of parameter type {%s} is not valid, error=%w", name, err)
+	}
+	if compiled.NumSubexp() > 0 {
+		return fmt.Errorf("regular expression of parameter type {%s} can not have capture groups, use (?:...)", name)
+	}
+
+	return nil
+}
+
+// Transform replaces the {type} parameters of the step definition, such as {int} in `^I have {int} apples$`, with
+// the regular expressions of their types. Unknown parameters are left as they are.
+func Transform(definition string) string {
+	return TransformWithTypes(definition, nil)
+}
+
+// TransformWithTypes works like Transform and also replaces the parameters of the custom types
+func TransformWithTypes(definition string, types Types) string {
+	return parameterPattern.ReplaceAllStringFunc(definition, func(parameter string) string {
+		if regex, ok := types.regex(parameter[1 : len(parameter)-1]); ok {
+			return regex
+		}
+
+		return parameter
+	})
+}
+
+// CustomTypeNames returns the names of the parameters of the step definition which are not built-in types, such as
+// money for {money}, in the order they are first used
+func CustomTypeNames(definition string) []string {
+	names := make([]string, 0)
+	for _, match := range parameterPattern.FindAllStringSubmatch(definition, -1) {
+		name := match[1]
+		if _, ok := ParameterRegex(name); ok || !typeNamePattern.MatchString(name) || slices.Contains(names, name) {
+			continue
+		}
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// AnyTextTypes returns the custom parameter types of the step definition matching any text. Definitions can be
+// checked with them when the parameter types are registered at runtime and are not known.
+func AnyTextTypes(definition string) Types {
+	types := make(Types)
+	for _, name := range CustomTypeNames(definition) {
+		types[name] = ".*"
+	}
+
+	return types
+}
+
+// Captures returns the capture groups of the step definition in the order of the arguments they are passed as
+func Captures(definition string) ([]Capture, error) {
+	return CapturesWithTypes(definition, nil)
+}
+
+// CapturesWithTypes works like Captures and also returns the groups of the parameters of the custom types
+func CapturesWithTypes(definition string, types Types) ([]Capture, error) {
+	named := parameterPattern.ReplaceAllStringFunc(definition, func(parameter string) string {
+		name := parameter[1 : len(parameter)-1]
+		if regex, ok := types.regex(name); ok {
+			return strings.Replace(regex, "(", "(?P<"+captureNamePrefix+name+">", 1)
+		}
+
+		return parameter
+	})
+	compiled, err := regexp.Compile(named)
+	if err != nil {
+		return nil, err
+	}
+
+	captures := make([]Capture, 0, compiled.NumSubexp())
+	for _, name := range compiled.SubexpNames()[1:] {
+		if strings.HasPrefix(name, captureNamePrefix) {
+			captures = append(captures, Capture{Type: strings.TrimPrefix(name, captureNamePrefix)})
+		} else {
+			captures = append(captures, Capture{Regexp: true, Name: name})
+		}
+	}
+
+	return captures, nil
+}
+
+// regex returns the regular expression of the built-in or custom parameter type with the name
+func (t Types) regex(name string) (string, bool) {
+	if regex, ok := ParameterRegex(name); ok {
+		return regex, true
+	}
+	if regex, ok := t[name]; ok {
+		return "(" + regex + ")", true
+	}
+
+	return "", false
+}
@@ -0,0 +1,145 @@
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/denizgursoy/cacik/pkg/converter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransform(t *testing.T) {
+	t.Run("should replace parameter types with their regular expressions", func(t *testing.T) {
+		definition := Transform(`^I have {int} apples named {string} for {}$`)
+
+		require.Equal(t, `^I have (-?(?:\d{1,3}(?:,\d{3})+|\d+)) apples named "([^"]*)" for (.*)$`, definition)
+		require.Equal(t, []string{"I have -3 apples named \"red\" for me", "-3", "red", "me"},
+			regexp.MustCompile(definition).FindStringSubmatch(`I have -3 apples named "red" for me`))
+	})
+	t.Run("should match the numbers of the number format of the converter", func(t *testing.T) {
+		defer func(numbers converter.NumberFormat) { converter.Numbers = numbers }(converter.Numbers)
+		converter.Numbers = converter.EuropeanNumbers
+
+		integer := regexp.MustCompile(Transform(`^I have {int} apples$`))
+		float := regexp.MustCompile(Transform(`^I pay {float} euros$`))
+
+		require.Equal(t, "1.000", integer.FindStringSubmatch("I have 1.000 apples")[1])
+		require.False(t, integer.MatchString("I have 1,5 apples"))
+		for _, value := range []string{"1.234,56", "-2,5", ",5", "3"} {
+			match := float.FindStringSubmatch(fmt.Sprintf("I pay %s euros", value))
+
+			require.Equal(t, value, match[1], value)
+		}
+	})
+	t.Run("should leave definitions written as regular expressions as they are", func(t *testing.T) {
+		definition := `^I have (\d+) apples in (\d{2}) baskets$`
+
+		require.Equal(t, definition, Transform(definition))
+	})
+	t.Run("should match written dates with {date}", func(t *testing.T) {
+		definition := regexp.MustCompile(Transform(`^the invoice is due on {date}$`))
+
+		require.Equal(t, "2 March 2024", definition.FindStringSubmatch("the invoice is due on 2 March 2024")[1])
+		require.Equal(t, "2024-03-02", definition.FindStringSubmatch("the invoice is due on 2024-03-02")[1])
+	})
+	t.Run("should match identifiers, addresses, versions and JSON", func(t *testing.T) {
+		definition := regexp.MustCompile(Transform(`^order {uuid} from {ip} on version {semver} is {json}$`))
+		text := `order 123e4567-e89b-12d3-a456-426614174000 from 2001:db8::1 on version v2.0.0-rc.1 is '{"id": 1}'`
+
+		require.Equal(t, []string{"123e4567-e89b-12d3-a456-426614174000", "2001:db8::1", "v2.0.0-rc.1", `{"id": 1}`},
+			definition.FindStringSubmatch(text)[1:])
+	})
+	t.Run("should not take the period ending a sentence into addresses and versions", func(t *testing.T) {
+		require.Equal(t, "192.0.2.1", regexp.MustCompile(Transform(`the server is {ip}`)).
+			FindStringSubmatch("the server is 192.0.2.1.")[1])
+		require.Equal(t, "1.4.2-beta", regexp.MustCompile(Transform(`the version is {semver}`)).
+			FindStringSubmatch("the version is 1.4.2-beta.")[1])
+	})
+	t.Run("should keep unknown parameters and regular expressions", func(t *testing.T) {
+		require.Equal(t, `^I have {money} (\d{2}) apples$`, Transform(`^I have {money} (\d{2}) apples$`))
+	})
+}
+
+func TestCaptures(t *testing.T) {
+	t.Run("should return the parameter type of every capture group", func(t *testing.T) {
+		captures, err := Captures(`^I have {int} (red|green) apples named {string} due on {date} for {}$`)
+
+		require.NoError(t, err)
+		require.Equal(t, []Capture{
+			{Type: "int"},
+			{Regexp: true},
+			{Type: "string"},
+			{Type: "date"},
+			{Type: ""},
+		}, captures)
+	})
+	t.Run("should return the names of named capture groups", func(t *testing.T) {
+		captures, err := Captures(`^I move (?P<count>\d+) boxes to (?P<room>\w+) on {date}$`)
+
+		require.NoError(t, err)
+		require.Equal(t, []Capture{
+			{Regexp: true, Name: "count"},
+			{Regexp: true, Name: "room"},
+			{Type: "date"},
+		}, captures)
+	})
+	t.Run("should return an error for an invalid regular expression", func(t *testing.T) {
+		_, err := Captures(`^I have {int} (apples$`)
+
+		require.Error(t, err)
+	})
+}
+
+func TestTransformWithTypes(t *testing.T) {
+	t.Run("should replace custom parameter types with a capture group of their regular expression", func(t *testing.T) {
+		types := Types{"money": `\d+ (?:EUR|USD)`}
+
+		require.Equal(t, `^I pay (\d+ (?:EUR|USD)) for (-?(?:\d{1,3}(?:,\d{3})+|\d+)) apples$`,
+			TransformWithTypes(`^I pay {money} for {int} apples$`, types))
+
+		captures, err := CapturesWithTypes(`^I pay {money} for {int} apples$`, types)
+
+		require.NoError(t, err)
+		require.Equal(t, []Capture{{Type: "money"}, {Type: "int"}}, captures)
+	})
+	t.Run("should return the custom parameter types of a definition", func(t *testing.T) {
+		definition := `^I pay {money} to {account} for {int} {money}s{2}$`
+
+		require.Equal(t, []string{"money", "account"}, CustomTypeNames(definition))
+		require.Equal(t, Types{"money": ".*", "account": ".*"}, AnyTextTypes(definition))
+		require.Empty(t, CustomTypeNames(`^I have {int} apples{}$`))
+	})
+	t.Run("should reject names and regular expressions which can not be parameter types", func(t *testing.T) {
+		require.NoError(t, ValidateType("money", `\d+ (?:EUR|USD)`))
+		require.Error(t, ValidateType("2", `\d+`))
+		require.Error(t, ValidateType("float", `\d+`))
+		require.Error(t, ValidateType("money", `(\d+)`))
+		require.Error(t, ValidateType("money", `(`))
+	})
+}
+
+func TestBuilder(t *testing.T) {
+	t.Run("should build the same definition as written", func(t *testing.T) {
+		definition := NewBuilder().Literal("I have ").Int().Literal(" apple").Optional("s").MustBuild()
+
+		require.Equal(t, `^I have {int} apple(?:s)?$`, definition)
+	})
+	t.Run("should escape literals", func(t *testing.T) {
+		definition := NewBuilder().Alternatives("I", "we").Literal(" pay $5 (cash) for {x} ").Word().MustBuild()
+
+		require.Equal(t, `^(?:I|we) pay \$5 \(cash\) for \{x\} {word}$`, definition)
+		require.True(t, regexp.MustCompile(Transform(definition)).MatchString("we pay $5 (cash) for {x} apples"))
+	})
+	t.Run("should write custom parameter types", func(t *testing.T) {
+		definition := NewBuilder().Literal("I pay ").Parameter("money").MustBuild()
+
+		require.Equal(t, `^I pay {money}$`, definition)
+		require.Equal(t, []string{"money"}, CustomTypeNames(definition))
+	})
+	t.Run("should return error for invalid parameter type names", func(t *testing.T) {
+		_, err := NewBuilder().Parameter("2 money").Build()
+
+		require.EqualError(t, err, `invalid parameter type name "2 money"`)
+	})
+}
@@ -0,0 +1,39 @@
+// Package patterntest helps unit testing step definitions against example step texts without executing
+// scenarios.
+package patterntest
+
+import (
+	"fmt"
+
+	"github.com/denizgursoy/cacik/pkg/executor"
+)
+
+// MatchStep returns the groups the step definition captures from the step text. It returns an error if the
+// definition is invalid or does not match the text.
+func MatchStep(definition, text string) ([]string, error) {
+	step, err := executor.NewStepDefinition(definition, func() {})
+	if err != nil {
+		return nil, err
+	}
+	arguments, ok := step.Match(text)
+	if !ok {
+		return nil, fmt.Errorf("step %s does not match %q", definition, text)
+	}
+
+	return arguments, nil
+}
+
+// MatchStepFunction returns the arguments the step function would be called with for the step text, converted
+// to the types of its parameters the same way the executor converts them
+func MatchStepFunction(definition string, function any, text string) ([]any, error) {
+	step, err := executor.NewStepDefinition(definition, function)
+	if err != nil {
+		return nil, err
+	}
+	arguments, ok := step.Match(text)
+	if !ok {
+		return nil, fmt.Errorf("step %s does not match %q", definition, text)
+	}
+
+	return step.ConvertArguments(arguments)
+}
@@ -0,0 +1,40 @@
+package patterntest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchStep(t *testing.T) {
+	t.Run("should return the captured groups", func(t *testing.T) {
+		arguments, err := MatchStep(`^I have {int} apples named {string}$`, `I have 3 apples named "red"`)
+
+		require.Nil(t, err)
+		require.Equal(t, []string{"3", "red"}, arguments)
+	})
+	t.Run("should return error if the definition does not match", func(t *testing.T) {
+		_, err := MatchStep(`^I have {int} apples$`, "I have some apples")
+
+		require.EqualError(t, err, `step ^I have {int} apples$ does not match "I have some apples"`)
+	})
+}
+
+func TestMatchStepFunction(t *testing.T) {
+	t.Run("should return the converted arguments", func(t *testing.T) {
+		function := func(ctx context.Context, count int, wait time.Duration) error { return nil }
+
+		arguments, err := MatchStepFunction(`^I have {int} apples for {duration}$`, function, "I have 3 apples for 2h")
+
+		require.Nil(t, err)
+		require.Equal(t, []any{3, 2 * time.Hour}, arguments)
+	})
+	t.Run("should return error if an argument can not be converted", func(t *testing.T) {
+		_, err := MatchStepFunction(`^I have (\w+) apples$`, func(count int) {}, "I have some apples")
+
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "could not convert argument 1 of step ^I have (\\w+) apples$")
+	})
+}
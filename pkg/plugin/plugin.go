@@ -0,0 +1,39 @@
+// Package plugin loads step libraries at runtime, so libraries shared by several test modules can be versioned and
+// distributed on their own. A library is a Go plugin built with go build -buildmode=plugin, or a program of any
+// language speaking the step protocol of Process on its standard input and output.
+package plugin
+
+import (
+	"fmt"
+	goplugin "plugin"
+)
+
+const (
+	// StepsSymbol is the function a Go plugin exports to return its steps, func CacikSteps() map[string]any
+	StepsSymbol = "CacikSteps"
+)
+
+type (
+	// Steps are the step functions of a library by their step definitions, as registered with
+	// runner.RegisterStep
+	Steps map[string]any
+)
+
+// Open loads the Go plugin at path and returns the steps returned by its CacikSteps function. The plugin must be
+// built with the same Go version and versions of the shared modules, such as cacik, as the program loading it.
+func Open(path string) (Steps, error) {
+	library, err := goplugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open plugin %s, error=%w", path, err)
+	}
+	symbol, err := library.Lookup(StepsSymbol)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s does not export %s, error=%w", path, StepsSymbol, err)
+	}
+	steps, ok := symbol.(func() map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s of plugin %s must be a func() map[string]any, got %T", StepsSymbol, path, symbol)
+	}
+
+	return steps(), nil
+}
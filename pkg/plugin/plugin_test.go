@@ -0,0 +1,124 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+// libraryVariable makes the test binary serve the steps of TestStepLibrary instead of running the tests
+const libraryVariable = "CACIK_TEST_STEP_LIBRARY"
+
+func TestStepLibrary(t *testing.T) {
+	if os.Getenv(libraryVariable) != "1" {
+		t.Skip("only runs as the step library of the tests")
+	}
+
+	err := Serve(os.Stdin, os.Stdout, map[string]func(ctx context.Context, args models.Args) error{
+		`^I have {int} apples$`: func(ctx context.Context, args models.Args) error {
+			count, err := args.GetInt("1")
+			if err != nil {
+				return err
+			}
+			if count > 5 {
+				return errors.New("basket is full")
+			}
+
+			return nil
+		},
+		`^I wait until {date}$`: func(ctx context.Context, args models.Args) error {
+			_, err := args.GetTime("1")
+
+			return err
+		},
+	})
+	if err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// startLibrary starts the test binary as the step library
+func startLibrary(t *testing.T) *Process {
+	t.Setenv(libraryVariable, "1")
+	process, err := Start(os.Args[0], "-test.run=^TestStepLibrary$")
+	require.Nil(t, err)
+
+	return process
+}
+
+func TestProcess(t *testing.T) {
+	t.Run("should run the steps of the library with their arguments", func(t *testing.T) {
+		process := startLibrary(t)
+
+		steps, err := process.Steps(context.Background())
+		require.Nil(t, err)
+		require.Len(t, steps, 2)
+		apples := steps[`^I have {int} apples$`].(func(context.Context, models.Args) error)
+		wait := steps[`^I wait until {date}$`].(func(context.Context, models.Args) error)
+
+		require.Nil(t, apples(context.Background(), models.Args{"1": 3}))
+		require.EqualError(t, apples(context.Background(), models.Args{"1": 8}), "basket is full")
+		require.Nil(t, wait(context.Background(), models.Args{"1": time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)}))
+		require.Nil(t, process.Close())
+	})
+	t.Run("should return error if the library exits without answering", func(t *testing.T) {
+		process, err := Start("sh", "-c", "head -n 1 > /dev/null")
+		require.Nil(t, err)
+
+		_, err = process.Steps(context.Background())
+
+		require.ErrorContains(t, err, "did not answer steps request")
+	})
+	t.Run("should kill the library if the request is cancelled", func(t *testing.T) {
+		process, err := Start("sh", "-c", "exec sleep 30")
+		require.Nil(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err = process.Steps(ctx)
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Nil(t, process.Close())
+	})
+	t.Run("should close the library while a request waits for its answer", func(t *testing.T) {
+		process, err := Start("sh", "-c", "cat > /dev/null")
+		require.Nil(t, err)
+		answered := make(chan error)
+		go func() {
+			_, err := process.Steps(context.Background())
+			answered <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+
+		require.Nil(t, process.Close())
+		require.ErrorContains(t, <-answered, "did not answer steps request")
+	})
+}
+
+func TestServe(t *testing.T) {
+	t.Run("should answer unknown steps and methods with errors", func(t *testing.T) {
+		out := &strings.Builder{}
+
+		err := Serve(strings.NewReader(`{"id":1,"method":"run","definition":"^x$"}`+"\n"+`{"id":2,"method":"stop"}`+"\n"),
+			out, nil)
+
+		require.Nil(t, err)
+		require.Equal(t, `{"id":1,"error":"step ^x$ is not defined"}`+"\n"+`{"id":2,"error":"unknown method stop"}`+"\n",
+			out.String())
+	})
+}
+
+func TestOpen(t *testing.T) {
+	t.Run("should return error if the plugin can not be opened", func(t *testing.T) {
+		_, err := Open("missing.so")
+
+		require.ErrorContains(t, err, "could not open plugin missing.so")
+	})
+}
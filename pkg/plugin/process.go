@@ -0,0 +1,265 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	// MethodSteps asks the library for its step definitions, which it returns in Response.Steps
+	MethodSteps = "steps"
+	// MethodRun runs the step function of Request.Definition with Request.Args, and returns its failure in
+	// Response.Error
+	MethodRun = "run"
+)
+
+type (
+	// Request is a line of JSON written to the standard input of a step library. Args are the arguments captured
+	// by the definition, keyed like models.Args and formatted as text, such as "3" for {int} or
+	// "2024-03-02T00:00:00Z" for {date}.
+	Request struct {
+		ID         int               `json:"id"`
+		Method     string            `json:"method"`
+		Definition string            `json:"definition,omitempty"`
+		Args       map[string]string `json:"args,omitempty"`
+	}
+
+	// Response is the line of JSON a step library writes to its standard output for the request with the ID
+	Response struct {
+		ID    int      `json:"id"`
+		Steps []string `json:"steps,omitempty"`
+		Error string   `json:"error,omitempty"`
+	}
+
+	// Process is a step library running as a subprocess. Every request is a line of JSON written to its standard
+	// input and answered with a line of JSON on its standard output, see Request and Response. Requests are sent
+	// one at a time, so steps of scenarios executed at the same time wait for each other. A library which does not
+	// answer a request before its context is done is killed.
+	Process struct {
+		// turn holds a value while a request waits for its response
+		turn      chan struct{}
+		command   *exec.Cmd
+		input     io.WriteCloser
+		responses chan []byte
+		// failure is the error which ended the responses, it is set before responses is closed
+		failure error
+		// stopped is closed when the responses are not read anymore, read is closed when the output ended
+		stopped   chan struct{}
+		read      chan struct{}
+		stopOnce  sync.Once
+		closeOnce sync.Once
+		closeErr  error
+		killed    atomic.Bool
+		nextID    int
+	}
+)
+
+// Start starts the step library with the arguments. Its standard error is the standard error of the program.
+func Start(name string, arguments ...string) (*Process, error) {
+	command := exec.Command(name, arguments...)
+	command.Stderr = os.Stderr
+	input, err := command.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("could not start step library %s, error=%w", name, err)
+	}
+	output, err := command.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("could not start step library %s, error=%w", name, err)
+	}
+	if err := command.Start(); err != nil {
+		return nil, fmt.Errorf("could not start step library %s, error=%w", name, err)
+	}
+
+	process := &Process{
+		turn:      make(chan struct{}, 1),
+		command:   command,
+		input:     input,
+		responses: make(chan []byte),
+		stopped:   make(chan struct{}),
+		read:      make(chan struct{}),
+	}
+	go process.readResponses(output)
+
+	return process, nil
+}
+
+// Steps returns the step definitions of the library with step functions running them in the library
+func (p *Process) Steps(ctx context.Context) (Steps, error) {
+	definitions, err := p.Definitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make(Steps, len(definitions))
+	for _, definition := range definitions {
+		steps[definition] = func(ctx context.Context, args models.Args) error {
+			return p.Run(ctx, definition, args)
+		}
+	}
+
+	return steps, nil
+}
+
+// Definitions returns the step definitions of the library
+func (p *Process) Definitions(ctx context.Context) ([]string, error) {
+	response, err := p.send(ctx, &Request{Method: MethodSteps})
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Error) > 0 {
+		return nil, fmt.Errorf("step library %s could not list its steps, error=%s", p.command.Path, response.Error)
+	}
+
+	return response.Steps, nil
+}
+
+// Run runs the step function of the definition in the library
+func (p *Process) Run(ctx context.Context, definition string, args models.Args) error {
+	texts := make(map[string]string, len(args))
+	for key, value := range args {
+		texts[key] = formatArg(value)
+	}
+
+	response, err := p.send(ctx, &Request{Method: MethodRun, Definition: definition, Args: texts})
+	if err != nil {
+		return err
+	}
+	if len(response.Error) > 0 {
+		return errors.New(response.Error)
+	}
+
+	return nil
+}
+
+// Close closes the standard input of the library and waits until it exits. It does not wait for the requests
+// being sent, which fail once the library exits. A library killed because a request was cancelled is not an error.
+func (p *Process) Close() error {
+	p.closeOnce.Do(func() {
+		inputErr := p.input.Close()
+		p.stop()
+		if !p.killed.Load() {
+			// the output must be read before Wait closes it, unless the answers are not needed anymore
+			<-p.read
+		}
+		waitErr := p.command.Wait()
+		if p.killed.Load() {
+			waitErr = nil
+		}
+		p.closeErr = errors.Join(inputErr, waitErr)
+	})
+
+	return p.closeErr
+}
+
+// send writes the request and waits for its response until the context is done
+func (p *Process) send(ctx context.Context, request *Request) (*Response, error) {
+	select {
+	case p.turn <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("could not send %s request to step library %s, error=%w", request.Method,
+			p.command.Path, ctx.Err())
+	}
+	defer func() { <-p.turn }()
+
+	p.nextID++
+	request.ID = p.nextID
+	line, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.input.Write(append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("could not send %s request to step library %s, error=%w", request.Method,
+			p.command.Path, err)
+	}
+
+	var answer []byte
+	select {
+	case received, ok := <-p.responses:
+		if !ok {
+			err := p.failure
+			if err == nil {
+				err = io.ErrUnexpectedEOF
+			}
+
+			return nil, fmt.Errorf("step library %s did not answer %s request, error=%w", p.command.Path,
+				request.Method, err)
+		}
+		answer = received
+	case <-ctx.Done():
+		// the answer of the request would be read by the next request, so the library can not be used anymore
+		p.kill()
+
+		return nil, fmt.Errorf("step library %s did not answer %s request, error=%w", p.command.Path,
+			request.Method, ctx.Err())
+	}
+
+	response := &Response{}
+	if err := json.Unmarshal(answer, response); err != nil {
+		return nil, fmt.Errorf("step library %s answered %s request with invalid JSON, error=%w", p.command.Path,
+			request.Method, err)
+	}
+	if response.ID != request.ID {
+		return nil, fmt.Errorf("step library %s answered request %d instead of %d", p.command.Path, response.ID,
+			request.ID)
+	}
+
+	return response, nil
+}
+
+// readResponses passes the lines of the output to the requests until it ends. Lines arriving after the responses
+// are stopped are discarded, so the library is not blocked writing them.
+func (p *Process) readResponses(output io.Reader) {
+	defer close(p.read)
+
+	scanner := bufio.NewScanner(output)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := append([]byte(nil), scanner.Bytes()...)
+		select {
+		case p.responses <- line:
+		case <-p.stopped:
+		}
+	}
+	p.failure = scanner.Err()
+	close(p.responses)
+}
+
+// kill stops the library without waiting for its answers
+func (p *Process) kill() {
+	p.killed.Store(true)
+	p.stop()
+	_ = p.command.Process.Kill()
+}
+
+// stop stops passing the lines of the output to the requests
+func (p *Process) stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopped)
+	})
+}
+
+// formatArg formats a converted argument as text the getters of models.Args parse back
+func formatArg(value any) string {
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case time.Time:
+		return typed.Format(time.RFC3339Nano)
+	case float64:
+		return strconv.FormatFloat(typed, 'g', -1, 64)
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,70 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+// Serve answers the requests read from in with the steps until in is closed, so a Go program can be a step library
+// started with Start. Its main function serves the standard input and output:
+//
+//	plugin.Serve(os.Stdin, os.Stdout, steps)
+//
+// Arguments of the steps are passed as text, which the getters of models.Args convert.
+func Serve(in io.Reader, out io.Writer, steps map[string]func(ctx context.Context, args models.Args) error) error {
+	definitions := make([]string, 0, len(steps))
+	for definition := range steps {
+		definitions = append(definitions, definition)
+	}
+	sort.Strings(definitions)
+
+	requests := bufio.NewScanner(in)
+	requests.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	encoder := json.NewEncoder(out)
+	for requests.Scan() {
+		request := &Request{}
+		response := &Response{}
+		if err := json.Unmarshal(requests.Bytes(), request); err != nil {
+			response.Error = fmt.Sprintf("could not decode request, error=%s", err)
+		} else {
+			response.ID = request.ID
+			response.Steps, response.Error = answer(request, definitions, steps)
+		}
+		if err := encoder.Encode(response); err != nil {
+			return fmt.Errorf("could not write response, error=%w", err)
+		}
+	}
+
+	return requests.Err()
+}
+
+// answer returns the step definitions or the error of the step run by the request
+func answer(request *Request, definitions []string,
+	steps map[string]func(ctx context.Context, args models.Args) error) ([]string, string) {
+	switch request.Method {
+	case MethodSteps:
+		return definitions, ""
+	case MethodRun:
+		step, ok := steps[request.Definition]
+		if !ok {
+			return nil, fmt.Sprintf("step %s is not defined", request.Definition)
+		}
+		args := make(models.Args, len(request.Args))
+		for key, value := range request.Args {
+			args[key] = value
+		}
+		if err := step(context.Background(), args); err != nil {
+			return nil, err.Error()
+		}
+
+		return nil, ""
+	default:
+		return nil, fmt.Sprintf("unknown method %s", request.Method)
+	}
+}
@@ -0,0 +1,75 @@
+// Package remote executes scenarios on remote agents. A coordinator passes an Executor to the runner, which sends
+// every pickle to one of the agents over HTTP and returns the results to the runner. An agent is a program with
+// the same registered steps serving an Agent.
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	messages "github.com/cucumber/messages/go/v21"
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	// PicklesPath is the path agents receive pickles on. The request body is a PickleRequest and the response
+	// body the models.ScenarioResult of the pickle, both as JSON.
+	PicklesPath = "/v1/pickles"
+)
+
+type (
+	// PickleRequest is the request sent to an agent to execute a pickle of the document
+	PickleRequest struct {
+		Document *messages.GherkinDocument `json:"document"`
+		Pickle   *messages.Pickle          `json:"pickle"`
+	}
+
+	// PickleExecutor executes a pickle, such as executor.StepExecutor
+	PickleExecutor interface {
+		ExecutePickle(context.Context, *messages.GherkinDocument, *messages.Pickle) *models.ScenarioResult
+	}
+
+	// Agent is the http.Handler of an agent executing the pickles it receives with its executor
+	Agent struct {
+		executor PickleExecutor
+	}
+)
+
+// NewAgent creates an agent executing pickles with the executor, which must have the steps of the coordinator
+func NewAgent(executor PickleExecutor) *Agent {
+	return &Agent{executor: executor}
+}
+
+func (a *Agent) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.URL.Path != PicklesPath {
+		http.NotFound(writer, request)
+
+		return
+	}
+	if request.Method != http.MethodPost {
+		writer.Header().Set("Allow", http.MethodPost)
+		http.Error(writer, "pickles must be sent with POST", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	pickleRequest := &PickleRequest{}
+	if err := json.NewDecoder(request.Body).Decode(pickleRequest); err != nil {
+		http.Error(writer, fmt.Sprintf("could not decode pickle request, error=%s", err), http.StatusBadRequest)
+
+		return
+	}
+	if pickleRequest.Document == nil || pickleRequest.Pickle == nil {
+		http.Error(writer, "pickle request must have a document and a pickle", http.StatusBadRequest)
+
+		return
+	}
+
+	result := a.executor.ExecutePickle(request.Context(), pickleRequest.Document, pickleRequest.Pickle)
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(result); err != nil {
+		http.Error(writer, fmt.Sprintf("could not encode scenario result, error=%s", err), http.StatusInternalServerError)
+	}
+}
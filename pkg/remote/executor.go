@@ -0,0 +1,210 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gherkin "github.com/cucumber/gherkin/go/v26"
+	messages "github.com/cucumber/messages/go/v21"
+	"github.com/denizgursoy/cacik/pkg/executor"
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+type (
+	// Executor is the executor of a coordinator. It sends the pickles to its agents, every agent executing one
+	// pickle at a time, and returns their results in the order of the document. Steps, providers and parameter
+	// types are registered to a local executor as well, so steps are validated and matched on the coordinator.
+	Executor struct {
+		local  *executor.StepExecutor
+		filter executor.PickleFilter
+		agents []string
+		client *http.Client
+		// next is the index of the agent ExecutePickle sends the next pickle to
+		next atomic.Uint64
+	}
+)
+
+// NewExecutor creates the executor of a coordinator sending pickles to the agents at the base URLs, such as
+// http://agent-1:8080
+func NewExecutor(agents ...string) *Executor {
+	trimmed := make([]string, 0, len(agents))
+	for _, agent := range agents {
+		trimmed = append(trimmed, strings.TrimSuffix(agent, "/"))
+	}
+
+	return &Executor{
+		local:  executor.NewStepExecutor(),
+		agents: trimmed,
+		client: http.DefaultClient,
+	}
+}
+
+// WithClient sets the client the pickles are sent with, such as one with a timeout or TLS configuration
+func (e *Executor) WithClient(client *http.Client) *Executor {
+	e.client = client
+
+	return e
+}
+
+// SetConfig sets the config of the local executor. Scenario and step hooks are executed by the agents with their
+// own config.
+func (e *Executor) SetConfig(config *models.Config) {
+	e.local.SetConfig(config)
+}
+
+// ConfigErrors returns the problems of the config of the local executor
+func (e *Executor) ConfigErrors() error {
+	return e.local.ConfigErrors()
+}
+
+func (e *Executor) SetFilter(filter executor.PickleFilter) {
+	e.filter = filter
+}
+
+func (e *Executor) RegisterStep(definition string, function any) error {
+	return e.local.RegisterStep(definition, function)
+}
+
+func (e *Executor) Provide(provider any) error {
+	return e.local.Provide(provider)
+}
+
+func (e *Executor) RegisterParameterType(name, regex string, transform func(string) (any, error)) error {
+	return e.local.RegisterParameterType(name, regex, transform)
+}
+
+func (e *Executor) MatchStep(text string) (*executor.StepDefinition, []string) {
+	return e.local.MatchStep(text)
+}
+
+// Execute sends the pickles of the document selected by the filter to the agents
+func (e *Executor) Execute(document *messages.GherkinDocument) ([]*models.ScenarioResult, error) {
+	if len(e.agents) == 0 {
+		return nil, errors.New("remote executor has no agents")
+	}
+	results := make([]*models.ScenarioResult, 0)
+	if document.Feature == nil {
+		return results, nil
+	}
+
+	pickles := make([]*messages.Pickle, 0)
+	for _, pickle := range gherkin.Pickles(*document, document.Uri, (&messages.Incrementing{}).NewId) {
+		if e.filter == nil || e.filter(document, pickle) {
+			pickles = append(pickles, pickle)
+		}
+	}
+
+	results = make([]*models.ScenarioResult, len(pickles))
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	for _, agent := range e.agents {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				results[index] = e.send(context.Background(), agent, document, pickles[index])
+			}
+		}()
+	}
+	for index := range pickles {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results, nil
+}
+
+// ExecutePickle sends the pickle to the next agent in turn
+func (e *Executor) ExecutePickle(ctx context.Context, document *messages.GherkinDocument,
+	pickle *messages.Pickle) *models.ScenarioResult {
+	if len(e.agents) == 0 {
+		return failedResult(document, pickle, "remote executor", errors.New("remote executor has no agents"), 0)
+	}
+	agent := e.agents[(e.next.Add(1)-1)%uint64(len(e.agents))]
+
+	return e.send(ctx, agent, document, pickle)
+}
+
+// send executes the pickle on the agent. A pickle which could not be executed fails with the error of the agent.
+func (e *Executor) send(ctx context.Context, agent string, document *messages.GherkinDocument,
+	pickle *messages.Pickle) *models.ScenarioResult {
+	start := time.Now()
+	result, err := e.post(ctx, agent, &PickleRequest{Document: document, Pickle: pickle})
+	if err != nil {
+		return failedResult(document, pickle, agent, err, time.Since(start))
+	}
+
+	return result
+}
+
+func (e *Executor) post(ctx context.Context, agent string, pickleRequest *PickleRequest) (*models.ScenarioResult, error) {
+	body, err := json.Marshal(pickleRequest)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode pickle request, error=%w", err)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, agent+PicklesPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("could not create request to agent %s, error=%w", agent, err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := e.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("could not send pickle to agent %s, error=%w", agent, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+
+		return nil, fmt.Errorf("agent %s responded with %s: %s", agent, response.Status,
+			strings.TrimSpace(string(message)))
+	}
+	result := &models.ScenarioResult{}
+	if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("could not decode scenario result of agent %s, error=%w", agent, err)
+	}
+
+	return result, nil
+}
+
+// failedResult returns the result of a pickle which could not be executed by the agent. The error is recorded as
+// a failed hook named after the agent and the steps are skipped.
+func failedResult(document *messages.GherkinDocument, pickle *messages.Pickle, agent string, err error,
+	duration time.Duration) *models.ScenarioResult {
+	result := &models.ScenarioResult{
+		Name:     pickle.Name,
+		Uri:      pickle.Uri,
+		Line:     executor.PickleLine(document, pickle),
+		Status:   models.StatusFailed,
+		Duration: duration,
+		Steps:    make([]*models.StepResult, 0, len(pickle.Steps)),
+		Hooks: []*models.HookResult{{
+			Name:     agent,
+			Status:   models.StatusFailed,
+			Duration: duration,
+			Error:    err.Error(),
+		}},
+	}
+	for _, step := range pickle.Steps {
+		result.Steps = append(result.Steps, &models.StepResult{
+			Text:      step.Text,
+			Status:    models.StatusSkipped,
+			SkipCause: models.SkipCauseHook,
+			Reason:    err.Error(),
+		})
+	}
+	result.Fingerprint = result.FailureFingerprint()
+
+	return result
+}
@@ -0,0 +1,143 @@
+package remote
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	gherkin "github.com/cucumber/gherkin/go/v26"
+	messages "github.com/cucumber/messages/go/v21"
+	"github.com/denizgursoy/cacik/pkg/executor"
+	"github.com/denizgursoy/cacik/pkg/gherkin_parser"
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/denizgursoy/cacik/pkg/runner"
+	"github.com/stretchr/testify/require"
+)
+
+const basketFeature = `Feature: Basket
+
+  Scenario Outline: Add items
+    Given I add <count> items
+
+    Examples:
+      | count |
+      | 1     |
+      | 2     |
+      | 3     |
+
+  Scenario: Remove items
+    Given I remove 1 items
+`
+
+var _ runner.Executor = (*Executor)(nil)
+
+func parseDocument(t *testing.T, source string) *messages.GherkinDocument {
+	document, err := gherkin_parser.ParseGherkinFile(strings.NewReader(source))
+	require.Nil(t, err)
+	document.Uri = "basket.feature"
+
+	return document
+}
+
+// startAgent starts an agent with the steps of the basket feature counting the pickles it executed
+func startAgent(t *testing.T, executed *atomic.Int32) *httptest.Server {
+	local := executor.NewStepExecutor()
+	require.Nil(t, local.RegisterStep(`^I add {int} items$`, func(count int) {
+		executed.Add(1)
+	}))
+	server := httptest.NewServer(NewAgent(local))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestExecutor_Execute(t *testing.T) {
+	t.Run("should distribute pickles to the agents and keep the order of the document", func(t *testing.T) {
+		first, second := &atomic.Int32{}, &atomic.Int32{}
+		coordinator := NewExecutor(startAgent(t, first).URL, startAgent(t, second).URL+"/")
+
+		results, err := coordinator.Execute(parseDocument(t, basketFeature))
+
+		require.Nil(t, err)
+		require.Len(t, results, 4)
+		for i, line := range []int{8, 9, 10} {
+			require.Equal(t, models.StatusPassed, results[i].Status)
+			require.Equal(t, line, results[i].Line)
+		}
+		require.Equal(t, models.StatusUndefined, results[3].Status)
+		require.Equal(t, int32(3), first.Load()+second.Load())
+	})
+
+	t.Run("should execute only the pickles selected by the filter", func(t *testing.T) {
+		executed := &atomic.Int32{}
+		coordinator := NewExecutor(startAgent(t, executed).URL)
+		coordinator.SetFilter(func(_ *messages.GherkinDocument, pickle *messages.Pickle) bool {
+			return pickle.Name == "Remove items"
+		})
+
+		results, err := coordinator.Execute(parseDocument(t, basketFeature))
+
+		require.Nil(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, int32(0), executed.Load())
+	})
+
+	t.Run("should fail the pickles an agent could not execute", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		coordinator := NewExecutor(server.URL)
+
+		results, err := coordinator.Execute(parseDocument(t, basketFeature))
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusFailed, results[0].Status)
+		require.Equal(t, models.StatusSkipped, results[0].Steps[0].Status)
+		require.Contains(t, results[0].FailureMessage(), "could not send pickle to agent "+server.URL)
+		require.NotEmpty(t, results[0].Fingerprint)
+	})
+
+	t.Run("should return error without agents", func(t *testing.T) {
+		_, err := NewExecutor().Execute(parseDocument(t, basketFeature))
+
+		require.EqualError(t, err, "remote executor has no agents")
+	})
+}
+
+func TestExecutor_ExecutePickle(t *testing.T) {
+	t.Run("should send pickles to the agents in turn", func(t *testing.T) {
+		first, second := &atomic.Int32{}, &atomic.Int32{}
+		coordinator := NewExecutor(startAgent(t, first).URL, startAgent(t, second).URL)
+		document := parseDocument(t, basketFeature)
+		pickles := gherkin.Pickles(*document, document.Uri, (&messages.Incrementing{}).NewId)
+
+		for _, pickle := range pickles[:2] {
+			require.Equal(t, models.StatusPassed, coordinator.ExecutePickle(context.Background(), document, pickle).Status)
+		}
+
+		require.Equal(t, int32(1), first.Load())
+		require.Equal(t, int32(1), second.Load())
+	})
+}
+
+func TestAgent(t *testing.T) {
+	server := startAgent(t, &atomic.Int32{})
+
+	t.Run("should reject requests without a pickle", func(t *testing.T) {
+		response, err := http.Post(server.URL+PicklesPath, "application/json", strings.NewReader(`{}`))
+		require.Nil(t, err)
+		defer response.Body.Close()
+
+		require.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("should only accept POST", func(t *testing.T) {
+		response, err := http.Get(server.URL + PicklesPath)
+		require.Nil(t, err)
+		defer response.Body.Close()
+
+		require.Equal(t, http.StatusMethodNotAllowed, response.StatusCode)
+	})
+}
@@ -0,0 +1,75 @@
+package reporter
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"os"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	badgeLabel = "cacik"
+
+	badgePassedColor = "#4c1"
+	badgeFailedColor = "#e05d44"
+	badgeEmptyColor  = "#9f9f9f"
+
+	// badgeCharacterWidth is the approximate width of a character of the 11px Verdana font of the badge
+	badgeCharacterWidth = 7
+	badgePadding        = 10
+)
+
+// GenerateBadge writes an SVG badge with the passed and failed scenario counts and the pass rate of the run to
+// the file at path, so the result can be embedded in a README or a dashboard
+func GenerateBadge(path string, result *models.RunResult) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create badge %s, error=%w", path, err)
+	}
+	defer file.Close()
+
+	return WriteBadge(file, result)
+}
+
+func WriteBadge(writer io.Writer, result *models.RunResult) error {
+	total := len(result.Scenarios)
+	passed := result.Count(models.StatusPassed)
+	message := fmt.Sprintf("%d passed, %d failed, %s", passed, len(result.FailedScenarios()), passRate(passed, total))
+	color := badgePassedColor
+	if total == 0 {
+		message = "no scenarios"
+		color = badgeEmptyColor
+	} else if !result.Passed() {
+		color = badgeFailedColor
+	}
+
+	labelWidth := len(badgeLabel)*badgeCharacterWidth + badgePadding
+	messageWidth := len(message)*badgeCharacterWidth + badgePadding
+	width := labelWidth + messageWidth
+
+	_, err := fmt.Fprintf(writer, `<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[2]s: %[3]s">
+  <title>%[2]s: %[3]s</title>
+  <linearGradient id="s" x2="0" y2="100%%">
+    <stop offset="0" stop-color="#bbb" stop-opacity=".1"/>
+    <stop offset="1" stop-opacity=".1"/>
+  </linearGradient>
+  <clipPath id="r">
+    <rect width="%[1]d" height="20" rx="3" fill="#fff"/>
+  </clipPath>
+  <g clip-path="url(#r)">
+    <rect width="%[4]d" height="20" fill="#555"/>
+    <rect x="%[4]d" width="%[5]d" height="20" fill="%[6]s"/>
+    <rect width="%[1]d" height="20" fill="url(#s)"/>
+  </g>
+  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+    <text x="%[7]d" y="14">%[2]s</text>
+    <text x="%[8]d" y="14">%[3]s</text>
+  </g>
+</svg>
+`, width, badgeLabel, html.EscapeString(message), labelWidth, messageWidth, color, labelWidth/2,
+		labelWidth+messageWidth/2)
+
+	return err
+}
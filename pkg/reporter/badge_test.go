@@ -0,0 +1,44 @@
+package reporter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBadge(t *testing.T) {
+	t.Run("should show the counts and the pass rate in red if a scenario failed", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		err := WriteBadge(buffer, &models.RunResult{Scenarios: []*models.ScenarioResult{
+			{Status: models.StatusPassed},
+			{Status: models.StatusPassed},
+			{Status: models.StatusPassed},
+			{Status: models.StatusUndefined},
+		}})
+
+		require.Nil(t, err)
+		require.Contains(t, buffer.String(), `aria-label="cacik: 3 passed, 1 failed, 75.0%"`)
+		require.Contains(t, buffer.String(), `fill="#e05d44"`)
+	})
+	t.Run("should be green if every scenario passed", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		err := WriteBadge(buffer, &models.RunResult{Scenarios: []*models.ScenarioResult{{Status: models.StatusPassed}}})
+
+		require.Nil(t, err)
+		require.Contains(t, buffer.String(), ">1 passed, 0 failed, 100.0%</text>")
+		require.Contains(t, buffer.String(), `fill="#4c1"`)
+	})
+	t.Run("should be grey without scenarios", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		err := WriteBadge(buffer, &models.RunResult{})
+
+		require.Nil(t, err)
+		require.Contains(t, buffer.String(), ">no scenarios</text>")
+		require.Contains(t, buffer.String(), `fill="#9f9f9f"`)
+	})
+}
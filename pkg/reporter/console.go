@@ -0,0 +1,171 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	ConsoleFormat = "pretty"
+)
+
+type (
+	// ConsoleReporter writes every scenario with its steps in a human-readable layout. The status column is
+	// aligned to the longest step of each scenario, so long parameters such as URLs do not make it ragged.
+	// Scenarios and failed steps are followed by their location in the feature file, such as
+	// # features/login.feature:12, which terminals and IDEs open on click.
+	ConsoleReporter struct {
+		NoopReporter
+		writer        io.Writer
+		symbols       map[models.Status]string
+		palette       *Palette
+		hideLocations bool
+		verbosity     Verbosity
+	}
+)
+
+// NewConsoleReporter creates a reporter marking statuses with UnicodeSymbols without colors
+func NewConsoleReporter(writer io.Writer) *ConsoleReporter {
+	return &ConsoleReporter{
+		writer:    writer,
+		symbols:   symbolSets[UnicodeSymbols],
+		verbosity: VerbosityNormal,
+	}
+}
+
+// SetStyle sets the symbols marking the statuses of steps, see SymbolsByName, and the palette coloring them, see
+// PaletteByName. A nil palette writes without colors.
+func (c *ConsoleReporter) SetStyle(symbols map[models.Status]string, palette *Palette) {
+	c.symbols = symbols
+	c.palette = palette
+}
+
+// ColorsEnabled reports whether the writer of the reporter may be colored, see the ColorsEnabled function
+func (c *ConsoleReporter) ColorsEnabled() bool {
+	return ColorsEnabled(c.writer)
+}
+
+// SetLocations sets whether the locations of scenarios and failed steps are written
+func (c *ConsoleReporter) SetLocations(enabled bool) {
+	c.hideLocations = !enabled
+}
+
+// SetVerbosity sets how much of the run is written, VerbosityNormal by default
+func (c *ConsoleReporter) SetVerbosity(verbosity Verbosity) {
+	c.verbosity = verbosity
+}
+
+func (c *ConsoleReporter) ScenarioFinished(scenario *models.ScenarioResult) {
+	if c.verbosity < VerbosityNormal {
+		return
+	}
+	fmt.Fprintf(c.writer, "Scenario: %s%s\n", scenario.Name, c.location(scenario.Uri, scenario.Line))
+
+	width := 0
+	for _, step := range scenario.Steps {
+		width = max(width, utf8.RuneCountInString(step.Keyword+step.Text))
+	}
+	for _, step := range scenario.Steps {
+		text := step.Keyword + step.Text
+		padding := strings.Repeat(" ", width-utf8.RuneCountInString(text))
+		mark := c.symbols[step.Status]
+		if c.palette != nil {
+			mark = colorize(mark, c.palette.Status(step.Status))
+		}
+		location := ""
+		if step.Line > 0 && (step.Status == models.StatusFailed || step.Status == models.StatusUndefined) {
+			location = c.location(scenario.Uri, step.Line)
+		}
+		fmt.Fprintf(c.writer, "  %s%s  %s %s%s\n", text, padding, mark, c.duration(step), location)
+		if len(step.Error) > 0 {
+			// every line of errors spanning lines, such as the diff of assert.Equal, is indented under the step
+			fmt.Fprintf(c.writer, "      %s\n", strings.ReplaceAll(step.Error, "\n", "\n      "))
+		}
+		if step.Failure != nil && len(step.Failure.Stack) > 0 {
+			fmt.Fprintf(c.writer, "        %s\n", strings.ReplaceAll(step.Failure.Stack, "\n", "\n        "))
+		}
+		for _, warning := range step.Warnings {
+			fmt.Fprintf(c.writer, "      warning: %s\n", warning)
+		}
+		// the reasons of the other skipped steps are the reason of the scenario or the step before them
+		if step.SkipCause == models.SkipCausePending {
+			fmt.Fprintf(c.writer, "      pending: %s\n", step.Reason)
+		}
+		if c.verbosity >= VerbosityVerbose {
+			for _, log := range step.Logs {
+				fmt.Fprintf(c.writer, "      | %s\n", strings.ReplaceAll(log, "\n", "\n      | "))
+			}
+		}
+	}
+	if len(scenario.SkipCause) > 0 {
+		fmt.Fprintf(c.writer, "  skipped (%s): %s\n", scenario.SkipCause, scenario.Reason)
+	} else if len(scenario.Reason) > 0 {
+		fmt.Fprintf(c.writer, "  skipped: %s\n", scenario.Reason)
+	}
+	fmt.Fprintln(c.writer)
+}
+
+// duration returns the duration of the step, highlighted with the color of the palette for slow steps or marked as
+// slow without colors
+func (c *ConsoleReporter) duration(step *models.StepResult) string {
+	duration := formatDuration(step.Duration)
+	if !step.Slow {
+		return duration
+	}
+	if c.palette == nil {
+		return duration + " (slow)"
+	}
+
+	return colorize(duration, c.palette.Slow)
+}
+
+// location returns the comment with the location of the line of the feature file, or an empty string if locations
+// are hidden
+func (c *ConsoleReporter) location(uri string, line int) string {
+	if c.hideLocations {
+		return ""
+	}
+
+	return formatLocation(uri, line)
+}
+
+// formatLocation returns the comment with the location of the line of the feature file, such as
+// " # features/login.feature:12", or an empty string if the uri is empty
+func formatLocation(uri string, line int) string {
+	if len(uri) == 0 {
+		return ""
+	}
+	if line > 0 {
+		return fmt.Sprintf(" # %s:%d", uri, line)
+	}
+
+	return " # " + uri
+}
+
+// RunFinished writes the number of scenarios by status and the duration of the run
+func (c *ConsoleReporter) RunFinished(run *models.RunResult) {
+	if c.verbosity < VerbositySummary {
+		return
+	}
+	writeSummary(c.writer, run)
+}
+
+// writeSummary writes the number of scenarios by status and the duration of the run
+func writeSummary(writer io.Writer, run *models.RunResult) {
+	counts := make([]string, 0)
+	for _, status := range []models.Status{models.StatusPassed, models.StatusFailed, models.StatusSkipped,
+		models.StatusUndefined} {
+		if count := run.Count(status); count > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", count, status))
+		}
+	}
+	summary := fmt.Sprintf("%d scenarios", len(run.Scenarios))
+	if len(counts) > 0 {
+		summary += " (" + strings.Join(counts, ", ") + ")"
+	}
+	fmt.Fprintf(writer, "%s\n%s\n", summary, formatDuration(run.Duration))
+}
@@ -0,0 +1,201 @@
+package reporter
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsoleReporter(t *testing.T) {
+	t.Run("should align the status column to the longest step of the scenario", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		reporter := NewConsoleReporter(buffer)
+
+		reporter.ScenarioFinished(&models.ScenarioResult{
+			Name:   "Open page",
+			Uri:    "a.feature",
+			Line:   3,
+			Status: models.StatusFailed,
+			Steps: []*models.StepResult{
+				{Keyword: "Given ", Text: "I open https://example.com/a/very/long/path", Status: models.StatusPassed,
+					Duration: 2 * time.Millisecond},
+				{Keyword: "Then ", Text: "I see «ok»", Line: 5, Status: models.StatusFailed, Error: "not ok\n-a\n+b",
+					Failure: &models.Failure{Message: "not ok\n-a\n+b", Stack: "shop.See()\n\tsee.go:12"}},
+				{Keyword: "And ", Text: "I leave", Status: models.StatusSkipped},
+			},
+		})
+
+		require.Equal(t, `Scenario: Open page # a.feature:3
+  Given I open https://example.com/a/very/long/path  ✓ 2ms
+  Then I see «ok»                                    ✗ 0s # a.feature:5
+      not ok
+      -a
+      +b
+        shop.See()
+        	see.go:12
+  And I leave                                        - 0s
+
+`, buffer.String())
+	})
+	t.Run("should not write the locations of scenarios and failed steps if they are hidden", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		reporter := NewConsoleReporter(buffer)
+		reporter.SetLocations(false)
+
+		reporter.ScenarioFinished(&models.ScenarioResult{
+			Name:   "Pay",
+			Uri:    "a.feature",
+			Line:   3,
+			Status: models.StatusUndefined,
+			Steps: []*models.StepResult{
+				{Keyword: "When ", Text: "I pay", Line: 4, Status: models.StatusUndefined},
+			},
+		})
+
+		require.Equal(t, "Scenario: Pay\n  When I pay  ? 0s\n\n", buffer.String())
+	})
+	t.Run("should write the messages logged by the steps if it is verbose", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		reporter := NewConsoleReporter(buffer)
+		reporter.SetVerbosity(VerbosityVerbose)
+
+		reporter.ScenarioFinished(&models.ScenarioResult{
+			Name: "Pay",
+			Steps: []*models.StepResult{
+				{Keyword: "When ", Text: "I pay", Status: models.StatusPassed, Logs: []string{"paid 3", "receipt\n#1"}},
+			},
+		})
+
+		require.Equal(t, "Scenario: Pay\n  When I pay  ✓ 0s\n      | paid 3\n      | receipt\n      | #1\n\n", buffer.String())
+	})
+	t.Run("should write only the summary or nothing if it is quiet", func(t *testing.T) {
+		scenario := &models.ScenarioResult{Name: "Pay", Status: models.StatusPassed}
+		run := &models.RunResult{Scenarios: []*models.ScenarioResult{scenario}, Duration: time.Second}
+		for verbosity, expected := range map[Verbosity]string{
+			VerbositySummary: "1 scenarios (1 passed)\n1s\n",
+			VerbositySilent:  "",
+		} {
+			buffer := &bytes.Buffer{}
+			reporter := NewConsoleReporter(buffer)
+			reporter.SetVerbosity(verbosity)
+
+			reporter.ScenarioFinished(scenario)
+			reporter.RunFinished(run)
+
+			require.Equal(t, expected, buffer.String())
+		}
+	})
+	t.Run("should mark the statuses with the symbols and colors of the style", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		reporter := NewConsoleReporter(buffer)
+		symbols, err := SymbolsByName(ASCIISymbols)
+		require.Nil(t, err)
+		palette, err := PaletteByName(ColorblindPalette)
+		require.Nil(t, err)
+		reporter.SetStyle(symbols, palette)
+
+		reporter.ScenarioFinished(&models.ScenarioResult{
+			Name: "Pay",
+			Uri:  "a.feature",
+			Steps: []*models.StepResult{
+				{Keyword: "When ", Text: "I pay", Status: models.StatusPassed},
+				{Keyword: "Then ", Text: "I am paid", Status: models.StatusFailed},
+			},
+		})
+
+		require.Equal(t, "Scenario: Pay # a.feature\n"+
+			"  When I pay      \x1b[38;2;0;114;178m[PASS]\x1b[0m 0s\n"+
+			"  Then I am paid  \x1b[38;2;213;94;0m[FAIL]\x1b[0m 0s\n\n", buffer.String())
+	})
+	t.Run("should highlight the durations of slow steps", func(t *testing.T) {
+		scenario := &models.ScenarioResult{
+			Name: "Pay",
+			Steps: []*models.StepResult{
+				{Keyword: "When ", Text: "I pay", Status: models.StatusPassed, Duration: 3 * time.Second, Slow: true},
+			},
+		}
+		for palette, expected := range map[string]string{
+			NoPalette:      "  When I pay  ✓ 3s (slow)\n",
+			DefaultPalette: "  When I pay  \x1b[38;2;46;125;50m✓\x1b[0m \x1b[38;2;249;168;37m3s\x1b[0m\n",
+		} {
+			buffer := &bytes.Buffer{}
+			reporter := NewConsoleReporter(buffer)
+			colors, err := PaletteByName(palette)
+			require.Nil(t, err)
+			reporter.SetStyle(symbolSets[UnicodeSymbols], colors)
+
+			reporter.ScenarioFinished(scenario)
+
+			require.Contains(t, buffer.String(), expected)
+		}
+	})
+	t.Run("should return error for unknown styles", func(t *testing.T) {
+		_, err := SymbolsByName("emoji")
+		require.EqualError(t, err, "unknown symbols emoji, use unicode or ascii")
+
+		_, err = PaletteByName("neon")
+		require.EqualError(t, err, "unknown palette neon, use none, default or colorblind")
+	})
+	t.Run("should write the skip causes and reasons", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		reporter := NewConsoleReporter(buffer)
+
+		reporter.ScenarioFinished(&models.ScenarioResult{
+			Name:      "Pay",
+			Uri:       "a.feature",
+			Status:    models.StatusSkipped,
+			SkipCause: models.SkipCausePending,
+			Reason:    "waiting for the payment API",
+			Steps: []*models.StepResult{
+				{Keyword: "When ", Text: "I pay", Status: models.StatusSkipped, SkipCause: models.SkipCausePending,
+					Reason: "waiting for the payment API"},
+				{Keyword: "Then ", Text: "I am paid", Status: models.StatusSkipped,
+					SkipCause: models.SkipCausePreviousStep, Reason: `step "I pay" skipped`},
+			},
+		})
+
+		require.Equal(t, `Scenario: Pay # a.feature
+  When I pay      - 0s
+      pending: waiting for the payment API
+  Then I am paid  - 0s
+  skipped (pending): waiting for the payment API
+
+`, buffer.String())
+	})
+	t.Run("should write the warnings of the steps", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		reporter := NewConsoleReporter(buffer)
+
+		reporter.ScenarioFinished(&models.ScenarioResult{
+			Name:   "Open basket",
+			Uri:    "a.feature",
+			Status: models.StatusPassed,
+			Steps: []*models.StepResult{
+				{Keyword: "Given ", Text: "I open the basket", Status: models.StatusPassed,
+					Warnings: []string{"step is a Given step but ^I open the basket$ is registered for When steps"}},
+			},
+		})
+
+		require.Equal(t, `Scenario: Open basket # a.feature
+  Given I open the basket  ✓ 0s
+      warning: step is a Given step but ^I open the basket$ is registered for When steps
+
+`, buffer.String())
+	})
+	t.Run("should write the summary of the run", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		reporter := NewConsoleReporter(buffer)
+
+		reporter.RunFinished(&models.RunResult{
+			Duration: 1500 * time.Millisecond,
+			Scenarios: []*models.ScenarioResult{
+				{Status: models.StatusPassed}, {Status: models.StatusPassed}, {Status: models.StatusFailed},
+			},
+		})
+
+		require.Equal(t, "3 scenarios (2 passed, 1 failed)\n1.5s\n", buffer.String())
+	})
+}
@@ -0,0 +1,201 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+type (
+	cucumberFeature struct {
+		Uri         string             `json:"uri"`
+		ID          string             `json:"id"`
+		Keyword     string             `json:"keyword"`
+		Name        string             `json:"name"`
+		Description string             `json:"description"`
+		Elements    []*cucumberElement `json:"elements"`
+	}
+
+	cucumberElement struct {
+		ID          string          `json:"id"`
+		Keyword     string          `json:"keyword"`
+		Type        string          `json:"type"`
+		Name        string          `json:"name"`
+		Description string          `json:"description"`
+		Line        int             `json:"line"`
+		Tags        []cucumberTag   `json:"tags,omitempty"`
+		Steps       []*cucumberStep `json:"steps"`
+		// After carries the attachments of the scenario hooks, which are not attached to a step
+		After []*cucumberHook `json:"after,omitempty"`
+	}
+
+	cucumberTag struct {
+		Name string `json:"name"`
+	}
+
+	cucumberStep struct {
+		Keyword    string               `json:"keyword"`
+		Name       string               `json:"name"`
+		Line       int                  `json:"line"`
+		Match      *cucumberMatch       `json:"match,omitempty"`
+		Result     cucumberResult       `json:"result"`
+		Rows       []cucumberRow        `json:"rows,omitempty"`
+		DocString  *cucumberDocString   `json:"doc_string,omitempty"`
+		Embeddings []*cucumberEmbedding `json:"embeddings,omitempty"`
+		Output     []string             `json:"output,omitempty"`
+	}
+
+	cucumberHook struct {
+		Result     cucumberResult       `json:"result"`
+		Embeddings []*cucumberEmbedding `json:"embeddings"`
+	}
+
+	cucumberMatch struct {
+		Location string `json:"location"`
+	}
+
+	cucumberResult struct {
+		Status string `json:"status"`
+		// Duration is in nanoseconds
+		Duration     int64  `json:"duration"`
+		ErrorMessage string `json:"error_message,omitempty"`
+	}
+
+	cucumberRow struct {
+		Cells []string `json:"cells"`
+	}
+
+	cucumberDocString struct {
+		ContentType string `json:"content_type,omitempty"`
+		Value       string `json:"value"`
+	}
+
+	// cucumberEmbedding is an attachment, whose data is encoded with base64 like []byte in JSON
+	cucumberEmbedding struct {
+		MimeType string `json:"mime_type"`
+		Data     []byte `json:"data"`
+		Name     string `json:"name,omitempty"`
+	}
+)
+
+var cucumberIDSeparators = regexp.MustCompile(`\s+`)
+
+// GenerateCucumberJSONReport writes the run as a Cucumber JSON report to the file at path, so tools reading the
+// format of the other Cucumber implementations, such as report plugins of CI servers, can show the results
+func GenerateCucumberJSONReport(path string, result *models.RunResult) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create cucumber json report %s, error=%w", path, err)
+	}
+	defer file.Close()
+
+	return WriteCucumberJSONReport(file, result)
+}
+
+// WriteCucumberJSONReport writes the run as a Cucumber JSON report with a feature for every feature file and an
+// element for every scenario. The attachments of the steps are their embeddings, and the attachments of the
+// scenario hooks are the embeddings of an after hook of the scenario.
+func WriteCucumberJSONReport(writer io.Writer, result *models.RunResult) error {
+	features := make([]*cucumberFeature, 0)
+	for _, feature := range result.Features() {
+		report := &cucumberFeature{
+			Uri:         feature.Uri,
+			ID:          cucumberID(feature.Name),
+			Keyword:     "Feature",
+			Name:        feature.Name,
+			Description: feature.Description,
+			Elements:    make([]*cucumberElement, 0, len(feature.Scenarios)),
+		}
+		for _, scenario := range feature.Scenarios {
+			report.Elements = append(report.Elements, newCucumberElement(report.ID, scenario))
+		}
+		features = append(features, report)
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(features); err != nil {
+		return fmt.Errorf("could not write cucumber json report, error=%w", err)
+	}
+
+	return nil
+}
+
+func newCucumberElement(featureID string, scenario *models.ScenarioResult) *cucumberElement {
+	keyword := "Scenario"
+	if len(scenario.Examples) > 0 {
+		keyword = "Scenario Outline"
+	}
+	element := &cucumberElement{
+		ID:          featureID + ";" + cucumberID(scenario.Name),
+		Keyword:     keyword,
+		Type:        "scenario",
+		Name:        scenario.Name,
+		Description: scenario.Description,
+		Line:        scenario.Line,
+		Steps:       make([]*cucumberStep, 0, len(scenario.Steps)),
+	}
+	for _, tag := range scenario.Tags {
+		element.Tags = append(element.Tags, cucumberTag{Name: tag})
+	}
+	for _, step := range scenario.Steps {
+		element.Steps = append(element.Steps, newCucumberStep(step))
+	}
+	if len(scenario.Attachments) > 0 {
+		element.After = append(element.After, &cucumberHook{
+			Result:     cucumberResult{Status: string(models.StatusPassed)},
+			Embeddings: cucumberEmbeddings(scenario.Attachments),
+		})
+	}
+
+	return element
+}
+
+func newCucumberStep(step *models.StepResult) *cucumberStep {
+	report := &cucumberStep{
+		Keyword: step.Keyword,
+		Name:    step.Text,
+		Line:    step.Line,
+		Result: cucumberResult{
+			Status:       string(step.Status),
+			Duration:     step.Duration.Nanoseconds(),
+			ErrorMessage: step.Error,
+		},
+		Embeddings: cucumberEmbeddings(step.Attachments),
+		Output:     step.Logs,
+	}
+	if len(step.Definition) > 0 {
+		report.Match = &cucumberMatch{Location: step.Definition}
+	}
+	for _, row := range step.DataTable {
+		report.Rows = append(report.Rows, cucumberRow{Cells: row})
+	}
+	if step.DocString != nil {
+		report.DocString = &cucumberDocString{ContentType: step.DocString.MediaType, Value: step.DocString.Content}
+	}
+
+	return report
+}
+
+func cucumberEmbeddings(attachments []*models.Attachment) []*cucumberEmbedding {
+	embeddings := make([]*cucumberEmbedding, 0, len(attachments))
+	for _, attachment := range attachments {
+		embeddings = append(embeddings, &cucumberEmbedding{
+			MimeType: attachment.MediaType,
+			Data:     attachment.Data,
+			Name:     attachment.Name,
+		})
+	}
+
+	return embeddings
+}
+
+// cucumberID returns the lower case name with dashes instead of white space, like the ids of Cucumber
+func cucumberID(name string) string {
+	return cucumberIDSeparators.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+}
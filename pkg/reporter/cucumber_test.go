@@ -0,0 +1,97 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCucumberJSONReport(t *testing.T) {
+	t.Run("should write a feature for every feature file with the embeddings of the attachments", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		err := WriteCucumberJSONReport(buffer, &models.RunResult{
+			Scenarios: []*models.ScenarioResult{
+				{
+					Name:    "Eat apples",
+					Uri:     "apples.feature",
+					Feature: "Apples",
+					Line:    3,
+					Tags:    []string{"@smoke"},
+					Status:  models.StatusFailed,
+					Steps: []*models.StepResult{
+						{Keyword: "Given ", Text: "I have 3 apples", Line: 4, Definition: "^I have {int} apples$",
+							Status: models.StatusFailed, Duration: time.Millisecond, Error: "no apples",
+							Attachments: []*models.Attachment{{Name: "screen", MediaType: "image/png", Data: []byte("png")}},
+							Logs:        []string{"counting"}},
+					},
+					Attachments: []*models.Attachment{{Name: "log", MediaType: "text/plain", Data: []byte("closed")}},
+				},
+			},
+		})
+
+		require.Nil(t, err)
+		require.JSONEq(t, `[{
+  "uri": "apples.feature",
+  "id": "apples",
+  "keyword": "Feature",
+  "name": "Apples",
+  "description": "",
+  "elements": [{
+    "id": "apples;eat-apples",
+    "keyword": "Scenario",
+    "type": "scenario",
+    "name": "Eat apples",
+    "description": "",
+    "line": 3,
+    "tags": [{"name": "@smoke"}],
+    "steps": [{
+      "keyword": "Given ",
+      "name": "I have 3 apples",
+      "line": 4,
+      "match": {"location": "^I have {int} apples$"},
+      "result": {"status": "failed", "duration": 1000000, "error_message": "no apples"},
+      "embeddings": [{"mime_type": "image/png", "data": "cG5n", "name": "screen"}],
+      "output": ["counting"]
+    }],
+    "after": [{
+      "result": {"status": "passed", "duration": 0},
+      "embeddings": [{"mime_type": "text/plain", "data": "Y2xvc2Vk", "name": "log"}]
+    }]
+  }]
+}]`, buffer.String())
+	})
+	t.Run("should write scenario outlines with their tables and doc strings", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		err := WriteCucumberJSONReport(buffer, &models.RunResult{
+			Scenarios: []*models.ScenarioResult{
+				{
+					Name:     "Eat pears",
+					Uri:      "pears.feature",
+					Examples: []models.ExampleValue{{Name: "count", Value: "2"}},
+					Steps: []*models.StepResult{
+						{Keyword: "Given ", Text: "I have pears", DataTable: [][]string{{"count"}, {"2"}},
+							Status: models.StatusPassed},
+						{Keyword: "Then ", Text: "the basket is", DocString: &models.DocString{Content: "{}"},
+							Status: models.StatusSkipped},
+					},
+				},
+			},
+		})
+
+		require.Nil(t, err)
+		var features []*cucumberFeature
+		require.Nil(t, json.Unmarshal(buffer.Bytes(), &features))
+		element := features[0].Elements[0]
+		require.Equal(t, "Scenario Outline", element.Keyword)
+		require.Equal(t, []cucumberRow{{Cells: []string{"count"}}, {Cells: []string{"2"}}}, element.Steps[0].Rows)
+		require.Equal(t, &cucumberDocString{Value: "{}"}, element.Steps[1].DocString)
+		require.Equal(t, "skipped", element.Steps[1].Result.Status)
+		require.Nil(t, element.Steps[0].Embeddings)
+	})
+}
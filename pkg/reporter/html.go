@@ -0,0 +1,433 @@
+package reporter
+
+import (
+	"cmp"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+var (
+	//go:embed html.tmpl
+	htmlTemplateText string
+
+	htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+		"duration":   formatDuration,
+		"passRate":   passRate,
+		"percent":    func(value float64) string { return fmt.Sprintf("%.2f%%", value) },
+		"attachment": newHTMLAttachment,
+		"bytes":      formatBytes,
+	}).Parse(htmlTemplateText))
+
+	// htmlStatuses are the statuses the report counts and filters by, in the order of the summary bar
+	htmlStatuses = []models.Status{models.StatusPassed, models.StatusFailed, models.StatusUndefined,
+		models.StatusSkipped}
+
+	// htmlImageTypes are the media types of the attachments shown as images. Other images, such as SVG which can
+	// contain scripts, are downloaded.
+	htmlImageTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp", "image/bmp"}
+
+	// htmlLinkTypes are the media types the data URLs of downloaded attachments keep. Others are downloaded as
+	// application/octet-stream, so a media type of a test can not make the browser render the data, such as HTML.
+	htmlLinkTypes = []string{"application/pdf", "application/zip", "application/gzip", "application/xml",
+		"application/octet-stream", "audio/mpeg", "audio/ogg", "audio/wav", "video/mp4", "video/webm", "video/ogg"}
+)
+
+const (
+	// htmlSlowest is the number of scenarios and steps the slowest charts show
+	htmlSlowest = 10
+	// HistoryRuns is the number of the last runs the trend charts of the HTML report show, which are the runs kept
+	// in a history directory
+	HistoryRuns = models.HistoryRuns
+
+	// AutoTheme follows the color scheme of the browser
+	AutoTheme  = "auto"
+	LightTheme = "light"
+	DarkTheme  = "dark"
+
+	defaultHTMLTitle = "Cacik Report"
+)
+
+type (
+	// HTMLReportOptions brand the HTML report and control its size
+	HTMLReportOptions struct {
+		// Title is the title of the page, Cacik Report if it is empty
+		Title string
+		// Logo is the URL of an image shown before the title, such as a path relative to the report
+		Logo string
+		// Theme is AutoTheme, the default, LightTheme or DarkTheme
+		Theme string
+		// Palette are the colors of the statuses, such as the palette of the console output, see PaletteByName. The
+		// colors of DefaultPalette are used if it is nil.
+		Palette *Palette
+		// EmbedAttachments writes the attachments into the page, showing images and text. Otherwise attachments are
+		// listed with their media type and size only, which keeps the reports of runs with screenshots small.
+		EmbedAttachments bool
+		// EmbedResult writes the run result as JSON into a script element with the id cacik-result, so the report
+		// can be downloaded as models.LoadRunResult reads it and tools can read the result from the page
+		EmbedResult bool
+		// History, such as the one read with models.LoadHistory, adds pass rate and duration charts of the last
+		// HistoryRuns runs
+		History []*models.RunSummary
+	}
+
+	htmlReport struct {
+		HTMLReportOptions
+		Result    *models.RunResult
+		Counts    []htmlCount
+		Passed    int
+		Scenarios []htmlScenario
+		// Lanes are the scenarios executed by every worker on the timeline of the run
+		Lanes            []htmlLane
+		SlowestScenarios []htmlBar
+		SlowestSteps     []htmlBar
+		// SlowestDefinitions are the step definitions the run spent the most time in
+		SlowestDefinitions []*models.StepTiming
+		// Trend are the last runs of the history
+		Trend []htmlTrend
+		// Environment is the environment of the run
+		Environment []metaProperty
+		// Leaking are the scenarios leaving goroutines running and Allocating the scenarios allocating the most
+		// bytes, if the run measured diagnostics
+		Leaking    []htmlScenario
+		Allocating []htmlScenario
+	}
+
+	htmlCount struct {
+		Status models.Status
+		Count  int
+	}
+
+	htmlScenario struct {
+		*models.ScenarioResult
+		// ID is the anchor of the scenario, so a link to the report can point at it
+		ID string
+		// Search is the lower case text the search box matches: the name, the feature, the tags and the errors
+		Search string
+	}
+
+	htmlLane struct {
+		Worker int
+		Bars   []htmlBar
+	}
+
+	// htmlBar is a bar of a chart linking to the scenario with the ID. Left and Width are percentages of the run on
+	// the timeline, and Width is the percentage of the slowest bar on the slowest charts.
+	htmlBar struct {
+		ID       string
+		Label    string
+		Status   models.Status
+		Duration time.Duration
+		Left     float64
+		Width    float64
+	}
+
+	// htmlTrend is a run of the trend charts. PassRate and Height, the duration of the run as a percentage of the
+	// slowest run, are the heights of its bars.
+	htmlTrend struct {
+		*models.RunSummary
+		PassRate float64
+		Height   float64
+	}
+
+	// htmlAttachment is an attachment with the image or the text shown in the report if attachments are embedded,
+	// and the data URL it is downloaded from otherwise
+	htmlAttachment struct {
+		*models.Attachment
+		Size  int
+		Image template.URL
+		Text  string
+		Link  template.URL
+	}
+)
+
+// GenerateHTMLReport writes a single HTML page of the run to the file at path. Scenarios can be searched by name,
+// tag and error text, filtered by status and linked to with the anchor of their location, such as
+// report.html#features/login.feature:12.
+func GenerateHTMLReport(path string, result *models.RunResult, options HTMLReportOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create html report %s, error=%w", path, err)
+	}
+	defer file.Close()
+
+	return WriteHTMLReport(file, result, options)
+}
+
+func WriteHTMLReport(writer io.Writer, result *models.RunResult, options HTMLReportOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	if len(options.Title) == 0 {
+		options.Title = defaultHTMLTitle
+	}
+	if len(options.Theme) == 0 {
+		options.Theme = AutoTheme
+	}
+	if options.Palette == nil {
+		options.Palette = palettes[DefaultPalette]
+	}
+
+	report := htmlReport{
+		HTMLReportOptions: options,
+		Result:            result,
+		Passed:            result.Count(models.StatusPassed),
+		Scenarios:         make([]htmlScenario, 0, len(result.Scenarios)),
+	}
+	for _, status := range htmlStatuses {
+		report.Counts = append(report.Counts, htmlCount{Status: status, Count: result.Count(status)})
+	}
+
+	ids := make(map[string]int)
+	for _, scenario := range result.Scenarios {
+		id := scenarioAnchor(scenario)
+		// scenarios without a line, such as the ones of a remote executor, get a suffix to keep anchors unique
+		if ids[id]++; ids[id] > 1 {
+			id = fmt.Sprintf("%s-%d", id, ids[id])
+		}
+		report.Scenarios = append(report.Scenarios, htmlScenario{
+			ScenarioResult: scenario,
+			ID:             id,
+			Search:         scenarioSearchText(scenario),
+		})
+	}
+	report.Lanes = timelineLanes(result, report.Scenarios)
+	report.SlowestScenarios, report.SlowestSteps = slowest(report.Scenarios)
+	report.SlowestDefinitions = result.StepTimings()
+	if len(report.SlowestDefinitions) > htmlSlowest {
+		report.SlowestDefinitions = report.SlowestDefinitions[:htmlSlowest]
+	}
+	report.Trend = trend(options.History)
+	report.Environment = metaProperties(result.Meta)
+	report.Leaking, report.Allocating = diagnosed(report.Scenarios)
+
+	if err := htmlTemplate.Execute(writer, report); err != nil {
+		return fmt.Errorf("could not write html report, error=%w", err)
+	}
+
+	return nil
+}
+
+// Validate returns an error if the theme is not AutoTheme, LightTheme or DarkTheme
+func (o HTMLReportOptions) Validate() error {
+	switch o.Theme {
+	case "", AutoTheme, LightTheme, DarkTheme:
+		return nil
+	default:
+		return fmt.Errorf("unknown html theme %s, use %s, %s or %s", o.Theme, AutoTheme, LightTheme, DarkTheme)
+	}
+}
+
+// newHTMLAttachment returns the attachment as it is shown in the report
+func newHTMLAttachment(embed bool, attachment *models.Attachment) htmlAttachment {
+	shown := htmlAttachment{Attachment: attachment, Size: len(attachment.Data)}
+	if !embed {
+		return shown
+	}
+
+	// data URLs are marked safe, so only media types of the allow-lists are written into them
+	data := base64.StdEncoding.EncodeToString(attachment.Data)
+	switch {
+	case slices.Contains(htmlImageTypes, attachment.MediaType):
+		shown.Image = template.URL("data:" + attachment.MediaType + ";base64," + data)
+	case strings.HasPrefix(attachment.MediaType, "text/"), attachment.MediaType == "application/json":
+		shown.Text = string(attachment.Data)
+	case slices.Contains(htmlLinkTypes, attachment.MediaType):
+		shown.Link = template.URL("data:" + attachment.MediaType + ";base64," + data)
+	default:
+		shown.Link = template.URL("data:application/octet-stream;base64," + data)
+	}
+
+	return shown
+}
+
+// scenarioAnchor returns the location of the scenario as an anchor, such as features/login.feature:12
+func scenarioAnchor(scenario *models.ScenarioResult) string {
+	anchor := scenario.Uri
+	if scenario.Line > 0 {
+		anchor = fmt.Sprintf("%s:%d", scenario.Uri, scenario.Line)
+	}
+
+	return strings.Join(strings.Fields(anchor), "-")
+}
+
+func scenarioSearchText(scenario *models.ScenarioResult) string {
+	parts := []string{scenario.Name, scenario.Uri}
+	parts = append(parts, scenario.Tags...)
+	for _, step := range scenario.Steps {
+		if len(step.Error) > 0 {
+			parts = append(parts, step.Error)
+		}
+	}
+	for _, hook := range scenario.Hooks {
+		if len(hook.Error) > 0 {
+			parts = append(parts, hook.Error)
+		}
+	}
+
+	return strings.ToLower(strings.Join(parts, "\n"))
+}
+
+// timelineLanes returns the scenarios of every worker placed on the duration of the run. There is no timeline if the
+// run or its scenarios do not have start times, such as results written by older versions.
+func timelineLanes(result *models.RunResult, scenarios []htmlScenario) []htmlLane {
+	if result.StartedAt.IsZero() || result.Duration <= 0 {
+		return nil
+	}
+
+	lanes := make([]htmlLane, 0)
+	workers := make(map[int]int)
+	for _, scenario := range scenarios {
+		if scenario.StartedAt.IsZero() {
+			continue
+		}
+		lane, ok := workers[scenario.Worker]
+		if !ok {
+			lane = len(lanes)
+			workers[scenario.Worker] = lane
+			lanes = append(lanes, htmlLane{Worker: scenario.Worker})
+		}
+		left := percentOf(scenario.StartedAt.Sub(result.StartedAt), result.Duration)
+		lanes[lane].Bars = append(lanes[lane].Bars, htmlBar{
+			ID:       scenario.ID,
+			Label:    scenario.Name,
+			Status:   scenario.Status,
+			Duration: scenario.Duration,
+			Left:     left,
+			Width:    min(percentOf(scenario.Duration, result.Duration), 100-left),
+		})
+	}
+	sort.Slice(lanes, func(i, j int) bool {
+		return lanes[i].Worker < lanes[j].Worker
+	})
+
+	return lanes
+}
+
+// slowest returns the slowest scenarios and steps, slowest first
+func slowest(scenarios []htmlScenario) ([]htmlBar, []htmlBar) {
+	scenarioBars := make([]htmlBar, 0, len(scenarios))
+	stepBars := make([]htmlBar, 0)
+	for _, scenario := range scenarios {
+		scenarioBars = append(scenarioBars, htmlBar{
+			ID:       scenario.ID,
+			Label:    scenario.Name,
+			Status:   scenario.Status,
+			Duration: scenario.Duration,
+		})
+		for _, step := range scenario.Steps {
+			stepBars = append(stepBars, htmlBar{
+				ID:       scenario.ID,
+				Label:    fmt.Sprintf("%s%s (%s)", step.Keyword, step.Text, scenario.Name),
+				Status:   step.Status,
+				Duration: step.Duration,
+			})
+		}
+	}
+
+	return slowestBars(scenarioBars), slowestBars(stepBars)
+}
+
+// slowestBars returns the htmlSlowest longest bars with widths relative to the longest one
+func slowestBars(bars []htmlBar) []htmlBar {
+	sort.SliceStable(bars, func(i, j int) bool {
+		return bars[i].Duration > bars[j].Duration
+	})
+	if len(bars) > htmlSlowest {
+		bars = bars[:htmlSlowest]
+	}
+	if len(bars) == 0 || bars[0].Duration <= 0 {
+		return nil
+	}
+	for i := range bars {
+		bars[i].Width = percentOf(bars[i].Duration, bars[0].Duration)
+	}
+
+	return bars
+}
+
+// diagnosed returns the scenarios leaving goroutines running, most goroutines first, and the htmlSlowest scenarios
+// allocating the most bytes
+func diagnosed(scenarios []htmlScenario) ([]htmlScenario, []htmlScenario) {
+	leaking := make([]htmlScenario, 0)
+	allocating := make([]htmlScenario, 0)
+	for _, scenario := range scenarios {
+		if scenario.Diagnostics == nil {
+			continue
+		}
+		if scenario.Diagnostics.LeakedGoroutines() > 0 {
+			leaking = append(leaking, scenario)
+		}
+		allocating = append(allocating, scenario)
+	}
+	sort.SliceStable(leaking, func(i, j int) bool {
+		return leaking[i].Diagnostics.LeakedGoroutines() > leaking[j].Diagnostics.LeakedGoroutines()
+	})
+	sort.SliceStable(allocating, func(i, j int) bool {
+		return allocating[i].Diagnostics.AllocatedBytes > allocating[j].Diagnostics.AllocatedBytes
+	})
+	if len(allocating) > htmlSlowest {
+		allocating = allocating[:htmlSlowest]
+	}
+
+	return leaking, allocating
+}
+
+// formatBytes returns the number of bytes with a binary unit, such as 1.5 MiB
+func formatBytes(bytes int64) string {
+	value, unit := float64(bytes), 0
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	for math.Abs(value) >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	return fmt.Sprintf("%.1f %s", value, units[unit])
+}
+
+// trend returns the last HistoryRuns runs of the history. A single run is not a trend, so it returns nothing for
+// shorter histories.
+func trend(history []*models.RunSummary) []htmlTrend {
+	if len(history) < 2 {
+		return nil
+	}
+	history = history[max(0, len(history)-HistoryRuns):]
+
+	slowestRun := slices.MaxFunc(history, func(a, b *models.RunSummary) int {
+		return cmp.Compare(a.Duration, b.Duration)
+	}).Duration
+	runs := make([]htmlTrend, 0, len(history))
+	for _, summary := range history {
+		run := htmlTrend{RunSummary: summary}
+		if summary.Scenarios > 0 {
+			run.PassRate = float64(summary.Passed) / float64(summary.Scenarios) * 100
+		}
+		if slowestRun > 0 {
+			run.Height = percentOf(summary.Duration, slowestRun)
+		}
+		runs = append(runs, run)
+	}
+
+	return runs
+}
+
+// percentOf returns the part as a percentage of the whole between 0 and 100
+func percentOf(part, whole time.Duration) float64 {
+	return max(0, min(100, float64(part)/float64(whole)*100))
+}
@@ -0,0 +1,354 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteHTMLReport(t *testing.T) {
+	t.Run("should write the scenarios with their anchors and the text the search matches", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		result := &models.RunResult{
+			Duration: 2 * time.Second,
+			Scenarios: []*models.ScenarioResult{
+				{Name: "Eat apples", Uri: "features/apples.feature", Line: 3, Tags: []string{"@smoke"},
+					Status: models.StatusPassed, Steps: []*models.StepResult{
+						{Keyword: "Given ", Text: "I have 3 apples", Status: models.StatusPassed},
+					}},
+				{Name: "Eat <pears>", Uri: "features/pears.feature", Line: 7, Status: models.StatusFailed,
+					Steps: []*models.StepResult{
+						{Keyword: "Then ", Text: "I am full", Status: models.StatusFailed, Error: "Not Full"},
+					}},
+				{Name: "Remote", Uri: "features/pears.feature", Status: models.StatusSkipped},
+				{Name: "Remote", Uri: "features/pears.feature", Status: models.StatusSkipped},
+			},
+		}
+
+		require.Nil(t, WriteHTMLReport(buffer, result, HTMLReportOptions{}))
+
+		report := buffer.String()
+		require.Contains(t, report, `<input id="search" type="search"`)
+		require.Contains(t, report, `<label class="filter"><input type="checkbox" value="failed" checked> failed</label>`)
+		require.Contains(t, report, `<span class="status failed">1 failed</span>`)
+		require.Contains(t, report, `<span>pass rate 25.0%</span>`)
+		require.Contains(t, report, `id="features/apples.feature:3" data-status="passed" `+
+			"data-search=\"eat apples\nfeatures/apples.feature\n@smoke\"")
+		require.Contains(t, report, `href="#features%2fapples.feature%3a3"`)
+		require.Contains(t, report, `Eat &lt;pears&gt;`)
+		require.Contains(t, report, "data-search=\"eat &lt;pears&gt;\nfeatures/pears.feature\nnot full\"")
+		require.Contains(t, report, `<pre>Not Full</pre>`)
+		require.Contains(t, report, `id="features/pears.feature"`)
+		require.Contains(t, report, `id="features/pears.feature-2"`)
+	})
+}
+
+func TestWriteHTMLReportCharts(t *testing.T) {
+	startedAt := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+	scenario := func(name string, worker int, start, duration time.Duration,
+		steps ...*models.StepResult) *models.ScenarioResult {
+		return &models.ScenarioResult{Name: name, Uri: "features/fruits.feature", Line: int(start / time.Second),
+			Status: models.StatusPassed, StartedAt: startedAt.Add(start), Worker: worker, Duration: duration,
+			Steps: steps}
+	}
+
+	t.Run("should place the scenarios of every worker on the timeline of the run", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		result := &models.RunResult{StartedAt: startedAt, Duration: 4 * time.Second, Scenarios: []*models.ScenarioResult{
+			scenario("Eat apples", 1, time.Second, time.Second),
+			scenario("Eat pears", 0, 2*time.Second, 3*time.Second),
+		}}
+
+		require.Nil(t, WriteHTMLReport(buffer, result, HTMLReportOptions{}))
+
+		report := buffer.String()
+		require.Contains(t, report, "<summary>Timeline</summary>")
+		require.Less(t, strings.Index(report, "worker 0"), strings.Index(report, "worker 1"))
+		require.Contains(t, report, `href="#features%2ffruits.feature%3a1" title="Eat apples 1s" `+
+			`style="left: 25.00%; width: 25.00%"`)
+		require.Contains(t, report, `style="left: 50.00%; width: 50.00%"`)
+	})
+
+	t.Run("should not write the timeline without start times", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		result := &models.RunResult{Duration: time.Second, Scenarios: []*models.ScenarioResult{
+			{Name: "Eat apples", Status: models.StatusPassed, Duration: time.Second},
+		}}
+
+		require.Nil(t, WriteHTMLReport(buffer, result, HTMLReportOptions{}))
+
+		require.NotContains(t, buffer.String(), "<summary>Timeline</summary>")
+		require.Contains(t, buffer.String(), "<summary>Slowest scenarios</summary>")
+	})
+
+	t.Run("should chart the slowest scenarios and steps relative to the slowest one", func(t *testing.T) {
+		scenarios, steps := slowest([]htmlScenario{
+			{ScenarioResult: scenario("Eat apples", 0, 0, time.Second,
+				&models.StepResult{Keyword: "Given ", Text: "I have apples", Duration: 250 * time.Millisecond}),
+				ID: "apples"},
+			{ScenarioResult: scenario("Eat pears", 0, 0, 4*time.Second,
+				&models.StepResult{Keyword: "When ", Text: "I eat pears", Duration: time.Second}), ID: "pears"},
+		})
+
+		require.Equal(t, []string{"pears", "apples"}, []string{scenarios[0].ID, scenarios[1].ID})
+		require.Equal(t, []float64{100, 25}, []float64{scenarios[0].Width, scenarios[1].Width})
+		require.Equal(t, "When I eat pears (Eat pears)", steps[0].Label)
+		require.Equal(t, 25.0, steps[1].Width)
+	})
+
+	t.Run("should keep the slowest scenarios only", func(t *testing.T) {
+		scenarios := make([]htmlScenario, 0)
+		for i := 1; i <= htmlSlowest+5; i++ {
+			scenarios = append(scenarios, htmlScenario{ScenarioResult: scenario("Eat", 0, 0, time.Duration(i))})
+		}
+
+		bars, steps := slowest(scenarios)
+
+		require.Len(t, bars, htmlSlowest)
+		require.Equal(t, time.Duration(htmlSlowest+5), bars[0].Duration)
+		require.Nil(t, steps)
+	})
+}
+
+func TestWriteHTMLReportTrend(t *testing.T) {
+	startedAt := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should chart the pass rate and duration of the runs in the history", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		history := []*models.RunSummary{
+			{StartedAt: startedAt, Duration: 4 * time.Second, Scenarios: 4, Passed: 3},
+			{StartedAt: startedAt.Add(time.Hour), Duration: time.Second, Scenarios: 2, Passed: 2},
+		}
+
+		require.Nil(t, WriteHTMLReport(buffer, &models.RunResult{}, HTMLReportOptions{History: history}))
+
+		report := buffer.String()
+		require.Contains(t, report, "<summary>Trend of the last 2 runs</summary>")
+		require.Contains(t, report, `<span title="2024-03-02 10:00 75.0%" style="height: 75.00%"></span>`)
+		require.Contains(t, report, `<span title="2024-03-02 11:00 1s" style="height: 25.00%"></span>`)
+	})
+
+	t.Run("should keep the last runs of the history", func(t *testing.T) {
+		history := make([]*models.RunSummary, 0)
+		for i := 0; i < HistoryRuns+5; i++ {
+			history = append(history, &models.RunSummary{StartedAt: startedAt.Add(time.Duration(i) * time.Hour)})
+		}
+
+		runs := trend(history)
+
+		require.Len(t, runs, HistoryRuns)
+		require.Equal(t, history[5], runs[0].RunSummary)
+		require.Zero(t, runs[0].PassRate)
+	})
+
+	t.Run("should not chart a single run", func(t *testing.T) {
+		require.Nil(t, trend([]*models.RunSummary{{StartedAt: startedAt}}))
+	})
+}
+
+func TestWriteHTMLReportOptions(t *testing.T) {
+	result := &models.RunResult{Scenarios: []*models.ScenarioResult{
+		{Name: "Eat apples", Status: models.StatusPassed, Steps: []*models.StepResult{
+			{Text: "I have 3 apples", Status: models.StatusPassed, Attachments: []*models.Attachment{
+				{Name: "basket.png", MediaType: "image/png", Data: []byte{1, 2}},
+				{Name: "order", MediaType: "application/json", Data: []byte(`{"apples":3}`)},
+				{Name: "receipt.pdf", MediaType: "application/pdf", Data: []byte{1}},
+			}},
+		}},
+	}}
+
+	t.Run("should brand the report with the title, logo and theme", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		require.Nil(t, WriteHTMLReport(buffer, result, HTMLReportOptions{Title: "Checkout", Logo: "logo.svg",
+			Theme: DarkTheme}))
+
+		report := buffer.String()
+		require.Contains(t, report, "<title>Checkout</title>")
+		require.Contains(t, report, `<img class="logo" src="logo.svg" alt="">`)
+		require.Contains(t, report, `<body class="dark">`)
+		require.Contains(t, report, "--passed: #2e7d32; --failed: #c62828;")
+	})
+
+	t.Run("should color the statuses with the palette", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		palette, err := PaletteByName(ColorblindPalette)
+		require.Nil(t, err)
+
+		require.Nil(t, WriteHTMLReport(buffer, result, HTMLReportOptions{Palette: palette}))
+
+		require.Contains(t, buffer.String(), "--passed: #0072b2; --failed: #d55e00;")
+	})
+
+	t.Run("should follow the browser without a theme", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		require.Nil(t, WriteHTMLReport(buffer, result, HTMLReportOptions{}))
+
+		require.Contains(t, buffer.String(), "<title>Cacik Report</title>")
+		require.Contains(t, buffer.String(), `<body class="auto">`)
+		require.NotContains(t, buffer.String(), `class="logo"`)
+	})
+
+	t.Run("should embed the attachments", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		require.Nil(t, WriteHTMLReport(buffer, result, HTMLReportOptions{EmbedAttachments: true}))
+
+		report := buffer.String()
+		require.Contains(t, report, `<img src="data:image/png;base64,AQI=" alt="basket.png">`)
+		require.Contains(t, report, `<pre>{&#34;apples&#34;:3}</pre>`)
+		require.Contains(t, report, `<a href="data:application/pdf;base64,AQ==" download="receipt.pdf">receipt.pdf</a>`)
+	})
+
+	t.Run("should list the attachments without embedding them", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		require.Nil(t, WriteHTMLReport(buffer, result, HTMLReportOptions{}))
+
+		report := buffer.String()
+		require.Contains(t, report, `<div class="attachment">basket.png (image/png, 2 bytes)`)
+		require.NotContains(t, report, "base64")
+		require.NotContains(t, report, "apples&#34;")
+	})
+
+	t.Run("should download attachments of other media types as octet streams", func(t *testing.T) {
+		attachments := []*models.Attachment{
+			{Name: "page.xhtml", MediaType: "application/xhtml+xml", Data: []byte{1}},
+			{Name: "logo.svg", MediaType: "image/svg+xml", Data: []byte{1}},
+		}
+		for _, attachment := range attachments {
+			shown := newHTMLAttachment(true, attachment)
+
+			require.Empty(t, shown.Image)
+			require.Equal(t, template.URL("data:application/octet-stream;base64,AQ=="), shown.Link)
+		}
+	})
+
+	t.Run("should return error if the theme is unknown", func(t *testing.T) {
+		err := WriteHTMLReport(&bytes.Buffer{}, result, HTMLReportOptions{Theme: "blue"})
+
+		require.EqualError(t, err, "unknown html theme blue, use auto, light or dark")
+	})
+}
+
+func TestWriteHTMLReportResult(t *testing.T) {
+	result := &models.RunResult{
+		StartedAt: time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC),
+		Duration:  time.Second,
+		Scenarios: []*models.ScenarioResult{
+			{Name: "Eat </script> apples", Status: models.StatusFailed, Steps: []*models.StepResult{
+				{Text: "I have 3 apples", Status: models.StatusFailed, Error: "not <enough>"},
+			}},
+		},
+	}
+
+	t.Run("should embed the run result which can be read back from the page", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		require.Nil(t, WriteHTMLReport(buffer, result, HTMLReportOptions{EmbedResult: true}))
+
+		report := buffer.String()
+		require.Contains(t, report, `<button id="download"`)
+		start := strings.Index(report, `<script type="application/json" id="cacik-result">`)
+		require.NotEqual(t, -1, start)
+		content := report[start+len(`<script type="application/json" id="cacik-result">`):]
+		content = content[:strings.Index(content, "</script>")]
+		loaded := &models.RunResult{}
+		require.Nil(t, json.Unmarshal([]byte(content), loaded))
+		require.Equal(t, result, loaded)
+	})
+
+	t.Run("should not embed the run result by default", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		require.Nil(t, WriteHTMLReport(buffer, result, HTMLReportOptions{}))
+
+		require.NotContains(t, buffer.String(), "cacik-result\">")
+		require.NotContains(t, buffer.String(), `<button id="download"`)
+	})
+}
+
+func TestWriteHTMLReportEnvironment(t *testing.T) {
+	t.Run("should write the environment of the run", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		result := &models.RunResult{Meta: &models.RunMeta{GoVersion: "go1.22.0", Hostname: "runner-1",
+			CI: map[string]string{"GITHUB_SHA": "abc123"}}}
+
+		require.Nil(t, WriteHTMLReport(buffer, result, HTMLReportOptions{}))
+
+		require.Contains(t, buffer.String(), "<tr><td>go</td><td>go1.22.0</td></tr>")
+		require.Contains(t, buffer.String(), "<tr><td>hostname</td><td>runner-1</td></tr>")
+		require.Contains(t, buffer.String(), "<tr><td>GITHUB_SHA</td><td>abc123</td></tr>")
+	})
+
+	t.Run("should not write the environment of results without it", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		require.Nil(t, WriteHTMLReport(buffer, &models.RunResult{}, HTMLReportOptions{}))
+
+		require.NotContains(t, buffer.String(), "<summary>Environment</summary>")
+	})
+}
+
+func TestWriteHTMLReportSlowSteps(t *testing.T) {
+	t.Run("should badge slow steps and list the slowest step definitions", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		result := &models.RunResult{Scenarios: []*models.ScenarioResult{
+			{Name: "Eat apples", Status: models.StatusPassed, Steps: []*models.StepResult{
+				{Keyword: "Given ", Text: "I have 3 apples", Definition: `^I have (\d+) apples$`,
+					Status: models.StatusPassed, Duration: 3 * time.Second, Slow: true},
+				{Keyword: "Given ", Text: "I have 1 apples", Definition: `^I have (\d+) apples$`,
+					Status: models.StatusPassed, Duration: time.Second},
+			}},
+		}}
+
+		require.Nil(t, WriteHTMLReport(buffer, result, HTMLReportOptions{}))
+
+		report := buffer.String()
+		require.Equal(t, 1, strings.Count(report, `<span class="badge slow">slow</span>`))
+		require.Contains(t, report, `<tr><td><code>^I have (\d&#43;) apples$</code></td><td>2</td>`+
+			`<td class="duration">4s</td>`)
+		require.Contains(t, report, `<td class="duration">2s</td></tr>`)
+	})
+}
+
+func TestWriteHTMLReportDiagnostics(t *testing.T) {
+	t.Run("should flag the scenarios leaving goroutines running and list the ones allocating most", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		result := &models.RunResult{Scenarios: []*models.ScenarioResult{
+			{Name: "Eat apples", Uri: "apples.feature", Line: 3, Status: models.StatusPassed,
+				Diagnostics: &models.Diagnostics{Allocations: 10, AllocatedBytes: 512, HeapBytes: -2048,
+					GoroutinesBefore: 3, GoroutinesAfter: 3}},
+			{Name: "Eat pears", Uri: "pears.feature", Line: 5, Status: models.StatusPassed,
+				Diagnostics: &models.Diagnostics{Allocations: 20, AllocatedBytes: 3 * 1024 * 1024, HeapBytes: 1536,
+					GoroutinesBefore: 3, GoroutinesAfter: 5}},
+		}}
+
+		require.Nil(t, WriteHTMLReport(buffer, result, HTMLReportOptions{}))
+
+		report := buffer.String()
+		require.Contains(t, report, `<details class="chart" open>
+  <summary>Diagnostics</summary>`)
+		require.Equal(t, 1, strings.Count(report, `<span class="badge leak">2 goroutines leaked</span>`))
+		require.Contains(t, report, `<tr><td><a href="#pears.feature%3a5">Eat pears</a></td><td>3.0 MiB</td>
+      <td>20</td><td>1.5 KiB</td>
+      <td>3 → 5</td></tr>`)
+		require.Contains(t, report, `<td>512 B</td>
+      <td>10</td><td>-2.0 KiB</td>`)
+	})
+
+	t.Run("should not write diagnostics of runs without them", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		require.Nil(t, WriteHTMLReport(buffer, &models.RunResult{}, HTMLReportOptions{}))
+
+		require.NotContains(t, buffer.String(), "<summary>Diagnostics</summary>")
+	})
+}
@@ -0,0 +1,127 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+type (
+	junitTestSuites struct {
+		XMLName  xml.Name          `xml:"testsuites"`
+		Name     string            `xml:"name,attr"`
+		Tests    int               `xml:"tests,attr"`
+		Failures int               `xml:"failures,attr"`
+		Skipped  int               `xml:"skipped,attr"`
+		Time     string            `xml:"time,attr"`
+		Suites   []*junitTestSuite `xml:"testsuite"`
+	}
+
+	junitTestSuite struct {
+		Name     string `xml:"name,attr"`
+		Tests    int    `xml:"tests,attr"`
+		Failures int    `xml:"failures,attr"`
+		Skipped  int    `xml:"skipped,attr"`
+		Time     string `xml:"time,attr"`
+		// Properties are the environment of the run
+		Properties *junitProperties `xml:"properties,omitempty"`
+		TestCases  []*junitTestCase `xml:"testcase"`
+	}
+
+	junitProperties struct {
+		Properties []metaProperty `xml:"property"`
+	}
+
+	junitTestCase struct {
+		Name      string        `xml:"name,attr"`
+		ClassName string        `xml:"classname,attr"`
+		Time      string        `xml:"time,attr"`
+		Failure   *junitFailure `xml:"failure,omitempty"`
+		Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	}
+
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+		Type    string `xml:"type,attr"`
+		Text    string `xml:",chardata"`
+	}
+
+	junitSkipped struct {
+		Message string `xml:"message,attr,omitempty"`
+		// Type is the skip cause of the scenario
+		Type string `xml:"type,attr,omitempty"`
+	}
+)
+
+// GenerateJUnitReport writes the run as a JUnit XML report to the file at path, with a test suite for every
+// feature file and a test case for every scenario, so CI servers can show the results
+func GenerateJUnitReport(path string, result *models.RunResult) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create junit report %s, error=%w", path, err)
+	}
+	defer file.Close()
+
+	return WriteJUnitReport(file, result)
+}
+
+func WriteJUnitReport(writer io.Writer, result *models.RunResult) error {
+	report := &junitTestSuites{
+		Name: "cacik",
+		Time: junitSeconds(result.Duration.Seconds()),
+	}
+	var properties *junitProperties
+	if meta := metaProperties(result.Meta); len(meta) > 0 {
+		properties = &junitProperties{Properties: meta}
+	}
+	for _, feature := range result.Features() {
+		suite := &junitTestSuite{Name: feature.Uri, Properties: properties}
+		report.Suites = append(report.Suites, suite)
+		seconds := 0.0
+		for _, scenario := range feature.Scenarios {
+			testCase := &junitTestCase{
+				Name:      scenario.Name,
+				ClassName: scenario.Uri,
+				Time:      junitSeconds(scenario.Duration.Seconds()),
+			}
+			switch scenario.Status {
+			case models.StatusFailed, models.StatusUndefined:
+				testCase.Failure = &junitFailure{
+					Message: scenario.FailureMessage(),
+					Type:    string(scenario.Status),
+					Text:    scenario.Gherkin(),
+				}
+				suite.Failures++
+				report.Failures++
+			case models.StatusSkipped:
+				testCase.Skipped = &junitSkipped{Message: scenario.Reason, Type: string(scenario.SkipCause)}
+				suite.Skipped++
+				report.Skipped++
+			}
+			suite.Tests++
+			report.Tests++
+			seconds += scenario.Duration.Seconds()
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+		suite.Time = junitSeconds(seconds)
+	}
+
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("could not write junit report, error=%w", err)
+	}
+	_, err := io.WriteString(writer, "\n")
+
+	return err
+}
+
+func junitSeconds(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
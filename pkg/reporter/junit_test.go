@@ -0,0 +1,73 @@
+package reporter
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteJUnitReport(t *testing.T) {
+	t.Run("should write a test suite for every feature file with its scenarios", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		err := WriteJUnitReport(buffer, &models.RunResult{
+			Duration: 2 * time.Second,
+			Scenarios: []*models.ScenarioResult{
+				{Name: "Eat apples", Uri: "apples.feature", Status: models.StatusPassed, Duration: time.Second},
+				{
+					Name:   "Eat pears",
+					Uri:    "pears.feature",
+					Status: models.StatusFailed,
+					Steps: []*models.StepResult{
+						{Keyword: "Given ", Text: "I have 3 pears", Status: models.StatusFailed, Error: "expected 3 < 2"},
+					},
+				},
+				{Name: "Sell pears", Uri: "pears.feature", Status: models.StatusSkipped,
+					SkipCause: models.SkipCauseHook, Reason: "shop is closed"},
+			},
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="cacik" tests="3" failures="1" skipped="1" time="2.000">
+  <testsuite name="apples.feature" tests="1" failures="0" skipped="0" time="1.000">
+    <testcase name="Eat apples" classname="apples.feature" time="1.000"></testcase>
+  </testsuite>
+  <testsuite name="pears.feature" tests="2" failures="1" skipped="1" time="0.000">
+    <testcase name="Eat pears" classname="pears.feature" time="0.000">
+      <failure message="expected 3 &lt; 2" type="failed">Scenario: Eat pears&#xA;  Given I have 3 pears&#xA;</failure>
+    </testcase>
+    <testcase name="Sell pears" classname="pears.feature" time="0.000">
+      <skipped message="shop is closed" type="hook"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, buffer.String())
+	})
+	t.Run("should write the environment of the run as properties of every test suite", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		err := WriteJUnitReport(buffer, &models.RunResult{
+			Meta: &models.RunMeta{GoVersion: "go1.22.0", OS: "linux", Arch: "amd64", TagExpression: "@smoke",
+				CI: map[string]string{"GITHUB_SHA": "abc123", "GITHUB_REF_NAME": "main"}},
+			Scenarios: []*models.ScenarioResult{
+				{Name: "Eat apples", Uri: "apples.feature", Status: models.StatusPassed},
+			},
+		})
+
+		require.Nil(t, err)
+		require.Contains(t, buffer.String(), `
+    <properties>
+      <property name="go" value="go1.22.0"></property>
+      <property name="os" value="linux"></property>
+      <property name="arch" value="amd64"></property>
+      <property name="tags" value="@smoke"></property>
+      <property name="GITHUB_REF_NAME" value="main"></property>
+      <property name="GITHUB_SHA" value="abc123"></property>
+    </properties>
+    <testcase name="Eat apples"`)
+	})
+}
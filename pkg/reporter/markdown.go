@@ -0,0 +1,220 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	slowestScenarioCount = 5
+)
+
+// GenerateMarkdownReport writes a GitHub flavored markdown summary of the run to the file at path.
+// The summary is suitable for a pull request comment or a CI job summary.
+func GenerateMarkdownReport(path string, result *models.RunResult) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create markdown report %s, error=%w", path, err)
+	}
+	defer file.Close()
+
+	return WriteMarkdownReport(file, result)
+}
+
+func WriteMarkdownReport(writer io.Writer, result *models.RunResult) error {
+	builder := &strings.Builder{}
+	total := len(result.Scenarios)
+	passed := result.Count(models.StatusPassed)
+
+	builder.WriteString("# Cacik Report\n\n")
+	builder.WriteString("| Scenarios | Passed | Failed | Undefined | Skipped | Pass rate | Duration |\n")
+	builder.WriteString("|---|---|---|---|---|---|---|\n")
+	builder.WriteString(fmt.Sprintf("| %d | %d | %d | %d | %d | %s | %s |\n",
+		total,
+		passed,
+		result.Count(models.StatusFailed),
+		result.Count(models.StatusUndefined),
+		result.Count(models.StatusSkipped),
+		passRate(passed, total),
+		formatDuration(result.Duration),
+	))
+
+	if groups := result.FailureGroups(); len(groups) > 0 {
+		builder.WriteString("\n## Failure Groups\n\n")
+		builder.WriteString("| Scenarios | Error |\n")
+		builder.WriteString("|---|---|\n")
+		for _, group := range groups {
+			builder.WriteString(fmt.Sprintf("| %d | %s |\n", len(group.Scenarios), escapeMarkdownCell(group.Error)))
+		}
+	}
+
+	failedHooks := make([]*models.HookResult, 0)
+	for _, hook := range result.Hooks {
+		if hook.Status == models.StatusFailed {
+			failedHooks = append(failedHooks, hook)
+		}
+	}
+	if len(failedHooks) > 0 {
+		builder.WriteString("\n## Failed Hooks\n\n")
+		builder.WriteString("| Hook | Error |\n")
+		builder.WriteString("|---|---|\n")
+		for _, hook := range failedHooks {
+			builder.WriteString(fmt.Sprintf("| %s | %s |\n", escapeMarkdownCell(hook.Name), escapeMarkdownCell(hook.Error)))
+		}
+	}
+
+	if failed := result.FailedScenarios(); len(failed) > 0 {
+		builder.WriteString("\n## Failed Scenarios\n\n")
+		builder.WriteString("| Feature | Scenario | Step | Error | Duration |\n")
+		builder.WriteString("|---|---|---|---|---|\n")
+		for _, scenario := range failed {
+			step := scenario.FailedStep()
+			stepText, stepError := "", ""
+			if step != nil {
+				stepText, stepError = step.Text, step.Error
+			}
+			builder.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+				escapeMarkdownCell(scenario.Uri),
+				escapeMarkdownCell(scenario.Name),
+				escapeMarkdownCell(stepText),
+				escapeMarkdownCell(stepError),
+				formatDuration(scenario.Duration),
+			))
+		}
+		for _, scenario := range failed {
+			builder.WriteString(fmt.Sprintf("\n<details>\n<summary>%s: %s</summary>\n\n", scenario.Uri, scenario.Name))
+			writeDescription(builder, scenario.FeatureDescription)
+			writeDescription(builder, scenario.Description)
+			builder.WriteString(fmt.Sprintf("```gherkin\n%s```\n\n", scenario.Gherkin()))
+			writeStepArguments(builder, scenario.Steps)
+			builder.WriteString("</details>\n")
+		}
+	}
+
+	writeSkipReasons(builder, result.Scenarios)
+
+	if total > 0 {
+		slowest := make([]*models.ScenarioResult, total)
+		copy(slowest, result.Scenarios)
+		sort.SliceStable(slowest, func(i, j int) bool {
+			return slowest[i].Duration > slowest[j].Duration
+		})
+		if len(slowest) > slowestScenarioCount {
+			slowest = slowest[:slowestScenarioCount]
+		}
+
+		builder.WriteString("\n## Slowest Scenarios\n\n")
+		builder.WriteString("| Feature | Scenario | Status | Duration |\n")
+		builder.WriteString("|---|---|---|---|\n")
+		for _, scenario := range slowest {
+			builder.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+				escapeMarkdownCell(scenario.Uri),
+				escapeMarkdownCell(scenario.Name),
+				scenario.Status,
+				formatDuration(scenario.Duration),
+			))
+		}
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+
+	return err
+}
+
+// writeDescription writes a feature or scenario description as a quoted paragraph
+func writeDescription(builder *strings.Builder, description string) {
+	if len(description) == 0 {
+		return
+	}
+	for _, line := range strings.Split(description, "\n") {
+		builder.WriteString(strings.TrimSpace("> "+line) + "\n")
+	}
+	builder.WriteString("\n")
+}
+
+// writeStepArguments writes the doc strings of the steps as code blocks highlighted by their media type and the
+// data tables as markdown tables
+func writeStepArguments(builder *strings.Builder, steps []*models.StepResult) {
+	for _, step := range steps {
+		if step.DocString == nil && len(step.DataTable) == 0 {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("**%s%s**\n\n", step.Keyword, step.Text))
+		if step.DocString != nil {
+			fence := "```"
+			for strings.Contains(step.DocString.Content, fence) {
+				fence += "`"
+			}
+			builder.WriteString(fmt.Sprintf("%s%s\n%s\n%s\n\n", fence, step.DocString.MediaType,
+				step.DocString.Content, fence))
+		}
+		if len(step.DataTable) > 0 {
+			for i, row := range step.DataTable {
+				cells := make([]string, 0, len(row))
+				for _, cell := range row {
+					cells = append(cells, escapeMarkdownCell(cell))
+				}
+				builder.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+				if i == 0 {
+					builder.WriteString(strings.Repeat("|---", len(row)) + "|\n")
+				}
+			}
+			builder.WriteString("\n")
+		}
+	}
+}
+
+func passRate(passed, total int) string {
+	if total == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.1f%%", float64(passed)*100/float64(total))
+}
+
+func formatDuration(duration time.Duration) string {
+	return duration.Round(time.Millisecond).String()
+}
+
+func escapeMarkdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	text = strings.ReplaceAll(text, "\r\n", "<br>")
+
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
+
+// writeSkipReasons writes the number of skipped scenarios by their skip cause and reason
+func writeSkipReasons(builder *strings.Builder, scenarios []*models.ScenarioResult) {
+	type skip struct {
+		cause  models.SkipCause
+		reason string
+	}
+	skips := make([]skip, 0)
+	counts := make(map[skip]int)
+	for _, scenario := range scenarios {
+		if scenario.Status != models.StatusSkipped {
+			continue
+		}
+		key := skip{cause: scenario.SkipCause, reason: scenario.Reason}
+		if counts[key] == 0 {
+			skips = append(skips, key)
+		}
+		counts[key]++
+	}
+	if len(skips) == 0 {
+		return
+	}
+
+	builder.WriteString("\n## Skipped Scenarios\n\n")
+	builder.WriteString("| Cause | Reason | Scenarios |\n")
+	builder.WriteString("|---|---|---|\n")
+	for _, key := range skips {
+		builder.WriteString(fmt.Sprintf("| %s | %s | %d |\n", key.cause, escapeMarkdownCell(key.reason), counts[key]))
+	}
+}
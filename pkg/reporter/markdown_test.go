@@ -0,0 +1,95 @@
+package reporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateMarkdownReport(t *testing.T) {
+	t.Run("should write summary and failed scenarios", func(t *testing.T) {
+		result := &models.RunResult{
+			Duration: 1500 * time.Millisecond,
+			Scenarios: []*models.ScenarioResult{
+				{Name: "Eat apples", Uri: "a.feature", Status: models.StatusPassed, Duration: time.Second},
+				{
+					Name:     "Eat pears",
+					Uri:      "a.feature",
+					Status:   models.StatusFailed,
+					Duration: 500 * time.Millisecond,
+					Steps: []*models.StepResult{
+						{Keyword: "Given ", Text: "I have 3 pears", Status: models.StatusFailed, Error: "expected 3|4\nactual 2"},
+						{Keyword: "When ", Text: "I eat 1 pear", Status: models.StatusSkipped},
+					},
+				},
+			},
+		}
+		path := filepath.Join(t.TempDir(), "report.md")
+
+		err := GenerateMarkdownReport(path, result)
+		require.Nil(t, err)
+
+		content, err := os.ReadFile(path)
+		require.Nil(t, err)
+		report := string(content)
+		require.True(t, strings.HasPrefix(report, "# Cacik Report\n"))
+		require.Contains(t, report, "| 2 | 1 | 1 | 0 | 0 | 50.0% | 1.5s |")
+		require.Contains(t, report, "| a.feature | Eat pears | I have 3 pears | expected 3\\|4<br>actual 2 | 500ms |")
+		require.Contains(t, report, "```gherkin\nScenario: Eat pears\n  Given I have 3 pears\n  When I eat 1 pear\n```")
+		require.Contains(t, report, "## Failure Groups\n\n| Scenarios | Error |\n|---|---|\n| 1 | expected <n>\\|<n> actual <n> |")
+		require.Contains(t, report, "## Slowest Scenarios")
+	})
+	t.Run("should count skipped scenarios by their cause and reason", func(t *testing.T) {
+		builder := &strings.Builder{}
+		docker := &models.ScenarioResult{Status: models.StatusSkipped, SkipCause: models.SkipCausePrecondition,
+			Reason: "command docker is not found"}
+		failFast := &models.ScenarioResult{Status: models.StatusSkipped, SkipCause: models.SkipCauseFailFast,
+			Reason: "a scenario failed before"}
+
+		err := WriteMarkdownReport(builder, &models.RunResult{Scenarios: []*models.ScenarioResult{
+			docker, failFast, docker,
+		}})
+
+		require.Nil(t, err)
+		require.Contains(t, builder.String(), "## Skipped Scenarios\n\n| Cause | Reason | Scenarios |\n|---|---|---|\n"+
+			"| precondition | command docker is not found | 2 |\n| fail-fast | a scenario failed before | 1 |\n")
+	})
+	t.Run("should show descriptions, doc strings and data tables of failed scenarios", func(t *testing.T) {
+		builder := &strings.Builder{}
+
+		err := WriteMarkdownReport(builder, &models.RunResult{Scenarios: []*models.ScenarioResult{{
+			Name:               "Create order",
+			Uri:                "orders.feature",
+			Status:             models.StatusFailed,
+			FeatureDescription: "As a customer\nI want to order",
+			Description:        "Orders are created with the API",
+			Steps: []*models.StepResult{
+				{
+					Keyword:   "When ",
+					Text:      "I post the order",
+					Status:    models.StatusFailed,
+					DocString: &models.DocString{MediaType: "json", Content: `{"items": 2}`},
+				},
+				{
+					Keyword:   "Then ",
+					Text:      "the order has items",
+					Status:    models.StatusSkipped,
+					DataTable: [][]string{{"name", "count"}, {"apple", "2"}},
+				},
+			},
+		}}})
+
+		require.Nil(t, err)
+		require.Contains(t, builder.String(), "<summary>orders.feature: Create order</summary>\n\n"+
+			"> As a customer\n> I want to order\n\n"+
+			"> Orders are created with the API\n\n"+
+			"```gherkin\n")
+		require.Contains(t, builder.String(), "**When I post the order**\n\n```json\n{\"items\": 2}\n```\n\n"+
+			"**Then the order has items**\n\n| name | count |\n|---|---|\n| apple | 2 |\n\n</details>\n")
+	})
+}
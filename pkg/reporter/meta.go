@@ -0,0 +1,46 @@
+package reporter
+
+import (
+	"sort"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+type (
+	// metaProperty is a line of the environment of a run in the reports
+	metaProperty struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:"value,attr"`
+	}
+)
+
+// metaProperties returns the environment of the run without its empty values, with the CI variables sorted by name
+func metaProperties(meta *models.RunMeta) []metaProperty {
+	if meta == nil {
+		return nil
+	}
+
+	properties := make([]metaProperty, 0)
+	for _, property := range []metaProperty{
+		{Name: "go", Value: meta.GoVersion},
+		{Name: "os", Value: meta.OS},
+		{Name: "arch", Value: meta.Arch},
+		{Name: "hostname", Value: meta.Hostname},
+		{Name: "cacik", Value: meta.CacikVersion},
+		{Name: "tags", Value: meta.TagExpression},
+	} {
+		if len(property.Value) > 0 {
+			properties = append(properties, property)
+		}
+	}
+	variables := make([]string, 0, len(meta.CI))
+	for variable := range meta.CI {
+		variables = append(variables, variable)
+	}
+	sort.Strings(variables)
+	for _, variable := range variables {
+		properties = append(properties, metaProperty{Name: variable, Value: meta.CI[variable]})
+	}
+
+	return properties
+}
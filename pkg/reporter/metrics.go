@@ -0,0 +1,173 @@
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	// DefaultMetricsJob is the job the metrics are pushed to the Pushgateway with if no job is given
+	DefaultMetricsJob = "cacik"
+	// untaggedLabel is the tag label of the scenarios without tags
+	untaggedLabel = "none"
+
+	metricsContentType = "text/plain; version=0.0.4"
+)
+
+var (
+	// durationBuckets are the upper bounds of the scenario duration histograms in seconds
+	durationBuckets = []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300}
+)
+
+// GenerateMetricsFile writes the metrics of the run to the file at path for the textfile collector of the Prometheus
+// node exporter, whose files end with .prom. The file is written next to path and renamed, so the collector never
+// reads a partial file.
+func GenerateMetricsFile(path string, result *models.RunResult) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("could not create metrics file %s, error=%w", path, err)
+	}
+	defer os.Remove(file.Name())
+
+	if err := WriteMetrics(file, result); err != nil {
+		file.Close()
+
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("could not write metrics file %s, error=%w", path, err)
+	}
+	if err := os.Chmod(file.Name(), 0o644); err != nil {
+		return fmt.Errorf("could not write metrics file %s, error=%w", path, err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("could not write metrics file %s, error=%w", path, err)
+	}
+
+	return nil
+}
+
+// PushMetrics replaces the metrics of the job in the Prometheus Pushgateway at the address, such as
+// http://localhost:9091, with the metrics of the run. The push is canceled with the context, so an unreachable
+// Pushgateway can not block the end of the run with a context which has a deadline.
+func PushMetrics(ctx context.Context, address, job string, result *models.RunResult) error {
+	if len(job) == 0 {
+		job = DefaultMetricsJob
+	}
+	body := &bytes.Buffer{}
+	if err := WriteMetrics(body, result); err != nil {
+		return err
+	}
+
+	target := strings.TrimSuffix(address, "/") + "/metrics/job/" + url.PathEscape(job)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, target, body)
+	if err != nil {
+		return fmt.Errorf("could not push metrics to %s, error=%w", address, err)
+	}
+	request.Header.Set("Content-Type", metricsContentType)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("could not push metrics to %s, error=%w", address, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+
+		return fmt.Errorf("could not push metrics to %s, status=%s %s", address, response.Status,
+			strings.TrimSpace(string(message)))
+	}
+
+	return nil
+}
+
+// WriteMetrics writes the metrics of the run in the Prometheus text format: the number of scenarios by status, the
+// duration and start time of the run and a histogram of the scenario durations for every tag
+func WriteMetrics(writer io.Writer, result *models.RunResult) error {
+	metrics := &bytes.Buffer{}
+
+	metrics.WriteString("# HELP cacik_scenarios Number of scenarios of the last run by status.\n")
+	metrics.WriteString("# TYPE cacik_scenarios gauge\n")
+	for _, status := range htmlStatuses {
+		fmt.Fprintf(metrics, "cacik_scenarios{status=%q} %d\n", status, result.Count(status))
+	}
+
+	metrics.WriteString("# HELP cacik_run_duration_seconds Duration of the last run.\n")
+	metrics.WriteString("# TYPE cacik_run_duration_seconds gauge\n")
+	fmt.Fprintf(metrics, "cacik_run_duration_seconds %s\n", formatSample(result.Duration.Seconds()))
+
+	if !result.StartedAt.IsZero() {
+		metrics.WriteString("# HELP cacik_run_start_timestamp_seconds Start time of the last run since the epoch.\n")
+		metrics.WriteString("# TYPE cacik_run_start_timestamp_seconds gauge\n")
+		fmt.Fprintf(metrics, "cacik_run_start_timestamp_seconds %d\n", result.StartedAt.Unix())
+	}
+
+	metrics.WriteString("# HELP cacik_scenario_duration_seconds Duration of the scenarios of the last run by tag.\n")
+	metrics.WriteString("# TYPE cacik_scenario_duration_seconds histogram\n")
+	durations := scenarioDurationsByTag(result)
+	tags := make([]string, 0, len(durations))
+	for tag := range durations {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		writeHistogram(metrics, "cacik_scenario_duration_seconds", tag, durations[tag])
+	}
+
+	if _, err := writer.Write(metrics.Bytes()); err != nil {
+		return fmt.Errorf("could not write metrics, error=%w", err)
+	}
+
+	return nil
+}
+
+// scenarioDurationsByTag returns the durations of the scenarios in seconds by their tags. A scenario is counted for
+// every tag it has, and for untaggedLabel if it has none.
+func scenarioDurationsByTag(result *models.RunResult) map[string][]float64 {
+	durations := make(map[string][]float64)
+	for _, scenario := range result.Scenarios {
+		tags := scenario.Tags
+		if len(tags) == 0 {
+			tags = []string{untaggedLabel}
+		}
+		for _, tag := range tags {
+			durations[tag] = append(durations[tag], scenario.Duration.Seconds())
+		}
+	}
+
+	return durations
+}
+
+// writeHistogram writes the cumulative buckets, the sum and the count of the durations
+func writeHistogram(writer io.Writer, name, tag string, durations []float64) {
+	sum := 0.0
+	for _, duration := range durations {
+		sum += duration
+	}
+	for _, bucket := range durationBuckets {
+		count := 0
+		for _, duration := range durations {
+			if duration <= bucket {
+				count++
+			}
+		}
+		fmt.Fprintf(writer, "%s_bucket{tag=%q,le=%q} %d\n", name, tag, formatSample(bucket), count)
+	}
+	fmt.Fprintf(writer, "%s_bucket{tag=%q,le=\"+Inf\"} %d\n", name, tag, len(durations))
+	fmt.Fprintf(writer, "%s_sum{tag=%q} %s\n", name, tag, formatSample(sum))
+	fmt.Fprintf(writer, "%s_count{tag=%q} %d\n", name, tag, len(durations))
+}
+
+func formatSample(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,140 @@
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	result := &models.RunResult{
+		StartedAt: time.Unix(1709373600, 0),
+		Duration:  3 * time.Second,
+		Scenarios: []*models.ScenarioResult{
+			{Name: "Eat apples", Tags: []string{"@smoke"}, Status: models.StatusPassed, Duration: 200 * time.Millisecond},
+			{Name: "Eat pears", Tags: []string{"@smoke", "@slow"}, Status: models.StatusFailed, Duration: 2 * time.Second},
+			{Name: "Sell pears", Status: models.StatusSkipped},
+		},
+	}
+
+	t.Run("should write the scenarios by status and the durations by tag", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		require.Nil(t, WriteMetrics(buffer, result))
+
+		require.Equal(t, `# HELP cacik_scenarios Number of scenarios of the last run by status.
+# TYPE cacik_scenarios gauge
+cacik_scenarios{status="passed"} 1
+cacik_scenarios{status="failed"} 1
+cacik_scenarios{status="undefined"} 0
+cacik_scenarios{status="skipped"} 1
+# HELP cacik_run_duration_seconds Duration of the last run.
+# TYPE cacik_run_duration_seconds gauge
+cacik_run_duration_seconds 3
+# HELP cacik_run_start_timestamp_seconds Start time of the last run since the epoch.
+# TYPE cacik_run_start_timestamp_seconds gauge
+cacik_run_start_timestamp_seconds 1709373600
+# HELP cacik_scenario_duration_seconds Duration of the scenarios of the last run by tag.
+# TYPE cacik_scenario_duration_seconds histogram
+cacik_scenario_duration_seconds_bucket{tag="@slow",le="0.1"} 0
+cacik_scenario_duration_seconds_bucket{tag="@slow",le="0.5"} 0
+cacik_scenario_duration_seconds_bucket{tag="@slow",le="1"} 0
+cacik_scenario_duration_seconds_bucket{tag="@slow",le="5"} 1
+cacik_scenario_duration_seconds_bucket{tag="@slow",le="10"} 1
+cacik_scenario_duration_seconds_bucket{tag="@slow",le="30"} 1
+cacik_scenario_duration_seconds_bucket{tag="@slow",le="60"} 1
+cacik_scenario_duration_seconds_bucket{tag="@slow",le="300"} 1
+cacik_scenario_duration_seconds_bucket{tag="@slow",le="+Inf"} 1
+cacik_scenario_duration_seconds_sum{tag="@slow"} 2
+cacik_scenario_duration_seconds_count{tag="@slow"} 1
+cacik_scenario_duration_seconds_bucket{tag="@smoke",le="0.1"} 0
+cacik_scenario_duration_seconds_bucket{tag="@smoke",le="0.5"} 1
+cacik_scenario_duration_seconds_bucket{tag="@smoke",le="1"} 1
+cacik_scenario_duration_seconds_bucket{tag="@smoke",le="5"} 2
+cacik_scenario_duration_seconds_bucket{tag="@smoke",le="10"} 2
+cacik_scenario_duration_seconds_bucket{tag="@smoke",le="30"} 2
+cacik_scenario_duration_seconds_bucket{tag="@smoke",le="60"} 2
+cacik_scenario_duration_seconds_bucket{tag="@smoke",le="300"} 2
+cacik_scenario_duration_seconds_bucket{tag="@smoke",le="+Inf"} 2
+cacik_scenario_duration_seconds_sum{tag="@smoke"} 2.2
+cacik_scenario_duration_seconds_count{tag="@smoke"} 2
+cacik_scenario_duration_seconds_bucket{tag="none",le="0.1"} 1
+cacik_scenario_duration_seconds_bucket{tag="none",le="0.5"} 1
+cacik_scenario_duration_seconds_bucket{tag="none",le="1"} 1
+cacik_scenario_duration_seconds_bucket{tag="none",le="5"} 1
+cacik_scenario_duration_seconds_bucket{tag="none",le="10"} 1
+cacik_scenario_duration_seconds_bucket{tag="none",le="30"} 1
+cacik_scenario_duration_seconds_bucket{tag="none",le="60"} 1
+cacik_scenario_duration_seconds_bucket{tag="none",le="300"} 1
+cacik_scenario_duration_seconds_bucket{tag="none",le="+Inf"} 1
+cacik_scenario_duration_seconds_sum{tag="none"} 0
+cacik_scenario_duration_seconds_count{tag="none"} 1
+`, buffer.String())
+	})
+
+	t.Run("should write the metrics file for the textfile collector", func(t *testing.T) {
+		directory := t.TempDir()
+		path := filepath.Join(directory, "cacik.prom")
+
+		require.Nil(t, GenerateMetricsFile(path, result))
+
+		content, err := os.ReadFile(path)
+		require.Nil(t, err)
+		require.Contains(t, string(content), `cacik_scenarios{status="failed"} 1`)
+		entries, err := os.ReadDir(directory)
+		require.Nil(t, err)
+		require.Len(t, entries, 1)
+	})
+
+	t.Run("should push the metrics to the job of the pushgateway", func(t *testing.T) {
+		var method, path, contentType, body string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			content, _ := io.ReadAll(r.Body)
+			method, path, contentType, body = r.Method, r.URL.Path, r.Header.Get("Content-Type"), string(content)
+		}))
+		defer server.Close()
+
+		require.Nil(t, PushMetrics(context.Background(), server.URL+"/", "checkout", result))
+
+		require.Equal(t, http.MethodPut, method)
+		require.Equal(t, "/metrics/job/checkout", path)
+		require.Equal(t, "text/plain; version=0.0.4", contentType)
+		require.Contains(t, body, "cacik_run_duration_seconds 3")
+	})
+
+	t.Run("should return error if the pushgateway rejects the metrics", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/metrics/job/cacik", r.URL.Path)
+			http.Error(w, "invalid metric", http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		err := PushMetrics(context.Background(), server.URL, "", result)
+
+		require.ErrorContains(t, err, "status=400 Bad Request invalid metric")
+	})
+
+	t.Run("should stop pushing when the context is done", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer server.Close()
+		defer close(release)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := PushMetrics(ctx, server.URL, "", result)
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
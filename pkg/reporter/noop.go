@@ -0,0 +1,16 @@
+package reporter
+
+import "github.com/denizgursoy/cacik/pkg/models"
+
+type (
+	// NoopReporter ignores every event. It can be embedded by reporters interested in only some of the events.
+	NoopReporter struct{}
+)
+
+func (NoopReporter) RunStarted(*models.RunResult) {}
+
+func (NoopReporter) ScenarioFinished(*models.ScenarioResult) {}
+
+func (NoopReporter) FeatureFinished(*models.FeatureResult) {}
+
+func (NoopReporter) RunFinished(*models.RunResult) {}
@@ -0,0 +1,81 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	// VerbositySilent writes nothing
+	VerbositySilent Verbosity = iota
+	// VerbositySummary writes the number of scenarios by status and the duration of the run only
+	VerbositySummary
+	// VerbosityNormal writes the scenarios with their steps and the summary
+	VerbosityNormal
+	// VerbosityVerbose writes the messages logged by every step under it in addition to VerbosityNormal
+	VerbosityVerbose
+)
+
+const (
+	// ColorVariable keeps the colors of the palette with always, disables them with never and detects whether the
+	// output supports them with auto, the default
+	ColorVariable = "CACIK_COLOR"
+	// NoColorVariable disables colors if it is set to any value, see https://no-color.org
+	NoColorVariable = "NO_COLOR"
+
+	ColorAlways = "always"
+	ColorNever  = "never"
+	ColorAuto   = "auto"
+)
+
+type (
+	// Verbosity is how much of a run the console reporters write
+	Verbosity int
+)
+
+var (
+	verbosityNames = map[string]Verbosity{
+		"silent":  VerbositySilent,
+		"summary": VerbositySummary,
+		"normal":  VerbosityNormal,
+		"verbose": VerbosityVerbose,
+	}
+)
+
+// VerbosityByName returns the verbosity with the name: silent, summary, normal or verbose
+func VerbosityByName(name string) (Verbosity, error) {
+	verbosity, ok := verbosityNames[name]
+	if !ok {
+		return VerbosityNormal, fmt.Errorf("unknown verbosity %s, use silent, summary, normal or verbose", name)
+	}
+
+	return verbosity, nil
+}
+
+// ColorsEnabled reports whether output written to the writer may be colored. ColorVariable set to always or never
+// decides it, otherwise colors are disabled if NoColorVariable is set or the writer is not a terminal, such as the
+// log of a CI job, a file or a buffer.
+func ColorsEnabled(writer io.Writer) bool {
+	file, ok := writer.(*os.File)
+
+	return colorsEnabled(os.Getenv, ok && isTerminal(file))
+}
+
+func colorsEnabled(getenv func(string) string, terminal bool) bool {
+	switch getenv(ColorVariable) {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	return len(getenv(NoColorVariable)) == 0 && terminal
+}
+
+// isTerminal reports whether the file is a character device such as a terminal
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
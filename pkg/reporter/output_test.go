@@ -0,0 +1,63 @@
+package reporter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerbosityByName(t *testing.T) {
+	t.Run("should return the verbosity with the name", func(t *testing.T) {
+		verbosity, err := VerbosityByName("summary")
+
+		require.Nil(t, err)
+		require.Equal(t, VerbositySummary, verbosity)
+	})
+	t.Run("should return error for unknown names", func(t *testing.T) {
+		_, err := VerbosityByName("loud")
+
+		require.EqualError(t, err, "unknown verbosity loud, use silent, summary, normal or verbose")
+	})
+}
+
+func TestColorsEnabled(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		variables map[string]string
+		terminal  bool
+		enabled   bool
+	}{
+		{name: "should enable colors in terminals", terminal: true, enabled: true},
+		{name: "should disable colors if the output is not a terminal"},
+		{name: "should disable colors if NO_COLOR is set", variables: map[string]string{NoColorVariable: "1"},
+			terminal: true},
+		{name: "should enable colors with CACIK_COLOR=always", variables: map[string]string{
+			ColorVariable: ColorAlways, NoColorVariable: "1"}, enabled: true},
+		{name: "should disable colors with CACIK_COLOR=never", variables: map[string]string{ColorVariable: ColorNever},
+			terminal: true},
+		{name: "should detect colors with CACIK_COLOR=auto", variables: map[string]string{ColorVariable: ColorAuto},
+			terminal: true, enabled: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			getenv := func(name string) string {
+				return test.variables[name]
+			}
+
+			require.Equal(t, test.enabled, colorsEnabled(getenv, test.terminal))
+		})
+	}
+}
+
+func TestColorsEnabledWriter(t *testing.T) {
+	t.Run("should disable colors for writers which are not files", func(t *testing.T) {
+		t.Setenv(ColorVariable, ColorAuto)
+
+		require.False(t, ColorsEnabled(&bytes.Buffer{}))
+	})
+	t.Run("should enable colors for any writer with CACIK_COLOR=always", func(t *testing.T) {
+		t.Setenv(ColorVariable, ColorAlways)
+
+		require.True(t, ColorsEnabled(&bytes.Buffer{}))
+	})
+}
@@ -0,0 +1,82 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	ProgressFormat = "progress"
+)
+
+var (
+	// progressMarks are the characters written for the steps of every status
+	progressMarks = map[models.Status]string{
+		models.StatusPassed:    ".",
+		models.StatusFailed:    "F",
+		models.StatusSkipped:   "-",
+		models.StatusUndefined: "U",
+	}
+)
+
+type (
+	// ProgressReporter writes one character per step, like the progress formatter of cucumber-ruby, and the
+	// failed scenarios with their failing step and error after the run, so huge suites print a line instead of a
+	// page per scenario
+	ProgressReporter struct {
+		NoopReporter
+		writer    io.Writer
+		verbosity Verbosity
+	}
+)
+
+func NewProgressReporter(writer io.Writer) *ProgressReporter {
+	return &ProgressReporter{writer: writer, verbosity: VerbosityNormal}
+}
+
+// SetVerbosity sets how much of the run is written, VerbosityNormal by default. VerbosityVerbose writes as much as
+// VerbosityNormal.
+func (p *ProgressReporter) SetVerbosity(verbosity Verbosity) {
+	p.verbosity = verbosity
+}
+
+// ScenarioFinished writes . for passed, F for failed, - for skipped and U for undefined steps
+func (p *ProgressReporter) ScenarioFinished(scenario *models.ScenarioResult) {
+	if p.verbosity < VerbosityNormal {
+		return
+	}
+	marks := &strings.Builder{}
+	for _, step := range scenario.Steps {
+		marks.WriteString(progressMarks[step.Status])
+	}
+	fmt.Fprint(p.writer, marks.String())
+}
+
+// RunFinished ends the line of the steps and writes every failed scenario with the location and the error of its
+// failing step, followed by the number of scenarios by status and the duration of the run
+func (p *ProgressReporter) RunFinished(run *models.RunResult) {
+	if p.verbosity < VerbositySummary {
+		return
+	}
+	if p.verbosity == VerbositySummary {
+		writeSummary(p.writer, run)
+
+		return
+	}
+	fmt.Fprintln(p.writer)
+	fmt.Fprintln(p.writer)
+	for i, scenario := range run.FailedScenarios() {
+		fmt.Fprintf(p.writer, "%d) Scenario: %s%s\n", i+1, scenario.Name, formatLocation(scenario.Uri, scenario.Line))
+		if step := scenario.FailedStep(); step != nil {
+			fmt.Fprintf(p.writer, "   %s%s%s\n", step.Keyword, step.Text, formatLocation(scenario.Uri, step.Line))
+		}
+		if message := scenario.FailureMessage(); len(message) > 0 {
+			fmt.Fprintf(p.writer, "      %s\n", strings.ReplaceAll(message, "\n", "\n      "))
+		}
+		fmt.Fprintln(p.writer)
+	}
+	writeSummary(p.writer, run)
+}
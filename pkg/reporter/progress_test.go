@@ -0,0 +1,72 @@
+package reporter
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressReporter(t *testing.T) {
+	t.Run("should write a character per step and the failed scenarios after the run", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		reporter := NewProgressReporter(buffer)
+		passed := &models.ScenarioResult{
+			Name:   "Eat apples",
+			Uri:    "a.feature",
+			Line:   3,
+			Status: models.StatusPassed,
+			Steps: []*models.StepResult{
+				{Keyword: "Given ", Text: "I have 3 apples", Status: models.StatusPassed},
+				{Keyword: "When ", Text: "I eat 1 apple", Status: models.StatusPassed},
+			},
+		}
+		failed := &models.ScenarioResult{
+			Name:   "Pay",
+			Uri:    "b.feature",
+			Line:   7,
+			Status: models.StatusFailed,
+			Steps: []*models.StepResult{
+				{Keyword: "When ", Text: "I pay", Status: models.StatusPassed},
+				{Keyword: "Then ", Text: "I am paid", Line: 9, Status: models.StatusFailed, Error: "not paid\n-1\n+0"},
+				{Keyword: "And ", Text: "I leave", Status: models.StatusSkipped},
+			},
+		}
+		undefined := &models.ScenarioResult{
+			Name:   "Refund",
+			Uri:    "b.feature",
+			Line:   12,
+			Status: models.StatusUndefined,
+			Steps: []*models.StepResult{
+				{Keyword: "When ", Text: "I refund", Line: 13, Status: models.StatusUndefined,
+					Error: `step "I refund" is not defined`},
+			},
+		}
+
+		reporter.ScenarioFinished(passed)
+		reporter.ScenarioFinished(failed)
+		reporter.ScenarioFinished(undefined)
+		reporter.RunFinished(&models.RunResult{
+			Scenarios: []*models.ScenarioResult{passed, failed, undefined},
+			Duration:  3 * time.Second,
+		})
+
+		require.Equal(t, `...F-U
+
+1) Scenario: Pay # b.feature:7
+   Then I am paid # b.feature:9
+      not paid
+      -1
+      +0
+
+2) Scenario: Refund # b.feature:12
+   When I refund # b.feature:13
+      step "I refund" is not defined
+
+3 scenarios (1 passed, 1 failed, 1 undefined)
+3s
+`, buffer.String())
+	})
+}
@@ -0,0 +1,124 @@
+package reporter
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	// UnicodeSymbols mark the status of steps with ✓, ✗, - and ?
+	UnicodeSymbols = "unicode"
+	// ASCIISymbols mark the status of steps with [PASS], [FAIL], [SKIP] and [UNDEF], for terminals and CI logs
+	// without unicode fonts and for screen readers
+	ASCIISymbols = "ascii"
+
+	// NoPalette writes reports without colors
+	NoPalette = "none"
+	// DefaultPalette colors statuses green, red, yellow and orange
+	DefaultPalette = "default"
+	// ColorblindPalette colors statuses with the Okabe-Ito colors, which are told apart with every kind of color
+	// blindness: passed is blue and failed vermillion
+	ColorblindPalette = "colorblind"
+)
+
+type (
+	// Palette are the colors of the statuses and the step parameters in reports, as #rrggbb
+	Palette struct {
+		Passed    string
+		Failed    string
+		Skipped   string
+		Undefined string
+		Parameter string
+		// Slow is the color of the durations of slow steps
+		Slow string
+	}
+)
+
+var (
+	symbolSets = map[string]map[models.Status]string{
+		UnicodeSymbols: {
+			models.StatusPassed:    "✓",
+			models.StatusFailed:    "✗",
+			models.StatusSkipped:   "-",
+			models.StatusUndefined: "?",
+		},
+		ASCIISymbols: {
+			models.StatusPassed:    "[PASS]",
+			models.StatusFailed:    "[FAIL]",
+			models.StatusSkipped:   "[SKIP]",
+			models.StatusUndefined: "[UNDEF]",
+		},
+	}
+
+	palettes = map[string]*Palette{
+		NoPalette: nil,
+		DefaultPalette: {
+			Passed:    "#2e7d32",
+			Failed:    "#c62828",
+			Skipped:   "#f9a825",
+			Undefined: "#ef6c00",
+			Parameter: "#1565c0",
+			Slow:      "#f9a825",
+		},
+		ColorblindPalette: {
+			Passed:    "#0072b2",
+			Failed:    "#d55e00",
+			Skipped:   "#999999",
+			Undefined: "#cc79a7",
+			Parameter: "#009e73",
+			Slow:      "#e69f00",
+		},
+	}
+)
+
+// SymbolsByName returns the status symbols with the name, UnicodeSymbols or ASCIISymbols
+func SymbolsByName(name string) (map[models.Status]string, error) {
+	symbols, ok := symbolSets[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown symbols %s, use %s or %s", name, UnicodeSymbols, ASCIISymbols)
+	}
+
+	return symbols, nil
+}
+
+// PaletteByName returns the palette with the name, NoPalette, DefaultPalette or ColorblindPalette. The palette of
+// NoPalette is nil.
+func PaletteByName(name string) (*Palette, error) {
+	palette, ok := palettes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown palette %s, use %s, %s or %s", name, NoPalette, DefaultPalette,
+			ColorblindPalette)
+	}
+
+	return palette, nil
+}
+
+// Status returns the color of the status
+func (p *Palette) Status(status models.Status) string {
+	switch status {
+	case models.StatusPassed:
+		return p.Passed
+	case models.StatusFailed:
+		return p.Failed
+	case models.StatusSkipped:
+		return p.Skipped
+	default:
+		return p.Undefined
+	}
+}
+
+// colorize wraps the text in the ANSI escape codes of the 24-bit #rrggbb color for terminals. The text is returned
+// as it is if the color can not be parsed.
+func colorize(text, color string) string {
+	if len(color) != 7 || color[0] != '#' {
+		return text
+	}
+	rgb, err := strconv.ParseUint(color[1:], 16, 32)
+	if err != nil {
+		return text
+	}
+
+	return fmt.Sprintf("\x1b[38;2;%d;%d;%dm%s\x1b[0m", rgb>>16, rgb>>8&0xff, rgb&0xff, text)
+}
@@ -0,0 +1,105 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	Test2JSONFormat = "test2json"
+)
+
+type (
+	// Test2JSONReporter writes one JSON event per line in the format of `go tool test2json`, so tools such as
+	// gotestsum can render scenario progress. Feature files are reported as packages and scenarios as tests.
+	Test2JSONReporter struct {
+		NoopReporter
+		encoder *json.Encoder
+		now     func() time.Time
+	}
+
+	test2JSONEvent struct {
+		Time    time.Time `json:",omitempty"`
+		Action  string
+		Package string   `json:",omitempty"`
+		Test    string   `json:",omitempty"`
+		Elapsed *float64 `json:",omitempty"`
+		Output  string   `json:",omitempty"`
+	}
+)
+
+func NewTest2JSONReporter(writer io.Writer) *Test2JSONReporter {
+	return &Test2JSONReporter{
+		encoder: json.NewEncoder(writer),
+		now:     time.Now,
+	}
+}
+
+func (t *Test2JSONReporter) ScenarioFinished(scenario *models.ScenarioResult) {
+	name := testName(scenario.Name)
+	elapsed := scenario.Duration.Seconds()
+
+	t.write(test2JSONEvent{Action: "run", Package: scenario.Uri, Test: name})
+	t.output(scenario.Uri, name, fmt.Sprintf("=== RUN   %s\n", name))
+	for _, step := range scenario.Steps {
+		t.output(scenario.Uri, name, fmt.Sprintf("    %s: %s\n", step.Text, step.Status))
+		if len(step.Error) > 0 {
+			t.output(scenario.Uri, name, fmt.Sprintf("        %s\n", step.Error))
+		}
+	}
+	for _, log := range scenario.Logs {
+		t.output(scenario.Uri, name, fmt.Sprintf("    %s\n", log))
+	}
+
+	action := testAction(scenario.Status)
+	t.output(scenario.Uri, name, fmt.Sprintf("--- %s: %s (%.2fs)\n", strings.ToUpper(action), name, elapsed))
+	t.write(test2JSONEvent{Action: action, Package: scenario.Uri, Test: name, Elapsed: &elapsed})
+}
+
+// RunFinished reports every feature file as a passed or failed package
+// FeatureFinished writes the result of the package of the feature file, which fails if a scenario failed
+func (t *Test2JSONReporter) FeatureFinished(feature *models.FeatureResult) {
+	if len(feature.Scenarios) == 0 {
+		return
+	}
+	elapsed := 0.0
+	action := "pass"
+	for _, scenario := range feature.Scenarios {
+		elapsed += scenario.Duration.Seconds()
+		if testAction(scenario.Status) == "fail" {
+			action = "fail"
+		}
+	}
+
+	t.output(feature.Uri, "", fmt.Sprintf("%s\n", strings.ToUpper(action)))
+	t.write(test2JSONEvent{Action: action, Package: feature.Uri, Elapsed: &elapsed})
+}
+
+func (t *Test2JSONReporter) output(uri, name, output string) {
+	t.write(test2JSONEvent{Action: "output", Package: uri, Test: name, Output: output})
+}
+
+func (t *Test2JSONReporter) write(event test2JSONEvent) {
+	event.Time = t.now()
+	_ = t.encoder.Encode(event)
+}
+
+func testName(scenarioName string) string {
+	return strings.ReplaceAll(strings.TrimSpace(scenarioName), " ", "_")
+}
+
+func testAction(status models.Status) string {
+	switch status {
+	case models.StatusPassed:
+		return "pass"
+	case models.StatusSkipped:
+		return "skip"
+	default:
+		return "fail"
+	}
+}
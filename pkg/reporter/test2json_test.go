@@ -0,0 +1,51 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTest2JSONReporter(t *testing.T) {
+	t.Run("should write test2json events for scenarios and feature files", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		reporter := NewTest2JSONReporter(buffer)
+		passing := &models.ScenarioResult{
+			Name:     "Eat apples",
+			Uri:      "a.feature",
+			Status:   models.StatusPassed,
+			Duration: time.Second,
+			Steps:    []*models.StepResult{{Text: "I have 3 apples", Status: models.StatusPassed}},
+		}
+		failing := &models.ScenarioResult{
+			Name:   "Eat pears",
+			Uri:    "a.feature",
+			Status: models.StatusFailed,
+			Steps:  []*models.StepResult{{Text: "I have 3 pears", Status: models.StatusFailed, Error: "no pears"}},
+		}
+
+		reporter.ScenarioFinished(passing)
+		reporter.ScenarioFinished(failing)
+		reporter.FeatureFinished(&models.FeatureResult{Uri: "a.feature", Scenarios: []*models.ScenarioResult{passing, failing}})
+		reporter.RunFinished(&models.RunResult{Scenarios: []*models.ScenarioResult{passing, failing}})
+
+		actions := make([]string, 0)
+		for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+			event := test2JSONEvent{}
+			require.Nil(t, json.Unmarshal([]byte(line), &event))
+			require.Equal(t, "a.feature", event.Package)
+			if event.Action != "output" {
+				actions = append(actions, event.Action+" "+event.Test)
+			}
+		}
+
+		require.Equal(t, []string{"run Eat_apples", "pass Eat_apples", "run Eat_pears", "fail Eat_pears", "fail "}, actions)
+		require.Contains(t, buffer.String(), `"Output":"--- FAIL: Eat_pears (0.00s)\n"`)
+		require.Contains(t, buffer.String(), `"Output":"        no pears\n"`)
+	})
+}
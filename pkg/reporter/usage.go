@@ -0,0 +1,66 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+// GenerateUsageReport writes a markdown report of the step definitions never matched in the run and of the step
+// texts matched by the others to the file at path
+func GenerateUsageReport(path string, usages []*models.StepUsage) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create usage report %s, error=%w", path, err)
+	}
+	defer file.Close()
+
+	return WriteUsageReport(file, usages)
+}
+
+// WriteUsageReport writes the markdown report of GenerateUsageReport to the writer
+func WriteUsageReport(writer io.Writer, usages []*models.StepUsage) error {
+	builder := &strings.Builder{}
+	builder.WriteString("# Step Usage\n")
+
+	unused := make([]*models.StepUsage, 0)
+	for _, usage := range usages {
+		if usage.Unused() {
+			unused = append(unused, usage)
+		}
+	}
+	if len(unused) > 0 {
+		builder.WriteString("\n## Unused Step Definitions\n\n")
+		for _, usage := range unused {
+			builder.WriteString(fmt.Sprintf("- `%s`%s\n", usage.Definition, usageSite(usage)))
+		}
+	}
+
+	if len(unused) < len(usages) {
+		builder.WriteString("\n## Used Step Definitions\n")
+		for _, usage := range usages {
+			if usage.Unused() {
+				continue
+			}
+			builder.WriteString(fmt.Sprintf("\n### `%s`%s\n\n", usage.Definition, usageSite(usage)))
+			for _, text := range usage.Texts {
+				builder.WriteString(fmt.Sprintf("- %s\n", text))
+			}
+		}
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+
+	return err
+}
+
+func usageSite(usage *models.StepUsage) string {
+	if len(usage.Site) == 0 {
+		return ""
+	}
+
+	return " (" + usage.Site + ")"
+}
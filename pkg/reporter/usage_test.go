@@ -0,0 +1,29 @@
+package reporter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteUsageReport(t *testing.T) {
+	t.Run("should list unused definitions and texts of used definitions", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		err := WriteUsageReport(buffer, []*models.StepUsage{
+			{Definition: `^I have (\d+) apples$`, Texts: []string{"I have 1 apples", "I have 3 apples"}},
+			{Definition: `^I sell apples$`, Site: "steps.go:12"},
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, "# Step Usage\n\n"+
+			"## Unused Step Definitions\n\n"+
+			"- `^I sell apples$` (steps.go:12)\n\n"+
+			"## Used Step Definitions\n\n"+
+			"### `^I have (\\d+) apples$`\n\n"+
+			"- I have 1 apples\n"+
+			"- I have 3 apples\n", buffer.String())
+	})
+}
@@ -0,0 +1,342 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/executor"
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/denizgursoy/cacik/pkg/reporter"
+)
+
+const (
+	SnippetArtifact  = "snippets"
+	MarkdownArtifact = "markdown"
+	HTMLArtifact     = "html"
+	CucumberArtifact = "cucumber"
+	ResultArtifact   = "result"
+	RerunArtifact    = "rerun"
+	UsageArtifact    = "usage"
+	BadgeArtifact    = "badge"
+	MetricsArtifact  = "metrics"
+	TraceArtifact    = "trace"
+
+	// LatestReportDirectory is the name of the symlink pointing to the directory of the last run
+	LatestReportDirectory = "latest"
+
+	defaultMarkdownReport = "report.md"
+	defaultResultFile     = "result.json"
+	// pushgatewayTimeout is how long the metrics are pushed to the Pushgateway before the run gives up
+	pushgatewayTimeout = 30 * time.Second
+)
+
+type (
+	Artifact struct {
+		Kind string `json:"kind"`
+		Path string `json:"path"`
+	}
+
+	// Tracer is implemented by executors which can record a trace of the run, such as executor.StepExecutor
+	Tracer interface {
+		SetTrace(trace *executor.Trace)
+	}
+
+	artifactManifest struct {
+		Artifacts []Artifact `json:"artifacts"`
+	}
+
+	artifactPaths struct {
+		snippetFile      string
+		markdownReport   string
+		htmlReport       string
+		cucumberReport   string
+		resultFile       string
+		rerunOutput      string
+		usageReport      string
+		badge            string
+		metricsFile      string
+		traceFile        string
+		artifactManifest string
+	}
+)
+
+// writeArtifacts creates the configured artifacts of the run, prints where they are and writes the manifest
+func (c *CucumberRunner) writeArtifacts(result *models.RunResult, trace *executor.Trace) error {
+	paths, err := c.artifactPaths(result.StartedAt)
+	if err != nil {
+		return err
+	}
+	artifacts := make([]Artifact, 0)
+
+	written, err := writeSnippets(result.UndefinedSteps(), paths.snippetFile)
+	if err != nil {
+		return err
+	}
+	if written {
+		artifacts = append(artifacts, newArtifact(SnippetArtifact, paths.snippetFile))
+	}
+
+	if len(paths.markdownReport) > 0 {
+		if err := reporter.GenerateMarkdownReport(paths.markdownReport, result); err != nil {
+			return err
+		}
+		artifacts = append(artifacts, newArtifact(MarkdownArtifact, paths.markdownReport))
+	}
+
+	// the history is kept across runs, so it is not in the directory of the run
+	htmlOptions := c.htmlOptions
+	// the report uses the palette of the console output, unless the options set one
+	if htmlOptions.Palette == nil {
+		htmlOptions.Palette, _ = reporter.PaletteByName(c.palette)
+	}
+	if len(c.historyDirectory) > 0 {
+		if err := models.AppendHistory(c.historyDirectory, result); err != nil {
+			return err
+		}
+		if htmlOptions.History, err = models.LoadHistory(c.historyDirectory); err != nil {
+			return err
+		}
+	}
+
+	if len(paths.htmlReport) > 0 {
+		if err := reporter.GenerateHTMLReport(paths.htmlReport, result, htmlOptions); err != nil {
+			return err
+		}
+		artifacts = append(artifacts, newArtifact(HTMLArtifact, paths.htmlReport))
+	}
+
+	if len(paths.cucumberReport) > 0 {
+		if err := reporter.GenerateCucumberJSONReport(paths.cucumberReport, result); err != nil {
+			return err
+		}
+		artifacts = append(artifacts, newArtifact(CucumberArtifact, paths.cucumberReport))
+	}
+
+	if len(paths.resultFile) > 0 {
+		if err := result.Save(paths.resultFile); err != nil {
+			return err
+		}
+		artifacts = append(artifacts, newArtifact(ResultArtifact, paths.resultFile))
+	}
+
+	if len(paths.usageReport) > 0 {
+		if err := reporter.GenerateUsageReport(paths.usageReport, c.Audit(result)); err != nil {
+			return err
+		}
+		artifacts = append(artifacts, newArtifact(UsageArtifact, paths.usageReport))
+	}
+
+	if len(paths.badge) > 0 {
+		if err := reporter.GenerateBadge(paths.badge, result); err != nil {
+			return err
+		}
+		artifacts = append(artifacts, newArtifact(BadgeArtifact, paths.badge))
+	}
+
+	if len(paths.metricsFile) > 0 {
+		if err := reporter.GenerateMetricsFile(paths.metricsFile, result); err != nil {
+			return err
+		}
+		artifacts = append(artifacts, newArtifact(MetricsArtifact, paths.metricsFile))
+	}
+
+	if len(c.pushgateway) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), pushgatewayTimeout)
+		defer cancel()
+		if err := reporter.PushMetrics(ctx, c.pushgateway, c.pushgatewayJob, result); err != nil {
+			return err
+		}
+	}
+
+	if len(paths.traceFile) > 0 && trace != nil {
+		if err := trace.Save(paths.traceFile); err != nil {
+			return err
+		}
+		artifacts = append(artifacts, newArtifact(TraceArtifact, paths.traceFile))
+	}
+
+	if len(paths.rerunOutput) > 0 {
+		if err := writeRerunFile(paths.rerunOutput, result); err != nil {
+			return err
+		}
+		artifacts = append(artifacts, newArtifact(RerunArtifact, paths.rerunOutput))
+	}
+
+	if len(artifacts) > 0 {
+		fmt.Println("Artifacts:")
+		for _, artifact := range artifacts {
+			fmt.Printf("  %-10s %s\n", artifact.Kind, artifact.Path)
+		}
+	}
+
+	if len(paths.artifactManifest) == 0 {
+		return nil
+	}
+	content, err := json.MarshalIndent(artifactManifest{Artifacts: artifacts}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(paths.artifactManifest, content, 0o644); err != nil {
+		return fmt.Errorf("could not write artifact manifest %s, error=%w", paths.artifactManifest, err)
+	}
+	fmt.Printf("Artifact manifest: %s\n", absolutePath(paths.artifactManifest))
+
+	return nil
+}
+
+// artifactPaths returns the paths of the artifacts of the run. If a report directory is configured, the directory
+// of the run is created in it and relative paths are resolved against that directory.
+func (c *CucumberRunner) artifactPaths(startedAt time.Time) (artifactPaths, error) {
+	paths := artifactPaths{
+		snippetFile:      c.snippetFile,
+		markdownReport:   c.markdownReport,
+		htmlReport:       c.htmlReport,
+		cucumberReport:   c.cucumberReport,
+		resultFile:       c.resultFile,
+		rerunOutput:      c.rerunOutput,
+		usageReport:      c.usageReport,
+		badge:            c.badge,
+		metricsFile:      c.metricsFile,
+		traceFile:        c.traceFile,
+		artifactManifest: c.artifactManifest,
+	}
+	if len(c.reportDirectory) == 0 {
+		return paths, nil
+	}
+
+	directory, err := createRunDirectory(c.reportDirectory, startedAt, commitSha())
+	if err != nil {
+		return paths, err
+	}
+	if len(paths.markdownReport) == 0 {
+		paths.markdownReport = defaultMarkdownReport
+	}
+	if len(paths.resultFile) == 0 {
+		paths.resultFile = defaultResultFile
+	}
+	paths.snippetFile = inDirectory(directory, paths.snippetFile)
+	paths.markdownReport = inDirectory(directory, paths.markdownReport)
+	paths.htmlReport = inDirectory(directory, paths.htmlReport)
+	paths.cucumberReport = inDirectory(directory, paths.cucumberReport)
+	paths.resultFile = inDirectory(directory, paths.resultFile)
+	paths.rerunOutput = inDirectory(directory, paths.rerunOutput)
+	paths.usageReport = inDirectory(directory, paths.usageReport)
+	paths.badge = inDirectory(directory, paths.badge)
+	paths.metricsFile = inDirectory(directory, paths.metricsFile)
+	paths.traceFile = inDirectory(directory, paths.traceFile)
+	paths.artifactManifest = inDirectory(directory, paths.artifactManifest)
+
+	return paths, nil
+}
+
+// createRunDirectory creates the directory <timestamp>-<sha> for the run in the report directory and points the
+// latest symlink to it. A numeric suffix is added if another run already created a directory with the same name.
+func createRunDirectory(reportDirectory string, startedAt time.Time, sha string) (string, error) {
+	if err := os.MkdirAll(reportDirectory, 0o755); err != nil {
+		return "", fmt.Errorf("could not create report directory %s, error=%w", reportDirectory, err)
+	}
+
+	name := startedAt.Format("20060102-150405")
+	if len(sha) > 0 {
+		name += "-" + sha
+	}
+	runName := name
+	for i := 2; ; i++ {
+		err := os.Mkdir(filepath.Join(reportDirectory, runName), 0o755)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return "", fmt.Errorf("could not create run directory in %s, error=%w", reportDirectory, err)
+		}
+		runName = fmt.Sprintf("%s-%d", name, i)
+	}
+
+	// the symlink is replaced by renaming so that a concurrent run never sees it missing
+	latest := filepath.Join(reportDirectory, LatestReportDirectory)
+	temporary := fmt.Sprintf("%s.%s", latest, runName)
+	if err := os.Symlink(runName, temporary); err != nil {
+		return "", fmt.Errorf("could not link %s to the run directory, error=%w", latest, err)
+	}
+	if err := os.Rename(temporary, latest); err != nil {
+		_ = os.Remove(temporary)
+
+		return "", fmt.Errorf("could not link %s to the run directory, error=%w", latest, err)
+	}
+
+	return filepath.Join(reportDirectory, runName), nil
+}
+
+// commitSha returns the short sha of the commit being tested, read from the CI environment or git
+func commitSha() string {
+	for _, variable := range []string{"GITHUB_SHA", "CI_COMMIT_SHA"} {
+		if sha := os.Getenv(variable); len(sha) > 0 {
+			return shortSha(sha)
+		}
+	}
+	output, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+
+	return shortSha(strings.TrimSpace(string(output)))
+}
+
+func shortSha(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+
+	return sha
+}
+
+// inDirectory resolves a relative path against the directory, keeping empty and absolute paths as they are
+func inDirectory(directory, path string) string {
+	if len(path) == 0 || filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(directory, path)
+}
+
+// writeSnippets prints the snippets of undefined steps or writes them to the snippet file.
+// It reports whether the snippet file was written.
+func writeSnippets(undefinedSteps []string, snippetFile string) (bool, error) {
+	if len(undefinedSteps) == 0 {
+		return false, nil
+	}
+	snippets := strings.Join(executor.GenerateSnippets(undefinedSteps), "\n")
+
+	if len(snippetFile) == 0 {
+		fmt.Printf("You can implement undefined steps with these snippets:\n\n%s", snippets)
+
+		return false, nil
+	}
+	if err := os.WriteFile(snippetFile, []byte(snippets), 0o644); err != nil {
+		return false, fmt.Errorf("could not write snippets to %s, error=%w", snippetFile, err)
+	}
+
+	return true, nil
+}
+
+func newArtifact(kind, path string) Artifact {
+	return Artifact{
+		Kind: kind,
+		Path: absolutePath(path),
+	}
+}
+
+func absolutePath(path string) string {
+	if absolute, err := filepath.Abs(path); err == nil {
+		return absolute
+	}
+
+	return path
+}
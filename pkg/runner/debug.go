@@ -0,0 +1,142 @@
+package runner
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	// DebugVariable pauses the run before every step if it is set. With an address, such as localhost:7357, the run
+	// is continued with POST /continue requests, otherwise with Enter.
+	DebugVariable = "CACIK_DEBUG"
+	// ContinuePath is the path of the HTTP endpoint continuing a paused step
+	ContinuePath = "/continue"
+)
+
+type (
+	// Debugger pauses scenarios before each of their steps and prints the step with the keys of the scenario data,
+	// so a debugger such as Delve can inspect the state of the scenario. Every Continue runs one paused step.
+	Debugger struct {
+		mutex     sync.Mutex
+		out       io.Writer
+		continues chan struct{}
+	}
+
+	// Pauser is implemented by executors which can pause before every step, such as executor.StepExecutor
+	Pauser interface {
+		SetPause(func(ctx context.Context, step string))
+	}
+)
+
+// NewDebugger creates a debugger printing the paused steps to out
+func NewDebugger(out io.Writer) *Debugger {
+	return &Debugger{
+		out:       out,
+		continues: make(chan struct{}),
+	}
+}
+
+// WithDebugger pauses the run before every step until the debugger is continued. The executor of the runner must
+// be able to pause, such as the default executor.
+func (c *CucumberRunner) WithDebugger(debugger *Debugger) *CucumberRunner {
+	pauser, ok := c.executor.(Pauser)
+	if !ok {
+		c.errors = append(c.errors, fmt.Errorf("executor %T can not pause before steps", c.executor))
+
+		return c
+	}
+	pauser.SetPause(debugger.Pause)
+
+	return c
+}
+
+// Pause prints the step with the keys of the scenario data and waits until the debugger is continued or the
+// context is done
+func (d *Debugger) Pause(ctx context.Context, step string) {
+	d.mutex.Lock()
+	fmt.Fprintf(d.out, "paused before step %q\ndata: %s\n", step, strings.Join(models.DataFrom(ctx).Keys(), ", "))
+	d.mutex.Unlock()
+
+	select {
+	case <-d.continues:
+	case <-ctx.Done():
+	}
+}
+
+// Continue runs the next paused step, waiting until a step pauses
+func (d *Debugger) Continue() {
+	d.continues <- struct{}{}
+}
+
+// ContinueOnLines continues a paused step for every line read from in, such as every Enter typed in a terminal
+func (d *Debugger) ContinueOnLines(in io.Reader) {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		d.Continue()
+	}
+}
+
+// ServeHTTP continues a paused step for every POST request to ContinuePath
+func (d *Debugger) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.URL.Path != ContinuePath {
+		http.NotFound(writer, request)
+
+		return
+	}
+	if request.Method != http.MethodPost {
+		writer.Header().Set("Allow", http.MethodPost)
+		http.Error(writer, "only POST is allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	select {
+	case d.continues <- struct{}{}:
+		writer.WriteHeader(http.StatusNoContent)
+	case <-request.Context().Done():
+	}
+}
+
+// debuggerFromEnvironment starts the debugger selected with DebugVariable, or returns nil if it is not set. The
+// returned function stops the HTTP server of the debugger and waits until it stopped serving.
+func debuggerFromEnvironment() (*Debugger, func(), error) {
+	address := os.Getenv(DebugVariable)
+	if len(address) == 0 {
+		return nil, func() {}, nil
+	}
+
+	debugger := NewDebugger(os.Stderr)
+	if !strings.Contains(address, ":") {
+		fmt.Fprintln(os.Stderr, "press Enter to run every step")
+		go debugger.ContinueOnLines(os.Stdin)
+
+		return debugger, func() {}, nil
+	}
+
+	server := &http.Server{Addr: address, Handler: debugger}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not start debugger on %s, error=%w", address, err)
+	}
+	fmt.Fprintf(os.Stderr, "send POST %s%s to run every step\n", listener.Addr(), ContinuePath)
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		_ = server.Serve(listener)
+	}()
+
+	// Close also cancels the requests waiting for a paused step, which Shutdown would wait for
+	return debugger, func() {
+		_ = server.Close()
+		<-served
+	}, nil
+}
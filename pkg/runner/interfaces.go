@@ -1,10 +1,48 @@
 //go:generate mockgen -source=interfaces.go -destination=interfaces_mock.go -package=runner
 package runner
 
-import messages "github.com/cucumber/messages/go/v21"
+import (
+	"context"
+
+	messages "github.com/cucumber/messages/go/v21"
+	"github.com/denizgursoy/cacik/pkg/executor"
+	"github.com/denizgursoy/cacik/pkg/models"
+)
 
 type (
+	// Executor executes the scenarios of the feature files for the runner. executor.StepExecutor is the default
+	// implementation. Other executors, such as one executing the scenarios on a remote agent, or wrappers
+	// observing an executor, can be passed to NewCucumberRunner. The methods are stable, new methods are only
+	// added in a new major version.
 	Executor interface {
-		Execute(*messages.GherkinDocument) error
+		// SetConfig sets the config with the hooks and providers of the run
+		SetConfig(*models.Config)
+		// SetFilter sets the filter selecting the pickles Execute executes
+		SetFilter(executor.PickleFilter)
+		// RegisterStep registers the function for the step definition
+		RegisterStep(string, any) error
+		// Provide registers a function creating the value of its result type for the step functions
+		Provide(any) error
+		// RegisterParameterType registers a parameter type by its name, regular expression and transformer
+		RegisterParameterType(string, string, func(string) (any, error)) error
+		// Execute executes the pickles of the document selected by the filter
+		Execute(*messages.GherkinDocument) ([]*models.ScenarioResult, error)
+		// ExecutePickle executes a single pickle of the document whether the filter selects it or not
+		ExecutePickle(context.Context, *messages.GherkinDocument, *messages.Pickle) *models.ScenarioResult
+		// MatchStep returns the step definition matching the step text with its captured arguments, or nil
+		MatchStep(string) (*executor.StepDefinition, []string)
+	}
+
+	// Reporter receives the results of a run while it executes, so reports can be written live. Reporters
+	// interested in some of the events can embed reporter.NoopReporter.
+	Reporter interface {
+		// RunStarted is called before the BeforeAll hooks with the start time and the tags of the run
+		RunStarted(*models.RunResult)
+		// ScenarioFinished is called for every executed or skipped scenario
+		ScenarioFinished(*models.ScenarioResult)
+		// FeatureFinished is called after the scenarios of a feature file selected by the tags
+		FeatureFinished(*models.FeatureResult)
+		// RunFinished is called after the AfterAll hooks with the results of every scenario
+		RunFinished(*models.RunResult)
 	}
 )
@@ -0,0 +1,67 @@
+package runner
+
+import (
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	cacikModule = "github.com/denizgursoy/cacik"
+)
+
+var (
+	// ciVariables identify the build of GitHub Actions, GitLab CI and Jenkins
+	ciVariables = []string{
+		"GITHUB_SHA", "GITHUB_REF_NAME", "GITHUB_REPOSITORY", "GITHUB_RUN_ID",
+		"CI_COMMIT_SHA", "CI_COMMIT_BRANCH", "CI_PROJECT_PATH", "CI_PIPELINE_ID",
+		"GIT_COMMIT", "GIT_BRANCH", "BUILD_NUMBER",
+	}
+)
+
+// newRunMeta collects the environment of the run filtered with the user tags
+func newRunMeta(userTags []string, getenv func(string) string) *models.RunMeta {
+	meta := &models.RunMeta{
+		GoVersion:    runtime.Version(),
+		OS:           runtime.GOOS,
+		Arch:         runtime.GOARCH,
+		CacikVersion: cacikVersion(),
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		meta.Hostname = hostname
+	}
+	if len(userTags) > 0 {
+		meta.TagExpression = "@" + strings.Join(userTags, " or @")
+	}
+	for _, variable := range ciVariables {
+		if value := getenv(variable); len(value) > 0 {
+			if meta.CI == nil {
+				meta.CI = make(map[string]string)
+			}
+			meta.CI[variable] = value
+		}
+	}
+
+	return meta
+}
+
+// cacikVersion returns the version of the cacik module in the build info of the program
+func cacikVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	if info.Main.Path == cacikModule {
+		return info.Main.Version
+	}
+	for _, dependency := range info.Deps {
+		if dependency.Path == cacikModule {
+			return dependency.Version
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,33 @@
+package runner
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRunMeta(t *testing.T) {
+	t.Run("should collect the environment of the run", func(t *testing.T) {
+		environment := map[string]string{"GITHUB_SHA": "abc123", "GITHUB_REF_NAME": "main", "HOME": "/root"}
+
+		meta := newRunMeta([]string{"smoke", "fast"}, func(variable string) string {
+			return environment[variable]
+		})
+
+		require.Equal(t, runtime.Version(), meta.GoVersion)
+		require.Equal(t, runtime.GOOS, meta.OS)
+		require.Equal(t, runtime.GOARCH, meta.Arch)
+		require.Equal(t, "@smoke or @fast", meta.TagExpression)
+		require.Equal(t, map[string]string{"GITHUB_SHA": "abc123", "GITHUB_REF_NAME": "main"}, meta.CI)
+	})
+
+	t.Run("should not have a tag expression or CI variables without them", func(t *testing.T) {
+		meta := newRunMeta(nil, func(string) string {
+			return ""
+		})
+
+		require.Empty(t, meta.TagExpression)
+		require.Nil(t, meta.CI)
+	})
+}
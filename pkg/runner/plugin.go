@@ -0,0 +1,147 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/denizgursoy/cacik/pkg/plugin"
+)
+
+type (
+	// stepLibrary is a step library of WithStepPlugin. Its steps are registered when the runner is validated or
+	// run for the first time. A subprocess is started by every validation and run, and stopped after it.
+	stepLibrary struct {
+		path       string
+		arguments  []string
+		registered bool
+		// err is the error of the registration, which is reported by every validation
+		err     error
+		process *plugin.Process
+	}
+)
+
+// WithStepPlugin registers the steps of the step library at path. A library ending with .so is opened as a Go
+// plugin exporting plugin.StepsSymbol, any other library is started as a subprocess with the arguments speaking the
+// step protocol of plugin.Process. Libraries are loaded by Validate and Run, and subprocesses are stopped when they
+// return. Steps of subprocesses take their arguments as models.Args. Loading errors are reported by Validate and
+// RunWithTags.
+func (c *CucumberRunner) WithStepPlugin(path string, arguments ...string) *CucumberRunner {
+	c.plugins = append(c.plugins, &stepLibrary{path: path, arguments: arguments})
+
+	return c
+}
+
+// loadPlugins registers the steps of the step libraries not registered yet and starts their subprocesses. It
+// returns the errors of the libraries which could not be loaded.
+func (c *CucumberRunner) loadPlugins() []error {
+	problems := make([]error, 0)
+	for _, library := range c.plugins {
+		if !library.registered {
+			library.registered = true
+			library.err = c.registerPlugin(library)
+		}
+		if library.err != nil {
+			problems = append(problems, library.err)
+
+			continue
+		}
+		if filepath.Ext(library.path) != ".so" && library.process == nil {
+			process, err := plugin.Start(library.path, library.arguments...)
+			if err != nil {
+				problems = append(problems, err)
+
+				continue
+			}
+			library.process = process
+		}
+	}
+
+	return problems
+}
+
+// registerPlugin registers the steps of the step library, whose subprocess is started to list them
+func (c *CucumberRunner) registerPlugin(library *stepLibrary) error {
+	if filepath.Ext(library.path) == ".so" {
+		steps, err := plugin.Open(library.path)
+		if err != nil {
+			return err
+		}
+		c.registerPluginSteps(library.path, steps, nil)
+
+		return nil
+	}
+
+	process, err := plugin.Start(library.path, library.arguments...)
+	if err != nil {
+		return err
+	}
+	library.process = process
+	definitions, err := process.Definitions(context.Background())
+	if err != nil {
+		return err
+	}
+	steps := make(plugin.Steps, len(definitions))
+	for _, definition := range definitions {
+		steps[definition] = func(ctx context.Context, args models.Args) error {
+			return library.run(ctx, definition, args)
+		}
+	}
+	// the steps run in the subprocess, so their failures point at the library instead of the function calling it
+	c.registerPluginSteps(library.path, steps, &models.SourceLocation{File: library.path})
+
+	return nil
+}
+
+// registerPluginSteps registers the steps in the order of their definitions, so errors are reported in the same
+// order every run. The steps are defined at the location if it is set, otherwise at their functions.
+func (c *CucumberRunner) registerPluginSteps(path string, steps plugin.Steps, location *models.SourceLocation) {
+	register := c.executor.RegisterStep
+	if locator, ok := c.executor.(StepLocator); ok && location != nil {
+		register = func(definition string, function any) error {
+			if err := c.executor.RegisterStep(definition, function); err != nil {
+				return err
+			}
+			locator.SetStepLocation(definition, location)
+
+			return nil
+		}
+	}
+
+	definitions := make([]string, 0, len(steps))
+	for definition := range steps {
+		definitions = append(definitions, definition)
+	}
+	sort.Strings(definitions)
+	for _, definition := range definitions {
+		c.registerStep(definition, steps[definition], "plugin "+path, "", register)
+	}
+}
+
+// run runs the step of the definition in the subprocess of the current validation or run
+func (l *stepLibrary) run(ctx context.Context, definition string, args models.Args) error {
+	if l.process == nil {
+		return fmt.Errorf("step library %s is not running", l.path)
+	}
+
+	return l.process.Run(ctx, definition, args)
+}
+
+// closePlugins stops the step libraries running as subprocesses
+func (c *CucumberRunner) closePlugins() error {
+	problems := make([]error, 0)
+	for _, library := range c.plugins {
+		if library.process == nil {
+			continue
+		}
+		if err := library.process.Close(); err != nil {
+			problems = append(problems, fmt.Errorf("could not stop step library %s, error=%w", library.path, err))
+		}
+		library.process = nil
+	}
+
+	return errors.Join(problems...)
+}
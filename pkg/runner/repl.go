@@ -0,0 +1,130 @@
+package runner
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/denizgursoy/cacik/pkg/executor"
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	// ReplVariable starts the REPL instead of the run in RunWithTags if it is set, which is how `cacik repl` runs
+	// the generated main file
+	ReplVariable = "CACIK_REPL"
+
+	replPrompt = "cacik> "
+	replHelp   = `Type a step, such as "Given I have 3 apples", to run it in the scenario of the session.
+Steps are read line by line, so they are not completed while typing.
+  :steps [text] list the registered steps, or the ones the start of a step can be completed to
+  :data         list the keys of the scenario data
+  :save <path>  save the steps run so far as a feature file
+  :reset        start a new scenario
+  :quit         exit
+`
+)
+
+type (
+	// SessionStarter is implemented by executors which can run steps one by one, such as executor.StepExecutor
+	SessionStarter interface {
+		NewSession(ctx context.Context, name string) *executor.Session
+	}
+)
+
+// Repl reads steps from in and runs them one by one in the scenario of a session, writing their outcome to out,
+// so step functions can be explored and debugged interactively. It returns when in ends or :quit is typed.
+func (c *CucumberRunner) Repl(in io.Reader, out io.Writer) error {
+	defer c.closePlugins()
+	if err := c.validate(nil); err != nil {
+		return err
+	}
+	starter, ok := c.executor.(SessionStarter)
+	if !ok {
+		return fmt.Errorf("executor %T can not run steps one by one", c.executor)
+	}
+	session := starter.NewSession(context.Background(), "REPL")
+
+	fmt.Fprint(out, replHelp)
+	scanner := bufio.NewScanner(in)
+	for fmt.Fprint(out, replPrompt); scanner.Scan(); fmt.Fprint(out, replPrompt) {
+		command := strings.TrimSpace(scanner.Text())
+		switch {
+		case len(command) == 0:
+		case command == ":quit":
+			return nil
+		case command == ":steps" || strings.HasPrefix(command, ":steps "):
+			for _, definition := range c.matchingSteps(strings.TrimPrefix(command, ":steps")) {
+				fmt.Fprintln(out, definition)
+			}
+		case command == ":data":
+			fmt.Fprintln(out, strings.Join(models.DataFrom(session.Context()).Keys(), "\n"))
+		case command == ":reset":
+			session = starter.NewSession(context.Background(), "REPL")
+		case strings.HasPrefix(command, ":save "):
+			path := strings.TrimSpace(strings.TrimPrefix(command, ":save "))
+			if err := session.WriteFeature(path, "REPL"); err != nil {
+				fmt.Fprintln(out, err)
+			}
+		case strings.HasPrefix(command, ":"):
+			fmt.Fprint(out, replHelp)
+		default:
+			err := session.RunStep(command)
+			writeStepOutput(out, session.Scenario().Steps)
+			if err != nil {
+				fmt.Fprintln(out, err)
+			} else {
+				fmt.Fprintln(out, "ok")
+			}
+		}
+	}
+
+	return scanner.Err()
+}
+
+// writeStepOutput writes the logs and the attachments of the last step run in the REPL
+func writeStepOutput(out io.Writer, steps []*models.StepResult) {
+	if len(steps) == 0 {
+		return
+	}
+	step := steps[len(steps)-1]
+	for _, entry := range step.Logs {
+		fmt.Fprintln(out, entry)
+	}
+	for _, attachment := range step.Attachments {
+		fmt.Fprintf(out, "attached %s (%s, %d bytes)\n", attachment.Name, attachment.MediaType, len(attachment.Data))
+	}
+}
+
+// matchingSteps returns the sorted registered step definitions the text can be completed to. The Gherkin keyword
+// of the text is ignored.
+func (c *CucumberRunner) matchingSteps(text string) []string {
+	_, text = executor.SplitKeyword(text)
+	text = strings.ToLower(text)
+
+	definitions := make([]string, 0)
+	for definition := range c.steps {
+		readable := strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(definition, "^"), "$"))
+		// the text is completed to the definition if the definition starts with it, or if it continues the
+		// literal start of the definition with parameters
+		literal := readable
+		if index := strings.IndexAny(readable, `{(\[.*+?|`); index >= 0 {
+			literal = readable[:index]
+		}
+		if strings.HasPrefix(readable, text) || (len(literal) > 0 && strings.HasPrefix(text, literal)) {
+			definitions = append(definitions, definition)
+		}
+	}
+	sort.Strings(definitions)
+
+	return definitions
+}
+
+// replRequested reports whether the REPL is started instead of the run
+func replRequested() bool {
+	return len(os.Getenv(ReplVariable)) > 0
+}
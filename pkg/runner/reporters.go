@@ -0,0 +1,42 @@
+package runner
+
+import "github.com/denizgursoy/cacik/pkg/models"
+
+type (
+	// MultiReporter passes every event to its reporters in order, so several reporters can be used where one is
+	// expected, such as a reporter wrapping another one
+	MultiReporter []Reporter
+)
+
+// NewMultiReporter creates a reporter passing every event to the reporters in the given order
+func NewMultiReporter(reporters ...Reporter) MultiReporter {
+	return reporters
+}
+
+// RunStarted passes the start of the run to every reporter
+func (m MultiReporter) RunStarted(run *models.RunResult) {
+	for _, reporter := range m {
+		reporter.RunStarted(run)
+	}
+}
+
+// ScenarioFinished passes the result of the scenario to every reporter
+func (m MultiReporter) ScenarioFinished(scenario *models.ScenarioResult) {
+	for _, reporter := range m {
+		reporter.ScenarioFinished(scenario)
+	}
+}
+
+// FeatureFinished passes the results of the feature file to every reporter
+func (m MultiReporter) FeatureFinished(feature *models.FeatureResult) {
+	for _, reporter := range m {
+		reporter.FeatureFinished(feature)
+	}
+}
+
+// RunFinished passes the result of the run to every reporter
+func (m MultiReporter) RunFinished(run *models.RunResult) {
+	for _, reporter := range m {
+		reporter.RunFinished(run)
+	}
+}
@@ -0,0 +1,67 @@
+package runner
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+// readRerunFile reads the locations of the scenarios in a rerun file. Every line of a rerun file lists the lines
+// of the scenarios of a feature file as path/to.feature:line[:line...].
+func readRerunFile(path string) (map[string][]int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read rerun file %s, error=%w", path, err)
+	}
+	defer file.Close()
+
+	locations := make(map[string][]int)
+	scanner := bufio.NewScanner(file)
+	for number := 1; scanner.Scan(); number++ {
+		for _, field := range strings.Fields(scanner.Text()) {
+			// the lines are split from the end, so paths containing colons, such as C:\features, are kept
+			feature, lines := splitFeatureLocation(field)
+			if len(lines) == 0 {
+				suffix := field[strings.LastIndex(field, ":")+1:]
+				if strings.Contains(field, ":") && !strings.ContainsAny(suffix, `/\`) {
+					return nil, fmt.Errorf("rerun file %s line %d: location %q has an invalid line", path, number, field)
+				}
+
+				return nil, fmt.Errorf("rerun file %s line %d: location %q has no line", path, number, field)
+			}
+			locations[feature] = append(locations[feature], lines...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read rerun file %s, error=%w", path, err)
+	}
+
+	return locations, nil
+}
+
+// writeRerunFile writes the locations of the failed scenarios of the run to the file. The file is empty if no
+// scenario failed.
+func writeRerunFile(path string, result *models.RunResult) error {
+	uris := make([]string, 0)
+	lines := make(map[string][]string)
+	for _, scenario := range result.FailedScenarios() {
+		if _, ok := lines[scenario.Uri]; !ok {
+			uris = append(uris, scenario.Uri)
+		}
+		lines[scenario.Uri] = append(lines[scenario.Uri], strconv.Itoa(scenario.Line))
+	}
+
+	var builder strings.Builder
+	for _, uri := range uris {
+		builder.WriteString(uri + ":" + strings.Join(lines[uri], ":") + "\n")
+	}
+	if err := os.WriteFile(path, []byte(builder.String()), 0o644); err != nil {
+		return fmt.Errorf("could not write rerun file %s, error=%w", path, err)
+	}
+
+	return nil
+}
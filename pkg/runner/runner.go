@@ -1,37 +1,174 @@
 package runner
 
 import (
-	"bytes"
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
 	"slices"
+	"strconv"
+	"strings"
+	"time"
 
-	gherkin "github.com/cucumber/gherkin/go/v26"
 	messages "github.com/cucumber/messages/go/v21"
+	"github.com/denizgursoy/cacik/pkg/config_file"
+	"github.com/denizgursoy/cacik/pkg/converter"
+	"github.com/denizgursoy/cacik/pkg/executor"
 	"github.com/denizgursoy/cacik/pkg/gherkin_parser"
 	"github.com/denizgursoy/cacik/pkg/models"
-	"github.com/gofrs/uuid"
+	"github.com/denizgursoy/cacik/pkg/reporter"
 )
 
 type (
 	CucumberRunner struct {
 		config             *models.Config
 		featureDirectories []string
+		featurePaths       []string
+		featuresFS         fs.FS
+		featureRoots       []string
+		featureLocations   map[string][]int
+		nameFilter         string
 		steps              map[string]any
+		registrationSites  map[string]string
 		executor           Executor
+		snippetFile        string
+		markdownReport     string
+		htmlReport         string
+		htmlOptions        reporter.HTMLReportOptions
+		cucumberReport     string
+		historyDirectory   string
+		resultFile         string
+		artifactManifest   string
+		reportDirectory    string
+		rerunFile          string
+		rerunOutput        string
+		usageReport        string
+		badge              string
+		metricsFile        string
+		pushgateway        string
+		pushgatewayJob     string
+		traceFile          string
+		// plugins are the step libraries of WithStepPlugin, which are loaded by Validate and Run
+		plugins     []*stepLibrary
+		projectFile string
+		timeZones   []string
+		format      string
+		symbols     string
+		palette     string
+		// locations hides the locations in the console output if it is false, and is nil if it is not set
+		locations *bool
+		// verbosity is the name of the reporter.Verbosity of the console reporters
+		verbosity string
+		// keywordSites are the sites of the registrations by their keyword and definition
+		keywordSites map[string]string
+		// registrations are the steps which are not registered on the executor yet
+		registrations []*registration
+		// diagnostics is set by WithDiagnostics, which can not measure scenarios executed at the same time
+		diagnostics bool
+		// strictKeywords is set by WithStrictKeywords
+		strictKeywords bool
+		// slowStepThreshold marks the steps taking longer as slow if it is set
+		slowStepThreshold time.Duration
+		shardIndex        int
+		shardTotal        int
+		reporters         MultiReporter
+		errors            []error
+	}
+
+	// registration is a step registered on the runner, which is registered on the executor with register
+	registration struct {
+		definition string
+		function   any
+		site       string
+		register   func(string, any) error
+	}
+
+	// ScenariosFailedError is returned by Run if every scenario was executed but some of them failed, so it can be
+	// told apart from the errors stopping the run
+	ScenariosFailedError struct {
+		// Scenarios are the failed scenarios written as <uri>: <name>
+		Scenarios []string
+	}
+
+	// featureFile is a feature file on the disk, or in fsys if it is set
+	featureFile struct {
+		path string
+		fsys fs.FS
+	}
+
+	// KeywordRegistrar is implemented by executors which can register step definitions for the steps of a keyword,
+	// such as executor.StepExecutor
+	KeywordRegistrar interface {
+		RegisterGiven(definition string, function any) error
+		RegisterWhen(definition string, function any) error
+		RegisterThen(definition string, function any) error
+		SetStrictKeywords(strict bool)
+	}
+
+	// Clocker is implemented by executors which can set the clock of the arguments and the wait steps of their
+	// scenarios, such as executor.StepExecutor
+	Clocker interface {
+		SetClock(clock func() time.Time)
+	}
+
+	// RunStarter is implemented by executors which keep state across the feature files of a run, such as the failed
+	// scenarios of a fail fast run of executor.StepExecutor, which is reset at the start of every run
+	RunStarter interface {
+		StartRun()
+	}
+
+	// ScenarioNotifier is implemented by executors which can report every scenario as soon as it finishes instead of
+	// after its feature file, such as executor.StepExecutor
+	ScenarioNotifier interface {
+		SetScenarioFinished(finished func(scenario *models.ScenarioResult))
+	}
+
+	// StepLocator is implemented by executors which can set where steps are defined, such as executor.StepExecutor
+	StepLocator interface {
+		SetStepLocation(definition string, location *models.SourceLocation)
+	}
+
+	// ConfigChecker is implemented by executors which can report the problems of the config set with SetConfig, such
+	// as executor.StepExecutor
+	ConfigChecker interface {
+		ConfigErrors() error
+	}
+
+	// Diagnoser is implemented by executors which can measure the allocations and goroutines of scenarios, such as
+	// executor.StepExecutor
+	Diagnoser interface {
+		SetDiagnostics(enabled bool)
 	}
 )
 
 func NewCucumberRunner(exec Executor) *CucumberRunner {
+	if exec == nil {
+		exec = executor.NewStepExecutor()
+	}
+
 	return &CucumberRunner{
-		steps:    make(map[string]any),
-		executor: exec,
+		steps:             make(map[string]any),
+		registrationSites: make(map[string]string),
+		keywordSites:      make(map[string]string),
+		featureLocations:  make(map[string][]int),
+		executor:          exec,
 	}
 }
 
 func (c *CucumberRunner) WithConfigFunc(configFunction func() *models.Config) *CucumberRunner {
 	if configFunction != nil {
 		c.config = configFunction()
+		c.executor.SetConfig(c.config)
+		if checker, ok := c.executor.(ConfigChecker); ok {
+			if err := checker.ConfigErrors(); err != nil {
+				c.errors = append(c.errors, fmt.Errorf("%w, registered at %s", err, callerSite(2)))
+			}
+		}
 	}
 
 	return c
@@ -43,46 +180,965 @@ func (c *CucumberRunner) WithFeaturesDirectories(directories ...string) *Cucumbe
 	return c
 }
 
+// WithFeaturesFS executes the feature files under the roots of the file system, such as the features compiled into
+// the test binary with go:embed, in addition to the feature files on the disk. The roots are slash separated paths
+// in the file system and default to its root. The Uri of the scenarios is their path in the file system.
+func (c *CucumberRunner) WithFeaturesFS(fsys fs.FS, roots ...string) *CucumberRunner {
+	if fsys == nil {
+		c.errors = append(c.errors, errors.New("feature file system can not be nil"))
+
+		return c
+	}
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+	c.featuresFS = fsys
+	c.featureRoots = roots
+
+	return c
+}
+
+// WithFeaturePaths adds feature files and directories to execute. A feature file can be followed by the lines of
+// its scenarios or Examples rows, such as features/login.feature:42 or features/login.feature:42:57, to execute
+// only those scenarios of the file.
+func (c *CucumberRunner) WithFeaturePaths(paths ...string) *CucumberRunner {
+	for _, path := range paths {
+		file, lines := splitFeatureLocation(path)
+		c.featurePaths = append(c.featurePaths, file)
+		if len(lines) > 0 {
+			key := filepath.Clean(file)
+			c.featureLocations[key] = append(c.featureLocations[key], lines...)
+		}
+	}
+
+	return c
+}
+
+// WithNameFilter executes only the scenarios whose name matches the regular expression
+func (c *CucumberRunner) WithNameFilter(pattern string) *CucumberRunner {
+	c.nameFilter = pattern
+
+	return c
+}
+
+// WithSnippetFile writes the snippets of undefined steps to the file instead of printing them
+func (c *CucumberRunner) WithSnippetFile(path string) *CucumberRunner {
+	c.snippetFile = path
+
+	return c
+}
+
+// WithMarkdownReport writes a markdown summary of the run to the file after the run
+func (c *CucumberRunner) WithMarkdownReport(path string) *CucumberRunner {
+	c.markdownReport = path
+
+	return c
+}
+
+// WithHTMLReport writes a single HTML page of the run to the file after the run, whose scenarios can be searched,
+// filtered by status and linked to
+func (c *CucumberRunner) WithHTMLReport(path string) *CucumberRunner {
+	c.htmlReport = path
+
+	return c
+}
+
+// WithHTMLReportOptions sets the title, logo and theme of the HTML report and whether attachments and the run
+// result are embedded in it
+func (c *CucumberRunner) WithHTMLReportOptions(options reporter.HTMLReportOptions) *CucumberRunner {
+	c.htmlOptions = options
+
+	return c
+}
+
+// WithCucumberJSONReport writes the run as a Cucumber JSON report to the file after the run, with the attachments
+// of the steps and hooks as embeddings, so report plugins of CI servers reading the format can show the results
+func (c *CucumberRunner) WithCucumberJSONReport(path string) *CucumberRunner {
+	c.cucumberReport = path
+
+	return c
+}
+
+// WithHistory appends a summary of every run to the directory, and adds pass rate and duration trends of the last
+// runs in it to the HTML report
+func (c *CucumberRunner) WithHistory(directory string) *CucumberRunner {
+	c.historyDirectory = directory
+
+	return c
+}
+
+// WithResultFile saves the RunResult as JSON to the file after the run. Saved results can be loaded with
+// models.LoadRunResult and merged with models.MergeRunResults.
+func (c *CucumberRunner) WithResultFile(path string) *CucumberRunner {
+	c.resultFile = path
+
+	return c
+}
+
+// WithArtifactManifest writes a JSON manifest listing every artifact created by the run to the file, so CI
+// steps can upload the artifacts without hardcoding their paths
+func (c *CucumberRunner) WithArtifactManifest(path string) *CucumberRunner {
+	c.artifactManifest = path
+
+	return c
+}
+
+// WithReportDirectory writes the artifacts of every run to its own directory <timestamp>-<sha> in the directory,
+// so concurrent runs do not overwrite each other's artifacts. Relative artifact paths are resolved against the run
+// directory, the markdown report and the result file are written even if they are not configured, and the symlink
+// latest always points to the directory of the last run.
+func (c *CucumberRunner) WithReportDirectory(directory string) *CucumberRunner {
+	c.reportDirectory = directory
+
+	return c
+}
+
+// WithRerunOutput writes the locations of the failed scenarios to the file after the run, in the format read by
+// WithRerunFile
+func (c *CucumberRunner) WithRerunOutput(path string) *CucumberRunner {
+	c.rerunOutput = path
+
+	return c
+}
+
+// WithRerunFile executes only the scenarios listed in the rerun file, such as the one written by WithRerunOutput.
+// Every line of the file lists the scenario lines of a feature file as path/to.feature:line[:line...].
+func (c *CucumberRunner) WithRerunFile(path string) *CucumberRunner {
+	c.rerunFile = path
+
+	return c
+}
+
+// WithUsageReport writes a markdown report of the registered step definitions never matched in the run and of
+// the step texts matched by the others to the file after the run
+func (c *CucumberRunner) WithUsageReport(path string) *CucumberRunner {
+	c.usageReport = path
+
+	return c
+}
+
+// WithBadge writes an SVG badge with the passed and failed scenario counts and the pass rate to the file after
+// the run, so the result can be embedded in a README or a dashboard
+func (c *CucumberRunner) WithBadge(path string) *CucumberRunner {
+	c.badge = path
+
+	return c
+}
+
+// WithMetricsFile writes the metrics of the run in the Prometheus text format to the file after the run, such as a
+// .prom file in the directory of the textfile collector of the node exporter
+func (c *CucumberRunner) WithMetricsFile(path string) *CucumberRunner {
+	c.metricsFile = path
+
+	return c
+}
+
+// WithPushgateway pushes the metrics of the run to the Prometheus Pushgateway at the address, such as
+// http://localhost:9091, with the job, or reporter.DefaultMetricsJob if it is empty
+func (c *CucumberRunner) WithPushgateway(address, job string) *CucumberRunner {
+	c.pushgateway = address
+	c.pushgatewayJob = job
+
+	return c
+}
+
+// WithTraceFile records every scenario, hook, step and argument conversion with its duration and goroutine, and
+// writes them to the file after the run in the Chrome trace event format, which Perfetto opens. The trace shows the
+// order of scenarios executed at the same time. The executor of the runner must be able to trace, such as the
+// default executor.
+func (c *CucumberRunner) WithTraceFile(path string) *CucumberRunner {
+	c.traceFile = path
+
+	return c
+}
+
+// WithProjectFile reads the settings of the config file at path, such as cacik.yaml. Without this option, the
+// cacik.yaml in the root of the module is read if there is one. Settings of the file are used only if the same
+// setting is not configured on the runner.
+func (c *CucumberRunner) WithProjectFile(path string) *CucumberRunner {
+	c.projectFile = path
+
+	return c
+}
+
+// WithTimeZones makes Validate check that the IANA time zones, such as Europe/Istanbul, can be loaded, so a
+// missing time zone database fails the run at startup instead of in the middle of a scenario
+func (c *CucumberRunner) WithTimeZones(names ...string) *CucumberRunner {
+	c.timeZones = append(c.timeZones, names...)
+
+	return c
+}
+
+// WithClock sets the clock of the run, so the {now} placeholder, calendar durations and the wait steps use a
+// frozen or mocked time. The executor of the runner must be able to set the clock, such as the default executor.
+func (c *CucumberRunner) WithClock(clock func() time.Time) *CucumberRunner {
+	clocker, ok := c.executor.(Clocker)
+	if !ok {
+		c.errors = append(c.errors, fmt.Errorf("executor %T can not set the clock", c.executor))
+
+		return c
+	}
+	clocker.SetClock(clock)
+
+	return c
+}
+
+// WithShard executes only the scenarios of the shard with the index, starting from 0, out of total shards.
+// Scenarios are assigned to shards by a stable hash, so each CI job can run one shard of the suite.
+func (c *CucumberRunner) WithShard(index, total int) *CucumberRunner {
+	c.shardIndex = index
+	c.shardTotal = total
+
+	return c
+}
+
+// WithReporter adds a reporter which is notified when the run starts and when a scenario, a feature or the run
+// finishes
+func (c *CucumberRunner) WithReporter(reporter Reporter) *CucumberRunner {
+	c.reporters = append(c.reporters, reporter)
+
+	return c
+}
+
+// WithFormat adds the built-in reporter with the name writing to the standard output.
+// Supported formats: test2json, pretty, progress
+func (c *CucumberRunner) WithFormat(format string) *CucumberRunner {
+	c.format = format
+	switch format {
+	case reporter.Test2JSONFormat:
+		c.reporters = append(c.reporters, reporter.NewTest2JSONReporter(os.Stdout))
+	case reporter.ConsoleFormat:
+		c.reporters = append(c.reporters, reporter.NewConsoleReporter(os.Stdout))
+	case reporter.ProgressFormat:
+		c.reporters = append(c.reporters, reporter.NewProgressReporter(os.Stdout))
+	}
+
+	return c
+}
+
+// WithSymbols marks the statuses of steps in the console output with the symbols with the name, such as
+// reporter.ASCIISymbols for [PASS] and [FAIL]
+func (c *CucumberRunner) WithSymbols(name string) *CucumberRunner {
+	c.symbols = name
+
+	return c
+}
+
+// WithPalette colors the statuses of steps in the console output with the palette with the name, such as
+// reporter.ColorblindPalette
+func (c *CucumberRunner) WithPalette(name string) *CucumberRunner {
+	c.palette = name
+
+	return c
+}
+
+// WithLocations sets whether the console output writes the locations of scenarios and failed steps in the feature
+// files, such as # features/login.feature:12. They are written by default.
+func (c *CucumberRunner) WithLocations(enabled bool) *CucumberRunner {
+	c.locations = &enabled
+
+	return c
+}
+
+// WithVerbosity sets how much of the run the console output writes: silent, summary, normal or verbose, which
+// writes the messages logged by every step under it
+func (c *CucumberRunner) WithVerbosity(name string) *CucumberRunner {
+	c.verbosity = name
+
+	return c
+}
+
+// WithDiagnostics measures the heap allocations and goroutines of every scenario and flags the scenarios leaving
+// goroutines running in the HTML report. The executor of the runner must be able to measure them, such as the
+// default executor. The allocations and goroutines are counted for the whole process, so the scenarios must be
+// executed one at a time and a Concurrency above 1 is reported by Validate. Calling it again has no effect.
+func (c *CucumberRunner) WithDiagnostics() *CucumberRunner {
+	if c.diagnostics {
+		return c
+	}
+	c.diagnostics = true
+	diagnoser, ok := c.executor.(Diagnoser)
+	if !ok {
+		c.errors = append(c.errors, fmt.Errorf("executor %T can not measure scenarios", c.executor))
+
+		return c
+	}
+	diagnoser.SetDiagnostics(true)
+
+	return c
+}
+
+// WithSlowStepThreshold marks the steps taking longer than the threshold as slow, which the console output
+// highlights and the HTML report badges
+func (c *CucumberRunner) WithSlowStepThreshold(threshold time.Duration) *CucumberRunner {
+	c.slowStepThreshold = threshold
+
+	return c
+}
+
+// RegisterStep registers the function for the step definition. Registration errors are not returned here;
+// they are reported by Validate and RunWithTags.
 func (c *CucumberRunner) RegisterStep(definition string, function any) *CucumberRunner {
-	if _, ok := c.steps[definition]; ok {
-		panic(definition)
+	return c.registerStep(definition, function, callerSite(2), "", c.executor.RegisterStep)
+}
+
+// RegisterGiven registers the function for the step definition of Given steps, including the And and But steps
+// following them. Using it for When or Then steps adds a warning to the step, or fails the step with
+// WithStrictKeywords. The executor must be able to register steps for keywords, such as the default executor.
+func (c *CucumberRunner) RegisterGiven(definition string, function any) *CucumberRunner {
+	return c.registerKeywordStep(definition, function, callerSite(2), "Given", KeywordRegistrar.RegisterGiven)
+}
+
+// RegisterWhen registers the function for the step definition of When steps, see RegisterGiven
+func (c *CucumberRunner) RegisterWhen(definition string, function any) *CucumberRunner {
+	return c.registerKeywordStep(definition, function, callerSite(2), "When", KeywordRegistrar.RegisterWhen)
+}
+
+// RegisterThen registers the function for the step definition of Then steps, see RegisterGiven
+func (c *CucumberRunner) RegisterThen(definition string, function any) *CucumberRunner {
+	return c.registerKeywordStep(definition, function, callerSite(2), "Then", KeywordRegistrar.RegisterThen)
+}
+
+// WithStrictKeywords fails the steps using a step definition registered for another keyword, such as a Given step
+// matching a definition registered with RegisterThen, instead of adding a warning to them. Calling it again has no
+// effect.
+func (c *CucumberRunner) WithStrictKeywords() *CucumberRunner {
+	if c.strictKeywords {
+		return c
+	}
+	c.strictKeywords = true
+	registrar, ok := c.executor.(KeywordRegistrar)
+	if !ok {
+		c.errors = append(c.errors, fmt.Errorf("executor %T can not register steps for keywords", c.executor))
+
+		return c
+	}
+	registrar.SetStrictKeywords(true)
+
+	return c
+}
+
+// registerKeywordStep registers the function for the step definition of a keyword with the registrar method
+func (c *CucumberRunner) registerKeywordStep(definition string, function any, site, keyword string,
+	register func(KeywordRegistrar, string, any) error) *CucumberRunner {
+	registrar, ok := c.executor.(KeywordRegistrar)
+	if !ok {
+		c.errors = append(c.errors, fmt.Errorf("executor %T can not register steps for keywords, registered at %s",
+			c.executor, site))
+
+		return c
+	}
+
+	return c.registerStep(definition, function, site, keyword, func(definition string, function any) error {
+		return register(registrar, definition, function)
+	})
+}
+
+// registerStep registers the function for the step definition of the keyword, or of every keyword if it is empty,
+// keeping the site it is registered at. A definition can be registered once for every keyword. The step is
+// registered on the executor with register by registerSteps.
+func (c *CucumberRunner) registerStep(definition string, function any, site, keyword string,
+	register func(string, any) error) *CucumberRunner {
+	key := keyword + " " + definition
+	if first, ok := c.keywordSites[key]; ok {
+		c.errors = append(c.errors, fmt.Errorf("step %s is registered more than once, first at %s and again at %s",
+			definition, first, site))
+
+		return c
+	}
+	c.keywordSites[key] = site
+	c.registrations = append(c.registrations, &registration{definition: definition, function: function, site: site,
+		register: register})
+
+	return c
+}
+
+// registerSteps registers the steps on the executor, after every parameter type they may use is registered
+func (c *CucumberRunner) registerSteps() {
+	for _, step := range c.registrations {
+		if err := step.register(step.definition, step.function); err != nil {
+			c.errors = append(c.errors, fmt.Errorf("%w, registered at %s", err, step.site))
+
+			continue
+		}
+		if _, ok := c.steps[step.definition]; !ok {
+			c.steps[step.definition] = step.function
+			c.registrationSites[step.definition] = step.site
+		}
+	}
+	c.registrations = nil
+}
+
+// RegisterParameterType adds the parameter type {name} matching the regular expression to the step definitions.
+// The text matched by it is converted with the transform function, such as parsing a {money} into a Money value.
+// Steps are registered on the executor by Validate and RunWithTags, so parameter types can be registered after the
+// steps using them. Registration errors are reported by Validate and RunWithTags.
+func (c *CucumberRunner) RegisterParameterType(name, regex string, transform func(string) (any, error)) *CucumberRunner {
+	if err := c.executor.RegisterParameterType(name, regex, transform); err != nil {
+		c.errors = append(c.errors, fmt.Errorf("%w, registered at %s", err, callerSite(2)))
+	}
+
+	return c
+}
+
+// Provide registers a function creating the value of its result type, such as func() *http.Client, for the step
+// functions declaring a parameter of that type. The value is created once per scenario, see
+// executor.StepExecutor.Provide. Registration errors are reported by Validate and RunWithTags.
+func (c *CucumberRunner) Provide(provider any) *CucumberRunner {
+	if err := c.executor.Provide(provider); err != nil {
+		c.errors = append(c.errors, fmt.Errorf("%w, registered at %s", err, callerSite(2)))
 	}
-	c.steps[definition] = function
 
 	return c
 }
 
+// Validate checks the whole configuration of the runner and returns all problems found as a single error. The step
+// libraries of WithStepPlugin are loaded to check their steps, and their subprocesses are stopped before it returns.
+func (c *CucumberRunner) Validate(userTags ...string) error {
+	err := c.validate(userTags)
+	if closeErr := c.closePlugins(); closeErr != nil {
+		return errors.Join(err, closeErr)
+	}
+
+	return err
+}
+
+// validate checks the configuration like Validate, and keeps the subprocesses of the step libraries running
+func (c *CucumberRunner) validate(userTags []string) error {
+	problems := make([]error, 0)
+	problems = append(problems, c.loadPlugins()...)
+	c.registerSteps()
+	problems = append(problems, c.errors...)
+	problems = append(problems, executor.ValidateHooks(c.config)...)
+	problems = append(problems, executor.ValidateProviders(c.config)...)
+	problems = append(problems, executor.ValidateResourceLimits(c.config)...)
+
+	if _, ok := c.executor.(Tracer); len(c.traceFile) > 0 && !ok {
+		problems = append(problems, fmt.Errorf("executor %T can not record a trace", c.executor))
+	}
+
+	if len(c.symbols) > 0 {
+		if _, err := reporter.SymbolsByName(c.symbols); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	if len(c.palette) > 0 {
+		if _, err := reporter.PaletteByName(c.palette); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	if len(c.verbosity) > 0 {
+		if _, err := reporter.VerbosityByName(c.verbosity); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	if c.diagnostics && c.config != nil && c.config.Concurrency > 1 {
+		problems = append(problems, fmt.Errorf("diagnostics can not measure scenarios executed at the same time, "+
+			"concurrency is %d", c.config.Concurrency))
+	}
+	if c.slowStepThreshold < 0 {
+		problems = append(problems, fmt.Errorf("slow step threshold %s can not be negative", c.slowStepThreshold))
+	}
+	if err := c.htmlOptions.Validate(); err != nil {
+		problems = append(problems, err)
+	}
+
+	if len(c.steps) == 0 {
+		problems = append(problems, errors.New("no step is registered, register steps with RegisterStep"))
+	}
+
+	for _, directory := range c.featureDirectories {
+		info, err := os.Stat(directory)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("feature directory %s does not exist", directory))
+		} else if !info.IsDir() {
+			problems = append(problems, fmt.Errorf("feature directory %s is not a directory", directory))
+		}
+	}
+
+	for _, path := range c.featurePaths {
+		if _, err := os.Stat(path); err != nil {
+			problems = append(problems, fmt.Errorf("feature path %s does not exist", path))
+		}
+	}
+
+	for _, root := range c.featureRoots {
+		if !fs.ValidPath(root) {
+			problems = append(problems, fmt.Errorf("feature root %s is not a valid path of the file system", root))
+		} else if _, err := fs.Stat(c.featuresFS, root); err != nil {
+			problems = append(problems, fmt.Errorf("feature root %s does not exist in the file system", root))
+		}
+	}
+
+	if len(c.nameFilter) > 0 {
+		if _, err := regexp.Compile(c.nameFilter); err != nil {
+			problems = append(problems, fmt.Errorf("name filter %s is not a valid regular expression, error=%w",
+				c.nameFilter, err))
+		}
+	}
+
+	for _, tag := range userTags {
+		if len(strings.TrimSpace(tag)) == 0 {
+			problems = append(problems, errors.New("tag can not be empty"))
+		} else if strings.ContainsAny(tag, " \t\n") {
+			problems = append(problems, fmt.Errorf("tag %q can not contain white space", tag))
+		} else if strings.HasPrefix(tag, "@") {
+			problems = append(problems, fmt.Errorf("tag %q must be given without @", tag))
+		}
+	}
+
+	if c.shardTotal != 0 && (c.shardTotal < 0 || c.shardIndex < 0 || c.shardIndex >= c.shardTotal) {
+		problems = append(problems, fmt.Errorf("shard index %d must be between 0 and shard total %d", c.shardIndex, c.shardTotal))
+	}
+
+	if len(c.format) > 0 && !slices.Contains([]string{reporter.Test2JSONFormat, reporter.ConsoleFormat,
+		reporter.ProgressFormat}, c.format) {
+		problems = append(problems, fmt.Errorf("unknown format %s", c.format))
+	}
+
+	if len(c.reportDirectory) > 0 {
+		if info, err := os.Stat(c.reportDirectory); err == nil && !info.IsDir() {
+			problems = append(problems, fmt.Errorf("report directory %s is not a directory", c.reportDirectory))
+		}
+	}
+
+	if len(c.rerunFile) > 0 {
+		if _, err := os.Stat(c.rerunFile); err != nil {
+			problems = append(problems, fmt.Errorf("rerun file %s does not exist", c.rerunFile))
+		}
+	}
+
+	for _, name := range c.timeZones {
+		if _, err := converter.LoadLocation(name); err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	if len(c.snippetFile) > 0 {
+		if info, err := os.Stat(c.snippetFile); err == nil && info.IsDir() {
+			problems = append(problems, fmt.Errorf("snippet file %s is a directory", c.snippetFile))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid cucumber runner configuration:\n%w", errors.Join(problems...))
+	}
+
+	return nil
+}
+
+// RunWithTags executes the scenarios of the feature files whose feature is tagged with one of the user tags.
+// All feature files are executed if no tag is given. If the CACIK_REPL environment variable is set, it starts the
+// REPL on the standard input and output instead.
 func (c *CucumberRunner) RunWithTags(userTags ...string) error {
-	if len(c.featureDirectories) == 0 {
+	if replRequested() {
+		return c.Repl(os.Stdin, os.Stdout)
+	}
+	_, err := c.Run(userTags...)
+
+	return err
+}
+
+// Run works like RunWithTags and also returns the result of the run so that callers can build custom reports
+// or notifications. The result is nil only if the run could not be started.
+func (c *CucumberRunner) Run(userTags ...string) (*models.RunResult, error) {
+	result, err := c.run(userTags)
+	if closeErr := c.closePlugins(); closeErr != nil {
+		return result, errors.Join(err, closeErr)
+	}
+
+	return result, err
+}
+
+// run executes the scenarios of Run, whose step libraries are stopped after it
+func (c *CucumberRunner) run(userTags []string) (*models.RunResult, error) {
+	userTags, err := c.applyProjectFile(userTags)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.featureDirectories) == 0 && len(c.featurePaths) == 0 && c.featuresFS == nil {
 		c.featureDirectories = append(c.featureDirectories, ".")
 	}
 
-	featureFiles, err := gherkin_parser.SearchFeatureFilesIn(c.featureDirectories)
+	debugger, stopDebugger, err := debuggerFromEnvironment()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer stopDebugger()
+	if debugger != nil {
+		c.WithDebugger(debugger)
 	}
 
-	allPickles := make([]*messages.Pickle, 0)
-	for _, file := range featureFiles {
-		readFile, err := os.ReadFile(file)
+	if err := c.validate(userTags); err != nil {
+		return nil, err
+	}
+
+	c.styleReporters()
+
+	featureFiles, err := c.featureFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := c.pickleFilters()
+	if err != nil {
+		return nil, err
+	}
+	if len(filters) > 0 {
+		c.executor.SetFilter(executor.AllFilters(filters...))
+	}
+
+	runResult := &models.RunResult{
+		StartedAt: time.Now(),
+		Tags:      userTags,
+		Meta:      newRunMeta(userTags, os.Getenv),
+		Scenarios: make([]*models.ScenarioResult, 0),
+		Hooks:     make([]*models.HookResult, 0),
+	}
+	var trace *executor.Trace
+	if len(c.traceFile) > 0 {
+		trace = executor.NewTrace()
+		c.executor.(Tracer).SetTrace(trace)
+	}
+	c.reporters.RunStarted(runResult)
+	runErr := c.execute(runResult, featureFiles, userTags, trace)
+	runResult.Duration = time.Since(runResult.StartedAt)
+	c.reporters.RunFinished(runResult)
+
+	if err := c.writeArtifacts(runResult, trace); err != nil {
+		return runResult, errors.Join(runErr, err)
+	}
+
+	if runErr != nil {
+		return runResult, runErr
+	}
+
+	if failedScenarios := runResult.FailedScenarios(); len(failedScenarios) > 0 {
+		names := make([]string, 0, len(failedScenarios))
+		for _, scenario := range failedScenarios {
+			names = append(names, fmt.Sprintf("%s: %s", scenario.Uri, scenario.Name))
+		}
+
+		return runResult, &ScenariosFailedError{Scenarios: names}
+	}
+
+	return runResult, nil
+}
+
+func (e *ScenariosFailedError) Error() string {
+	return fmt.Sprintf("%d scenario(s) failed:\n%s", len(e.Scenarios), strings.Join(e.Scenarios, "\n"))
+}
+
+// Audit returns the usage of every registered step definition in the run, sorted by definition, to find step
+// definitions which are never matched by any scenario. A nil result is a run without scenarios, in which every step
+// definition is unused.
+func (c *CucumberRunner) Audit(result *models.RunResult) []*models.StepUsage {
+	if result == nil {
+		result = &models.RunResult{}
+	}
+	// the steps are registered on the executor when the runner is validated, so the ones registered after are
+	// audited with the site of their registration too
+	sites := make(map[string]string, len(c.registrationSites)+len(c.registrations))
+	for definition, site := range c.registrationSites {
+		sites[definition] = site
+	}
+	for _, step := range c.registrations {
+		if _, ok := sites[step.definition]; !ok {
+			sites[step.definition] = step.site
+		}
+	}
+	definitions := make([]string, 0, len(sites))
+	for definition := range sites {
+		definitions = append(definitions, definition)
+	}
+	slices.Sort(definitions)
+
+	usages := result.StepUsage(definitions)
+	for _, usage := range usages {
+		usage.Site = sites[usage.Definition]
+	}
+
+	return usages
+}
+
+// applyProjectFile applies the settings of the project file which are not configured on the runner and returns
+// the tags of the run
+func (c *CucumberRunner) applyProjectFile(userTags []string) ([]string, error) {
+	var file *config_file.File
+	var err error
+	if len(c.projectFile) > 0 {
+		file, err = config_file.Load(c.projectFile)
+	} else {
+		file, err = config_file.FindAndLoad()
+	}
+	if err != nil || file == nil {
+		return userTags, err
+	}
+
+	if len(c.featureDirectories) == 0 && len(c.featurePaths) == 0 && c.featuresFS == nil {
+		c.WithFeaturePaths(file.Features...)
+	}
+	if len(userTags) == 0 {
+		userTags = file.Tags
+	}
+	if len(c.format) == 0 && len(file.Format) > 0 {
+		c.WithFormat(file.Format)
+	}
+	setIfEmpty := func(setting *string, value string) {
+		if len(*setting) == 0 {
+			*setting = value
+		}
+	}
+	if c.locations == nil {
+		c.locations = file.Locations
+	}
+	if file.Diagnostics {
+		c.WithDiagnostics()
+	}
+	if file.StrictKeywords {
+		c.WithStrictKeywords()
+	}
+	if c.slowStepThreshold == 0 {
+		c.slowStepThreshold = file.SlowStepThreshold
+	}
+	setIfEmpty(&c.symbols, file.Symbols)
+	setIfEmpty(&c.palette, file.Palette)
+	setIfEmpty(&c.verbosity, file.Verbosity)
+	setIfEmpty(&c.reportDirectory, file.Reports.Directory)
+	setIfEmpty(&c.markdownReport, file.Reports.Markdown)
+	setIfEmpty(&c.htmlReport, file.Reports.HTML)
+	setIfEmpty(&c.htmlOptions.Title, file.Reports.HTMLOptions.Title)
+	setIfEmpty(&c.htmlOptions.Logo, file.Reports.HTMLOptions.Logo)
+	setIfEmpty(&c.htmlOptions.Theme, file.Reports.HTMLOptions.Theme)
+	c.htmlOptions.EmbedAttachments = c.htmlOptions.EmbedAttachments || file.Reports.HTMLOptions.EmbedAttachments
+	c.htmlOptions.EmbedResult = c.htmlOptions.EmbedResult || file.Reports.HTMLOptions.EmbedResult
+	setIfEmpty(&c.cucumberReport, file.Reports.Cucumber)
+	setIfEmpty(&c.historyDirectory, file.Reports.History)
+	setIfEmpty(&c.resultFile, file.Reports.Result)
+	setIfEmpty(&c.snippetFile, file.Reports.Snippets)
+	setIfEmpty(&c.rerunOutput, file.Reports.Rerun)
+	setIfEmpty(&c.usageReport, file.Reports.Usage)
+	setIfEmpty(&c.badge, file.Reports.Badge)
+	setIfEmpty(&c.metricsFile, file.Reports.Metrics)
+	setIfEmpty(&c.pushgateway, file.Reports.Pushgateway.URL)
+	setIfEmpty(&c.pushgatewayJob, file.Reports.Pushgateway.Job)
+	setIfEmpty(&c.traceFile, file.Reports.Trace)
+	setIfEmpty(&c.artifactManifest, file.Reports.Manifest)
+
+	return userTags, nil
+}
+
+func (c *CucumberRunner) pickleFilters() ([]executor.PickleFilter, error) {
+	filters := make([]executor.PickleFilter, 0)
+	if c.shardTotal > 0 {
+		filters = append(filters, executor.ShardFilter(c.shardIndex, c.shardTotal))
+	}
+	if len(c.nameFilter) > 0 {
+		filters = append(filters, executor.NameFilter(regexp.MustCompile(c.nameFilter)))
+	}
+	if len(c.featureLocations) > 0 {
+		locationFilter := executor.LocationFilter(c.featureLocations)
+		filters = append(filters, func(document *messages.GherkinDocument, pickle *messages.Pickle) bool {
+			if _, ok := c.featureLocations[filepath.Clean(pickle.Uri)]; !ok {
+				return true
+			}
+
+			return locationFilter(document, pickle)
+		})
+	}
+	if len(c.rerunFile) > 0 {
+		locations, err := readRerunFile(c.rerunFile)
 		if err != nil {
-			return fmt.Errorf("could not read file %s, error=%w", file, err)
+			return nil, err
+		}
+		filters = append(filters, executor.LocationFilter(locations))
+	}
+
+	return filters, nil
+}
+
+// styleReporters sets the symbols, the palette, the locations and the verbosity of the runner on its console
+// reporters. The palette is not used by reporters whose writer can not be colored, see reporter.ColorsEnabled.
+func (c *CucumberRunner) styleReporters() {
+	symbols, _ := reporter.SymbolsByName(cmp.Or(c.symbols, reporter.UnicodeSymbols))
+	palette, _ := reporter.PaletteByName(cmp.Or(c.palette, reporter.NoPalette))
+	verbosity, _ := reporter.VerbosityByName(cmp.Or(c.verbosity, "normal"))
+	for _, r := range c.reporters {
+		switch typed := r.(type) {
+		case *reporter.ConsoleReporter:
+			if len(c.symbols) > 0 || len(c.palette) > 0 {
+				if typed.ColorsEnabled() {
+					typed.SetStyle(symbols, palette)
+				} else {
+					typed.SetStyle(symbols, nil)
+				}
+			}
+			if c.locations != nil {
+				typed.SetLocations(*c.locations)
+			}
+			typed.SetVerbosity(verbosity)
+		case *reporter.ProgressReporter:
+			typed.SetVerbosity(verbosity)
 		}
-		document, err := gherkin_parser.ParseGherkinFile(bytes.NewReader(readFile))
+	}
+}
+
+// featureFiles returns the feature files on the disk followed by the feature files in the file system of
+// WithFeaturesFS
+func (c *CucumberRunner) featureFiles() ([]featureFile, error) {
+	paths, err := gherkin_parser.SearchFeatureFilesIn(append(slices.Clone(c.featureDirectories), c.featurePaths...))
+	if err != nil {
+		return nil, err
+	}
+	files := make([]featureFile, 0, len(paths))
+	for _, path := range uniquePaths(paths) {
+		files = append(files, featureFile{path: path})
+	}
+	if c.featuresFS == nil {
+		return files, nil
+	}
+
+	paths, err = gherkin_parser.SearchFeatureFilesInFS(c.featuresFS, c.featureRoots)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range uniquePaths(paths) {
+		files = append(files, featureFile{path: path, fsys: c.featuresFS})
+	}
+
+	return files, nil
+}
+
+// parse returns the document of the feature file
+func (f featureFile) parse() (*messages.GherkinDocument, error) {
+	if f.fsys != nil {
+		return gherkin_parser.ParseFSCached(f.fsys, f.path)
+	}
+
+	return gherkin_parser.ParseFileCached(f.path)
+}
+
+func (c *CucumberRunner) execute(runResult *models.RunResult, featureFiles []featureFile, userTags []string,
+	trace *executor.Trace) (err error) {
+	if starter, ok := c.executor.(RunStarter); ok {
+		starter.StartRun()
+	}
+	// the reporters receive the scenarios as they finish if the executor can report them, otherwise after their
+	// feature file
+	notifier, live := c.executor.(ScenarioNotifier)
+	if live {
+		notifier.SetScenarioFinished(c.scenarioFinished)
+		defer notifier.SetScenarioFinished(nil)
+	}
+	ctx := executor.ContextWithTrace(context.Background(), trace)
+	hooks := executor.NewHookExecutor(c.config)
+	// the AfterAll hooks run once the BeforeAll hooks started, even if they or a feature file failed, so that they
+	// can release what the BeforeAll hooks acquired
+	defer func() {
+		if hook := hooks.AfterAll(ctx); hook != nil {
+			runResult.Hooks = append(runResult.Hooks, hook)
+			if hook.Status == models.StatusFailed {
+				err = errors.Join(err, errors.New(hook.Error))
+			}
+		}
+	}()
+	if hook := hooks.BeforeAll(ctx); hook != nil {
+		runResult.Hooks = append(runResult.Hooks, hook)
+		if hook.Status == models.StatusFailed {
+			return errors.New(hook.Error)
+		}
+	}
+
+	for _, file := range featureFiles {
+		document, err := file.parse()
 		if err != nil {
-			return fmt.Errorf("gherkin parse error in file %s, error=%w", file, err)
+			return err
+		}
+		if len(userTags) > 0 && (document.Feature == nil || !includeTags(document.Feature.Tags, userTags)) {
+			continue
 		}
 
-		pickles := gherkin.Pickles(*document, document.Uri, name)
-		allPickles = append(allPickles, pickles...)
+		results, err := c.executor.Execute(document)
+		if err != nil {
+			return fmt.Errorf("could not execute file %s, error=%w", file.path, err)
+		}
+		runResult.Scenarios = append(runResult.Scenarios, results...)
+		if !live {
+			for _, result := range results {
+				c.scenarioFinished(result)
+			}
+		}
+		feature := &models.FeatureResult{Uri: file.path, Scenarios: results}
+		if document.Feature != nil {
+			feature.Name = document.Feature.Name
+		}
+		if len(results) > 0 {
+			feature.Description = results[0].FeatureDescription
+		}
+		c.reporters.FeatureFinished(feature)
 	}
 
-	fmt.Println(allPickles)
 	return nil
 }
-func name() string {
-	v4, _ := uuid.NewV4()
-	return v4.String()
+
+// scenarioFinished marks the slow steps of the scenario and reports it
+func (c *CucumberRunner) scenarioFinished(scenario *models.ScenarioResult) {
+	c.markSlowSteps(scenario)
+	c.reporters.ScenarioFinished(scenario)
+}
+
+// markSlowSteps marks the steps of the scenario taking longer than the slow step threshold
+func (c *CucumberRunner) markSlowSteps(scenario *models.ScenarioResult) {
+	if c.slowStepThreshold <= 0 {
+		return
+	}
+	for _, step := range scenario.Steps {
+		step.Slow = step.Duration > c.slowStepThreshold
+	}
+}
+
+// splitFeatureLocation splits the lines from a feature path given as path/to.feature:line[:line...]
+func splitFeatureLocation(path string) (string, []int) {
+	lines := make([]int, 0)
+	for {
+		index := strings.LastIndex(path, ":")
+		if index < 0 {
+			break
+		}
+		line, err := strconv.Atoi(path[index+1:])
+		if err != nil || line < 1 {
+			break
+		}
+		lines = append([]int{line}, lines...)
+		path = path[:index]
+	}
+
+	return path, lines
+}
+
+// uniquePaths removes the repeated paths, such as a feature file given both directly and by its directory
+func uniquePaths(paths []string) []string {
+	seen := make(map[string]bool, len(paths))
+	unique := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if cleaned := filepath.Clean(path); !seen[cleaned] {
+			seen[cleaned] = true
+			unique = append(unique, path)
+		}
+	}
+
+	return unique
+}
+
+// callerSite returns file:line of the function skip levels above the caller of callerSite
+func callerSite(skip int) string {
+	_, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return "unknown location"
+	}
+
+	return fmt.Sprintf("%s:%d", file, line)
 }
 
 func getBackground(feature *messages.Feature) *messages.Background {
@@ -2,13 +2,29 @@ package runner
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	messages "github.com/cucumber/messages/go/v21"
+	"github.com/denizgursoy/cacik/pkg/converter"
+	"github.com/denizgursoy/cacik/pkg/executor"
 	"github.com/denizgursoy/cacik/pkg/gherkin_parser"
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/denizgursoy/cacik/pkg/plugin"
+	"github.com/denizgursoy/cacik/pkg/reporter"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -42,10 +58,14 @@ func TestCucumberRunner_RunWithTags(t *testing.T) {
 		require.Nil(t, err)
 		document, err := gherkin_parser.ParseGherkinFile(bytes.NewReader(readFile))
 		require.Nil(t, err)
+		document.Uri = "testdata/with-tag/a.feature"
 
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
 		executor.EXPECT().Execute(document).Times(1)
 
-		runner := NewCucumberRunner(executor).WithFeaturesDirectories("testdata/with-tag")
+		runner := NewCucumberRunner(executor).
+			WithFeaturesDirectories("testdata/with-tag").
+			RegisterStep("^hello$", func() {})
 		err = runner.RunWithTags("test")
 
 		require.Nil(t, err)
@@ -54,16 +74,1118 @@ func TestCucumberRunner_RunWithTags(t *testing.T) {
 		controller := gomock.NewController(t)
 		defer controller.Finish()
 		executor := NewMockExecutor(controller)
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
 
-		runner := NewCucumberRunner(executor).WithFeaturesDirectories("testdata/without-tag")
+		runner := NewCucumberRunner(executor).
+			WithFeaturesDirectories("testdata/without-tag").
+			RegisterStep("^hello$", func() {})
 		err := runner.RunWithTags("test")
 
 		require.Nil(t, err)
 	})
 }
 
+func TestCucumberRunner_Run(t *testing.T) {
+	t.Run("should return result of the scenarios", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		executor := NewMockExecutor(controller)
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
+		executor.EXPECT().Execute(gomock.Any()).Return([]*models.ScenarioResult{
+			{Name: "passing", Status: models.StatusPassed},
+			{Name: "failing", Uri: "testdata/with-tag/a.feature", Status: models.StatusFailed},
+		}, nil).Times(1)
+
+		result, err := NewCucumberRunner(executor).
+			WithFeaturesDirectories("testdata/with-tag").
+			RegisterStep("^hello$", func() {}).
+			Run()
+
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "testdata/with-tag/a.feature: failing")
+		require.NotNil(t, result)
+		require.Len(t, result.Scenarios, 2)
+		require.Equal(t, 1, result.Count(models.StatusPassed))
+		require.False(t, result.Passed())
+	})
+	t.Run("should notify reporters", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		executor := NewMockExecutor(controller)
+		reporter := NewMockReporter(controller)
+		scenario := &models.ScenarioResult{Name: "passing", Status: models.StatusPassed}
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
+		executor.EXPECT().Execute(gomock.Any()).Return([]*models.ScenarioResult{scenario}, nil).Times(1)
+		gomock.InOrder(
+			reporter.EXPECT().RunStarted(gomock.Any()).Times(1),
+			reporter.EXPECT().ScenarioFinished(scenario).Times(1),
+			reporter.EXPECT().FeatureFinished(gomock.Any()).Do(func(feature *models.FeatureResult) {
+				require.Equal(t, "testdata/with-tag/a.feature", feature.Uri)
+				require.Equal(t, []*models.ScenarioResult{scenario}, feature.Scenarios)
+			}).Times(1),
+			reporter.EXPECT().RunFinished(gomock.Any()).Times(1),
+		)
+
+		_, err := NewCucumberRunner(executor).
+			WithFeaturesDirectories("testdata/with-tag").
+			WithReporter(reporter).
+			RegisterStep("^hello$", func() {}).
+			Run()
+
+		require.Nil(t, err)
+	})
+	t.Run("should notify reporters of every scenario as soon as it finishes", func(t *testing.T) {
+		features := fstest.MapFS{"basket.feature": {Data: []byte("Feature: Basket\n\n" +
+			"  Scenario: Open basket\n    Given I count the reported scenarios\n\n" +
+			"  Scenario: Close basket\n    Given I count the reported scenarios\n")}}
+		reported := make([]string, 0)
+		counted := make([]int, 0)
+
+		_, err := NewCucumberRunner(nil).
+			WithFeaturesFS(features).
+			WithReporter(&scenarioRecorder{names: &reported}).
+			RegisterStep("^I count the reported scenarios$", func() { counted = append(counted, len(reported)) }).
+			Run()
+
+		require.Nil(t, err)
+		require.Equal(t, []int{0, 1}, counted)
+		require.Equal(t, []string{"Open basket", "Close basket"}, reported)
+	})
+	t.Run("should run the AfterAll hooks if the BeforeAll hooks or a feature file fail", func(t *testing.T) {
+		invalid := filepath.Join(t.TempDir(), "invalid.feature")
+		require.Nil(t, os.WriteFile(invalid, []byte("Given an apple\n"), 0o644))
+
+		for name, config := range map[string]func(afterAll func(context.Context) error) *models.Config{
+			"BeforeAll": func(afterAll func(context.Context) error) *models.Config {
+				return &models.Config{BeforeAll: func(context.Context) error { return errors.New("no database") },
+					AfterAll: afterAll}
+			},
+			"feature file": func(afterAll func(context.Context) error) *models.Config {
+				return &models.Config{AfterAll: afterAll}
+			},
+		} {
+			afterAll := 0
+			result, err := NewCucumberRunner(nil).
+				WithFeaturePaths(invalid).
+				WithConfigFunc(func() *models.Config {
+					return config(func(context.Context) error {
+						afterAll++
+
+						return errors.New("no cleanup")
+					})
+				}).
+				RegisterStep("^hello$", func() {}).
+				Run()
+
+			require.NotNil(t, err, name)
+			require.ErrorContains(t, err, "no cleanup", name)
+			require.Equal(t, 1, afterAll, name)
+			require.Contains(t, result.Hooks[len(result.Hooks)-1].Error, "no cleanup", name)
+		}
+	})
+}
+
+func TestCucumberRunner_Artifacts(t *testing.T) {
+	t.Run("should write artifacts and the manifest listing them", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		executor := NewMockExecutor(controller)
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
+		executor.EXPECT().Execute(gomock.Any()).Return([]*models.ScenarioResult{
+			{Name: "passing", Status: models.StatusPassed},
+		}, nil).Times(1)
+		directory := t.TempDir()
+		markdown := filepath.Join(directory, "report.md")
+		html := filepath.Join(directory, "report.html")
+		cucumber := filepath.Join(directory, "cucumber.json")
+		resultFile := filepath.Join(directory, "run.json")
+		manifest := filepath.Join(directory, "artifacts.json")
+
+		_, err := NewCucumberRunner(executor).
+			WithFeaturesDirectories("testdata/with-tag").
+			WithMarkdownReport(markdown).
+			WithHTMLReport(html).
+			WithCucumberJSONReport(cucumber).
+			WithResultFile(resultFile).
+			WithArtifactManifest(manifest).
+			RegisterStep("^hello$", func() {}).
+			Run()
+		require.Nil(t, err)
+
+		require.FileExists(t, markdown)
+		require.FileExists(t, html)
+		require.FileExists(t, cucumber)
+		saved, err := models.LoadRunResult(resultFile)
+		require.Nil(t, err)
+		require.Len(t, saved.Scenarios, 1)
+		content, err := os.ReadFile(manifest)
+		require.Nil(t, err)
+		require.JSONEq(t, fmt.Sprintf(`{"artifacts":[{"kind":"markdown","path":%q},{"kind":"html","path":%q},`+
+			`{"kind":"cucumber","path":%q},{"kind":"result","path":%q}]}`, markdown, html, cucumber, resultFile),
+			string(content))
+	})
+}
+
+func TestCucumberRunner_WithHistory(t *testing.T) {
+	t.Run("should append the summary of every run to the history and chart it in the html report", func(t *testing.T) {
+		directory := t.TempDir()
+		history := filepath.Join(directory, "history")
+		html := filepath.Join(directory, "report.html")
+		for i := 0; i < 2; i++ {
+			controller := gomock.NewController(t)
+			executor := NewMockExecutor(controller)
+			executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
+			executor.EXPECT().Execute(gomock.Any()).Return([]*models.ScenarioResult{
+				{Name: "passing", Status: models.StatusPassed},
+			}, nil).Times(1)
+
+			_, err := NewCucumberRunner(executor).
+				WithFeaturesDirectories("testdata/with-tag").
+				WithHistory(history).
+				WithHTMLReport(html).
+				RegisterStep("^hello$", func() {}).
+				Run()
+			require.Nil(t, err)
+			controller.Finish()
+		}
+
+		summaries, err := models.LoadHistory(history)
+		require.Nil(t, err)
+		require.Len(t, summaries, 2)
+		require.Equal(t, 1, summaries[1].Passed)
+		content, err := os.ReadFile(html)
+		require.Nil(t, err)
+		require.Contains(t, string(content), "Trend of the last 2 runs")
+	})
+}
+
+func TestCucumberRunner_WithMetrics(t *testing.T) {
+	t.Run("should write the metrics file and push the metrics to the pushgateway", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		executor := NewMockExecutor(controller)
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
+		executor.EXPECT().Execute(gomock.Any()).Return([]*models.ScenarioResult{
+			{Name: "passing", Status: models.StatusPassed},
+		}, nil).Times(1)
+		pushed := ""
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pushed = r.URL.Path
+		}))
+		defer server.Close()
+		metrics := filepath.Join(t.TempDir(), "cacik.prom")
+
+		_, err := NewCucumberRunner(executor).
+			WithFeaturesDirectories("testdata/with-tag").
+			WithMetricsFile(metrics).
+			WithPushgateway(server.URL, "checkout").
+			RegisterStep("^hello$", func() {}).
+			Run()
+
+		require.Nil(t, err)
+		require.Equal(t, "/metrics/job/checkout", pushed)
+		content, err := os.ReadFile(metrics)
+		require.Nil(t, err)
+		require.Contains(t, string(content), `cacik_scenarios{status="passed"} 1`)
+	})
+}
+
+func TestCucumberRunner_WithSlowStepThreshold(t *testing.T) {
+	t.Run("should mark the steps taking longer than the threshold as slow", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		executor := NewMockExecutor(controller)
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
+		executor.EXPECT().Execute(gomock.Any()).Return([]*models.ScenarioResult{
+			{Name: "passing", Status: models.StatusPassed, Steps: []*models.StepResult{
+				{Text: "hello", Duration: 2 * time.Second},
+				{Text: "hello", Duration: time.Second},
+			}},
+		}, nil).Times(1)
+
+		result, err := NewCucumberRunner(executor).
+			WithFeaturesDirectories("testdata/with-tag").
+			WithSlowStepThreshold(time.Second).
+			RegisterStep("^hello$", func() {}).
+			Run()
+
+		require.Nil(t, err)
+		require.True(t, result.Scenarios[0].Steps[0].Slow)
+		require.False(t, result.Scenarios[0].Steps[1].Slow)
+	})
+
+	t.Run("should return error if the threshold is negative", func(t *testing.T) {
+		err := NewCucumberRunner(nil).
+			WithSlowStepThreshold(-time.Second).
+			RegisterStep("^hello$", func() {}).
+			Validate()
+
+		require.ErrorContains(t, err, "slow step threshold -1s can not be negative")
+	})
+}
+
+func TestCucumberRunner_WithDiagnostics(t *testing.T) {
+	t.Run("should measure the scenarios of the default executor", func(t *testing.T) {
+		// the scenarios fail for their undefined steps, which are measured too
+		result, _ := NewCucumberRunner(nil).
+			WithFeaturesDirectories("testdata/with-tag").
+			WithDiagnostics().
+			RegisterStep("^hello$", func() {}).
+			Run()
+
+		require.NotEmpty(t, result.Scenarios)
+		require.NotNil(t, result.Scenarios[0].Diagnostics)
+	})
+
+	t.Run("should return error if the executor can not measure scenarios", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		executor := NewMockExecutor(controller)
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
+
+		err := NewCucumberRunner(executor).
+			WithDiagnostics().
+			RegisterStep("^hello$", func() {}).
+			Validate()
+
+		require.ErrorContains(t, err, "can not measure scenarios")
+	})
+
+	t.Run("should return the error of the project file once for every run", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		executor := NewMockExecutor(controller)
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
+		projectFile := filepath.Join(t.TempDir(), "cacik.yaml")
+		require.Nil(t, os.WriteFile(projectFile, []byte("diagnostics: true\n"), 0o644))
+		runner := NewCucumberRunner(executor).
+			WithProjectFile(projectFile).
+			RegisterStep("^hello$", func() {})
+
+		_, err := runner.Run()
+		require.ErrorContains(t, err, "can not measure scenarios")
+		_, err = runner.Run()
+
+		require.Equal(t, 1, strings.Count(err.Error(), "can not measure scenarios"), err.Error())
+	})
+
+	t.Run("should return error if the scenarios are executed at the same time", func(t *testing.T) {
+		err := NewCucumberRunner(nil).
+			WithConfigFunc(func() *models.Config { return &models.Config{Concurrency: 4} }).
+			WithDiagnostics().
+			RegisterStep("^hello$", func() {}).
+			Validate()
+
+		require.ErrorContains(t, err, "diagnostics can not measure scenarios executed at the same time, concurrency is 4")
+	})
+}
+
+func TestCucumberRunner_RegisterGiven(t *testing.T) {
+	t.Run("should fail steps using a definition of another keyword with strict keywords", func(t *testing.T) {
+		features := fstest.MapFS{"basket.feature": {Data: []byte(
+			"Feature: Basket\n\n  Scenario: Open basket\n    Given I open the basket\n    Then the basket is empty\n")}}
+
+		result, err := NewCucumberRunner(nil).
+			WithFeaturesFS(features).
+			WithStrictKeywords().
+			RegisterWhen("^I open the basket$", func() {}).
+			RegisterThen("^the basket is empty$", func() {}).
+			Run()
+
+		require.NotNil(t, err)
+		require.Equal(t, models.StatusFailed, result.Scenarios[0].Steps[0].Status)
+		require.Equal(t, `step "I open the basket" is a Given step but ^I open the basket$ is registered for `+
+			"When steps", result.Scenarios[0].Steps[0].Error)
+	})
+
+	t.Run("should register the same definition for different keywords", func(t *testing.T) {
+		features := fstest.MapFS{"basket.feature": {Data: []byte(
+			"Feature: Basket\n\n  Scenario: Empty basket\n    Given the basket is empty\n" +
+				"    Then the basket is empty\n")}}
+		used := make([]string, 0)
+
+		result, err := NewCucumberRunner(nil).
+			WithFeaturesFS(features).
+			WithStrictKeywords().
+			RegisterGiven("^the basket is empty$", func() { used = append(used, "given") }).
+			RegisterThen("^the basket is empty$", func() { used = append(used, "then") }).
+			Run()
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, result.Scenarios[0].Status)
+		require.Equal(t, []string{"given", "then"}, used)
+	})
+
+	t.Run("should return error if the executor can not register steps for keywords", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		executor := NewMockExecutor(controller)
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
+
+		err := NewCucumberRunner(executor).
+			RegisterStep("^hello$", func() {}).
+			RegisterGiven("^I open the basket$", func() {}).
+			Validate()
+
+		require.ErrorContains(t, err, "can not register steps for keywords")
+	})
+}
+
+func TestCucumberRunner_WithReportDirectory(t *testing.T) {
+	run := func(t *testing.T, directory string) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		executor := NewMockExecutor(controller)
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
+		executor.EXPECT().Execute(gomock.Any()).Return([]*models.ScenarioResult{
+			{Name: "passing", Status: models.StatusPassed},
+		}, nil).Times(1)
+
+		_, err := NewCucumberRunner(executor).
+			WithFeaturesDirectories("testdata/with-tag").
+			WithReportDirectory(directory).
+			WithArtifactManifest("artifacts.json").
+			RegisterStep("^hello$", func() {}).
+			Run()
+		require.Nil(t, err)
+	}
+
+	t.Run("should write the artifacts of each run to its own directory and link the latest one", func(t *testing.T) {
+		directory := t.TempDir()
+
+		run(t, directory)
+		run(t, directory)
+
+		entries, err := os.ReadDir(directory)
+		require.Nil(t, err)
+		require.Len(t, entries, 3)
+		latest, err := os.Readlink(filepath.Join(directory, LatestReportDirectory))
+		require.Nil(t, err)
+		require.Equal(t, entries[1].Name(), latest)
+		require.FileExists(t, filepath.Join(directory, latest, "report.md"))
+		require.FileExists(t, filepath.Join(directory, latest, "result.json"))
+		require.FileExists(t, filepath.Join(directory, latest, "artifacts.json"))
+		require.FileExists(t, filepath.Join(directory, entries[0].Name(), "result.json"))
+	})
+}
+
+func TestCucumberRunner_WithRerunFile(t *testing.T) {
+	t.Run("should rerun only the scenarios failed in the previous run", func(t *testing.T) {
+		rerunFile := filepath.Join(t.TempDir(), "rerun.txt")
+
+		result, err := NewCucumberRunner(nil).
+			WithFeaturesDirectories("testdata/with-tag").
+			WithRerunOutput(rerunFile).
+			RegisterStep("^hello$", func() {}).
+			Run()
+		require.NotNil(t, err)
+		require.Len(t, result.Scenarios, 4)
+		content, err := os.ReadFile(rerunFile)
+		require.Nil(t, err)
+		require.Equal(t, "testdata/with-tag/a.feature:20:25\n", string(content))
+
+		result, err = NewCucumberRunner(nil).
+			WithFeaturesDirectories("testdata/with-tag").
+			WithRerunFile(rerunFile).
+			RegisterStep("^hello$", func() {}).
+			Run()
+		require.NotNil(t, err)
+		require.Len(t, result.Scenarios, 2)
+		require.Equal(t, 20, result.Scenarios[0].Line)
+		require.Equal(t, 25, result.Scenarios[1].Line)
+	})
+	t.Run("should return an error for an invalid rerun file", func(t *testing.T) {
+		rerunFile := filepath.Join(t.TempDir(), "rerun.txt")
+		require.Nil(t, os.WriteFile(rerunFile, []byte("a.feature:x\n"), 0o644))
+
+		_, err := NewCucumberRunner(nil).
+			WithFeaturesDirectories("testdata/with-tag").
+			WithRerunFile(rerunFile).
+			RegisterStep("^hello$", func() {}).
+			Run()
+
+		require.EqualError(t, err, fmt.Sprintf(`rerun file %s line 1: location "a.feature:x" has an invalid line`, rerunFile))
+	})
+}
+
+func Test_readRerunFile(t *testing.T) {
+	t.Run("should split the lines from the end of the locations", func(t *testing.T) {
+		rerunFile := filepath.Join(t.TempDir(), "rerun.txt")
+		require.Nil(t, os.WriteFile(rerunFile, []byte(`C:\features\a.feature:3:7 b.feature:2`+"\n"), 0o644))
+
+		locations, err := readRerunFile(rerunFile)
+
+		require.Nil(t, err)
+		require.Equal(t, map[string][]int{`C:\features\a.feature`: {3, 7}, "b.feature": {2}}, locations)
+	})
+	t.Run("should return an error for locations without a line", func(t *testing.T) {
+		rerunFile := filepath.Join(t.TempDir(), "rerun.txt")
+		require.Nil(t, os.WriteFile(rerunFile, []byte(`C:\features\a.feature`+"\n"), 0o644))
+
+		_, err := readRerunFile(rerunFile)
+
+		require.EqualError(t, err, fmt.Sprintf(`rerun file %s line 1: location "C:\\features\\a.feature" has no line`,
+			rerunFile))
+	})
+}
+
+func TestCucumberRunner_WithFeaturePaths(t *testing.T) {
+	t.Run("should execute only the scenarios at the lines of the feature file", func(t *testing.T) {
+		result, err := NewCucumberRunner(nil).
+			WithFeaturePaths("testdata/with-tag/a.feature:5:25").
+			RegisterStep("^hello$", func() {}).
+			Run()
+
+		require.NotNil(t, err)
+		require.Len(t, result.Scenarios, 2)
+		require.Equal(t, "Missing product description", result.Scenarios[0].Name)
+		require.Equal(t, 25, result.Scenarios[1].Line)
+	})
+	t.Run("should execute every scenario of feature files without lines once", func(t *testing.T) {
+		result, err := NewCucumberRunner(nil).
+			WithFeaturesDirectories("testdata/with-tag").
+			WithFeaturePaths("testdata/with-tag/a.feature").
+			RegisterStep("^hello$", func() {}).
+			Run()
+
+		require.NotNil(t, err)
+		require.Len(t, result.Scenarios, 4)
+	})
+}
+
+func TestCucumberRunner_WithFeaturesFS(t *testing.T) {
+	features := fstest.MapFS{
+		"features/basket.feature": {Data: []byte("Feature: Basket\n\n  Scenario: Open basket\n    Given hello\n")},
+		"features/notes.txt":      {Data: []byte("not a feature")},
+		"other/fruit.feature":     {Data: []byte("Feature: Fruit\n\n  Scenario: Eat fruit\n    Given hello\n")},
+	}
+
+	t.Run("should execute the feature files under the roots of the file system", func(t *testing.T) {
+		result, err := NewCucumberRunner(nil).
+			WithFeaturesFS(features, "features").
+			RegisterStep("^hello$", func() {}).
+			Run()
+
+		require.Nil(t, err)
+		require.Len(t, result.Scenarios, 1)
+		require.Equal(t, "features/basket.feature", result.Scenarios[0].Uri)
+		require.Equal(t, models.StatusPassed, result.Scenarios[0].Status)
+	})
+	t.Run("should execute every feature file of the file system without roots", func(t *testing.T) {
+		result, err := NewCucumberRunner(nil).
+			WithFeaturesFS(features).
+			RegisterStep("^hello$", func() {}).
+			Run()
+
+		require.Nil(t, err)
+		require.Len(t, result.Scenarios, 2)
+	})
+	t.Run("should return error if a root does not exist", func(t *testing.T) {
+		err := NewCucumberRunner(nil).
+			WithFeaturesFS(features, "missing", "/features").
+			RegisterStep("^hello$", func() {}).
+			Validate()
+
+		require.ErrorContains(t, err, "feature root missing does not exist in the file system")
+		require.ErrorContains(t, err, "feature root /features is not a valid path of the file system")
+	})
+	t.Run("should return error if the file system is nil", func(t *testing.T) {
+		err := NewCucumberRunner(nil).
+			WithFeaturesFS(nil).
+			RegisterStep("^hello$", func() {}).
+			Validate()
+
+		require.ErrorContains(t, err, "feature file system can not be nil")
+	})
+}
+
+func TestCucumberRunner_WithClock(t *testing.T) {
+	t.Run("should resolve {now} with the clock during the run", func(t *testing.T) {
+		directory := t.TempDir()
+		require.Nil(t, os.WriteFile(filepath.Join(directory, "order.feature"), []byte(`Feature: Orders
+
+  Scenario: Place order
+    Given the order is placed at {now} + 1h
+`), 0o644))
+		frozen := time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)
+		var placed time.Time
+
+		_, err := NewCucumberRunner(nil).
+			WithFeaturesDirectories(directory).
+			WithClock(func() time.Time { return frozen }).
+			RegisterStep(`^the order is placed at {date}$`, func(at time.Time) { placed = at }).
+			Run()
+
+		require.Nil(t, err)
+		require.Equal(t, frozen.Add(time.Hour), placed)
+		require.NotEqual(t, frozen, converter.Now())
+	})
+	t.Run("should keep the clocks of runners running at the same time apart", func(t *testing.T) {
+		directory := t.TempDir()
+		require.Nil(t, os.WriteFile(filepath.Join(directory, "order.feature"), []byte(`Feature: Orders
+
+  Scenario: Place order
+    Given the order is placed at {now}
+`), 0o644))
+		clocks := []time.Time{time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC), time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)}
+		placed := make([]time.Time, len(clocks))
+		errs := make(chan error, len(clocks))
+		for i, frozen := range clocks {
+			go func() {
+				_, err := NewCucumberRunner(nil).
+					WithFeaturesDirectories(directory).
+					WithClock(func() time.Time { return frozen }).
+					RegisterStep(`^the order is placed at {date}$`, func(at time.Time) { placed[i] = at }).
+					Run()
+				errs <- err
+			}()
+		}
+		for range clocks {
+			require.Nil(t, <-errs)
+		}
+
+		require.Equal(t, clocks, placed)
+	})
+	t.Run("should report executors which can not set the clock", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		executor := NewMockExecutor(controller)
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
+
+		err := NewCucumberRunner(executor).
+			WithClock(time.Now).
+			RegisterStep("^hello$", func() {}).
+			Validate()
+
+		require.ErrorContains(t, err, "can not set the clock")
+	})
+}
+
+func TestCucumberRunner_WithNameFilter(t *testing.T) {
+	t.Run("should execute only the scenarios with a matching name", func(t *testing.T) {
+		result, err := NewCucumberRunner(nil).
+			WithFeaturesDirectories("testdata/with-tag").
+			WithNameFilter("product").
+			RegisterStep("^hello$", func() {}).
+			Run()
+
+		require.Nil(t, err)
+		require.Len(t, result.Scenarios, 2)
+	})
+}
+
+func TestCucumberRunner_Audit(t *testing.T) {
+	t.Run("should report the registered step definitions never matched in the run", func(t *testing.T) {
+		usageReport := filepath.Join(t.TempDir(), "usage.md")
+		runner := NewCucumberRunner(nil).
+			WithFeaturesDirectories("testdata/with-tag").
+			WithUsageReport(usageReport).
+			RegisterStep("^hello$", func() {}).
+			RegisterStep("^goodbye$", func() {})
+
+		result, _ := runner.Run()
+		usages := runner.Audit(result)
+
+		require.Len(t, usages, 2)
+		require.Equal(t, "^goodbye$", usages[0].Definition)
+		require.True(t, usages[0].Unused())
+		require.Regexp(t, `runner_test.go:\d+$`, usages[0].Site)
+		require.Equal(t, []string{"hello"}, usages[1].Texts)
+		content, err := os.ReadFile(usageReport)
+		require.Nil(t, err)
+		require.Contains(t, string(content), "## Unused Step Definitions")
+	})
+	t.Run("should report every step definition as unused without a result", func(t *testing.T) {
+		runner := NewCucumberRunner(nil).
+			RegisterStep("^hello$", func() {})
+
+		usages := runner.Audit(nil)
+
+		require.Len(t, usages, 1)
+		require.Equal(t, "^hello$", usages[0].Definition)
+		require.True(t, usages[0].Unused())
+	})
+}
+
+func TestCucumberRunner_WithTraceFile(t *testing.T) {
+	t.Run("should write the scenarios, hooks and steps of the run as trace events", func(t *testing.T) {
+		traceFile := filepath.Join(t.TempDir(), "trace.json")
+
+		_, err := NewCucumberRunner(nil).
+			WithConfigFunc(func() *models.Config {
+				return &models.Config{BeforeAll: func(context.Context) error { return nil }}
+			}).
+			WithFeaturePaths("testdata/with-tag/a.feature:5").
+			WithTraceFile(traceFile).
+			RegisterStep("^hello$", func() {}).
+			Run()
+
+		require.Nil(t, err)
+		content, err := os.ReadFile(traceFile)
+		require.Nil(t, err)
+		trace := struct {
+			TraceEvents []executor.TraceEvent `json:"traceEvents"`
+		}{}
+		require.Nil(t, json.Unmarshal(content, &trace))
+		categories := make([]string, 0)
+		for _, event := range trace.TraceEvents {
+			categories = append(categories, event.Category)
+		}
+		require.Equal(t, []string{executor.TraceCategoryHook, executor.TraceCategoryStep,
+			executor.TraceCategoryScenario}, categories)
+		require.Equal(t, "^hello$", trace.TraceEvents[1].Args["definition"])
+	})
+}
+
+func TestCucumberRunner_WithStepPlugin(t *testing.T) {
+	t.Run("should report plugins which can not be loaded", func(t *testing.T) {
+		err := NewCucumberRunner(nil).
+			WithFeaturesDirectories("testdata/with-tag").
+			WithStepPlugin("missing.so").
+			WithStepPlugin("testdata/missing-library").
+			RegisterStep("^hello$", func() {}).
+			Validate()
+
+		require.ErrorContains(t, err, "could not open plugin missing.so")
+		require.ErrorContains(t, err, "could not start step library testdata/missing-library")
+	})
+	t.Run("should start subprocess libraries when the runner is validated", func(t *testing.T) {
+		started := filepath.Join(t.TempDir(), "started")
+
+		runner := NewCucumberRunner(nil).
+			WithFeaturesDirectories("testdata/with-tag").
+			WithStepPlugin("sh", "-c", "touch "+started).
+			RegisterStep("^hello$", func() {})
+		require.NoFileExists(t, started)
+
+		err := runner.Validate()
+
+		require.ErrorContains(t, err, "did not answer steps request")
+		require.FileExists(t, started)
+	})
+	t.Run("should point failures of subprocess steps at their library", func(t *testing.T) {
+		features := fstest.MapFS{"basket.feature": {Data: []byte(
+			"Feature: Basket\n\n  Scenario: Open basket\n    Given I open the basket\n")}}
+		runner := NewCucumberRunner(nil).WithFeaturesFS(features)
+		runner.registerPluginSteps("bin/basket-steps", plugin.Steps{
+			"^I open the basket$": func(ctx context.Context, args models.Args) error {
+				return errors.New("basket is locked")
+			},
+		}, &models.SourceLocation{File: "bin/basket-steps"})
+
+		result, err := runner.Run()
+
+		require.NotNil(t, err)
+		require.Equal(t, &models.SourceLocation{File: "bin/basket-steps"}, result.Scenarios[0].Steps[0].Failure.Location)
+	})
+}
+
+func TestCucumberRunner_WithProjectFile(t *testing.T) {
+	t.Run("should use the settings of the project file not configured on the runner", func(t *testing.T) {
+		directory := t.TempDir()
+		projectFile := filepath.Join(directory, "cacik.yaml")
+		resultFile := filepath.Join(directory, "result.json")
+		badge := filepath.Join(directory, "badge.svg")
+		feature, err := filepath.Abs("testdata/with-tag/a.feature")
+		require.Nil(t, err)
+		require.Nil(t, os.WriteFile(projectFile, []byte(fmt.Sprintf(`features: [%s]
+tags: [billing]
+reports:
+  result: %s
+  badge: %s
+`, feature, resultFile, badge)), 0o644))
+
+		result, err := NewCucumberRunner(nil).
+			WithProjectFile(projectFile).
+			WithNameFilter("product").
+			RegisterStep("^hello$", func() {}).
+			Run()
+
+		require.Nil(t, err)
+		require.Equal(t, []string{"billing"}, result.Tags)
+		require.Len(t, result.Scenarios, 2)
+		require.FileExists(t, resultFile)
+		content, err := os.ReadFile(badge)
+		require.Nil(t, err)
+		require.Contains(t, string(content), "2 passed, 0 failed, 100.0%")
+	})
+	t.Run("should resolve the paths of the project file against its directory", func(t *testing.T) {
+		directory := t.TempDir()
+		require.Nil(t, os.MkdirAll(filepath.Join(directory, "features"), 0o755))
+		require.Nil(t, os.WriteFile(filepath.Join(directory, "features", "a.feature"),
+			[]byte("Feature: Paths\n  Scenario: Relative paths\n    Given hello\n"), 0o644))
+		projectFile := filepath.Join(directory, "cacik.yaml")
+		require.Nil(t, os.WriteFile(projectFile, []byte("features: [features]\nreports:\n  result: result.json\n"),
+			0o644))
+		workingDirectory, err := os.Getwd()
+		require.Nil(t, err)
+		require.Nil(t, os.Chdir(t.TempDir()))
+		t.Cleanup(func() {
+			require.Nil(t, os.Chdir(workingDirectory))
+		})
+
+		result, err := NewCucumberRunner(nil).
+			WithProjectFile(projectFile).
+			RegisterStep("^hello$", func() {}).
+			Run()
+
+		require.Nil(t, err)
+		require.Len(t, result.Scenarios, 1)
+		require.FileExists(t, filepath.Join(directory, "result.json"))
+	})
+	t.Run("should write the reports of the project file to the directory of the run", func(t *testing.T) {
+		directory := t.TempDir()
+		require.Nil(t, os.MkdirAll(filepath.Join(directory, "features"), 0o755))
+		require.Nil(t, os.WriteFile(filepath.Join(directory, "features", "a.feature"),
+			[]byte("Feature: Paths\n  Scenario: Report directory\n    Given hello\n"), 0o644))
+		projectFile := filepath.Join(directory, "cacik.yaml")
+		require.Nil(t, os.WriteFile(projectFile, []byte(`features: [features]
+reports:
+  directory: reports
+  markdown: summary.md
+  result: run.json
+  manifest: artifacts.json
+`), 0o644))
+
+		_, err := NewCucumberRunner(nil).
+			WithProjectFile(projectFile).
+			RegisterStep("^hello$", func() {}).
+			Run()
+
+		require.Nil(t, err)
+		runDirectory := filepath.Join(directory, "reports", LatestReportDirectory)
+		content, err := os.ReadFile(filepath.Join(runDirectory, "artifacts.json"))
+		require.Nil(t, err)
+		manifest := artifactManifest{}
+		require.Nil(t, json.Unmarshal(content, &manifest))
+		require.Len(t, manifest.Artifacts, 2)
+		reports, err := filepath.EvalSymlinks(filepath.Join(directory, "reports"))
+		require.Nil(t, err)
+		for _, artifact := range manifest.Artifacts {
+			resolved, err := filepath.EvalSymlinks(artifact.Path)
+			require.Nil(t, err)
+			require.Equal(t, reports, filepath.Dir(filepath.Dir(resolved)))
+		}
+		require.FileExists(t, filepath.Join(runDirectory, "summary.md"))
+		require.FileExists(t, filepath.Join(runDirectory, "run.json"))
+		require.NoFileExists(t, filepath.Join(directory, "summary.md"))
+		require.NoFileExists(t, filepath.Join(directory, "run.json"))
+	})
+}
+
+func TestCucumberRunner_WithShard(t *testing.T) {
+	t.Run("should set the shard filter of the executor", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		executor := NewMockExecutor(controller)
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
+		executor.EXPECT().SetFilter(gomock.Not(gomock.Nil())).Times(1)
+		executor.EXPECT().Execute(gomock.Any()).Times(1)
+
+		_, err := NewCucumberRunner(executor).
+			WithFeaturesDirectories("testdata/with-tag").
+			WithShard(1, 2).
+			RegisterStep("^hello$", func() {}).
+			Run()
+
+		require.Nil(t, err)
+	})
+	t.Run("should reject invalid shards", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		executor := NewMockExecutor(controller)
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
+
+		err := NewCucumberRunner(executor).
+			WithShard(2, 2).
+			RegisterStep("^hello$", func() {}).
+			Validate()
+
+		require.ErrorContains(t, err, "shard index 2 must be between 0 and shard total 2")
+	})
+}
+
+func TestCucumberRunner_Validate(t *testing.T) {
+	t.Run("should return all configuration problems at once", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		executor := NewMockExecutor(controller)
+		executor.EXPECT().SetConfig(gomock.Any()).Times(1)
+
+		runner := NewCucumberRunner(executor).
+			WithFeaturesDirectories("testdata/missing").
+			WithReportDirectory("runner_test.go").
+			WithFeaturePaths("testdata/missing.feature:3").
+			WithNameFilter("(").
+			WithTimeZones("UTC", "Nowhere/Unknown").
+			WithConfigFunc(func() *models.Config {
+				return &models.Config{Hooks: []*models.Hooks{{Tags: "@db and"}}, Providers: []any{"client"}}
+			})
+		err := runner.Validate("@test")
+
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "no step is registered")
+		require.Contains(t, err.Error(), "feature directory testdata/missing does not exist")
+		require.Contains(t, err.Error(), `tag "@test" must be given without @`)
+		require.Contains(t, err.Error(), `invalid tag expression "@db and"`)
+		require.Contains(t, err.Error(), "report directory runner_test.go is not a directory")
+		require.Contains(t, err.Error(), "feature path testdata/missing.feature does not exist")
+		require.Contains(t, err.Error(), "name filter ( is not a valid regular expression")
+		require.Contains(t, err.Error(), "time zone Nowhere/Unknown is not available")
+		require.NotContains(t, err.Error(), "time zone UTC")
+		require.Contains(t, err.Error(), "provider must be a function, got string")
+	})
+	t.Run("should report a month language without registered month names", func(t *testing.T) {
+		err := NewCucumberRunner(nil).
+			WithConfigFunc(func() *models.Config { return &models.Config{Language: "xx"} }).
+			RegisterStep("^hello$", func() {}).
+			Validate()
+
+		require.ErrorContains(t, err, "month names of language xx are not registered")
+	})
+	t.Run("should not execute any scenario if configuration is invalid", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		executor := NewMockExecutor(controller)
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
+		executor.EXPECT().Execute(gomock.Any()).Times(0)
+
+		err := NewCucumberRunner(executor).
+			WithFeaturesDirectories("testdata/with-tag").
+			RegisterStep("^hello$", func() {}).
+			RegisterStep("^hello$", func() {}).
+			RunWithTags()
+
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "step ^hello$ is registered more than once")
+		require.Regexp(t, `first at .*runner_test.go:\d+ and again at .*runner_test.go:\d+`, err.Error())
+	})
+	t.Run("should report providers the executor rejected with their registration site", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		executor := NewMockExecutor(controller)
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
+		executor.EXPECT().Provide(gomock.Any()).Return(fmt.Errorf("type *http.Client is provided more than once")).Times(1)
+
+		err := NewCucumberRunner(executor).
+			RegisterStep("^hello$", func() {}).
+			Provide(func() {}).
+			Validate()
+
+		require.NotNil(t, err)
+		require.Regexp(t, `type \*http.Client is provided more than once, registered at .*runner_test.go:\d+`, err.Error())
+	})
+	t.Run("should report providers of the config which are already provided", func(t *testing.T) {
+		err := NewCucumberRunner(nil).
+			RegisterStep("^hello$", func() {}).
+			Provide(func() *http.Client { return http.DefaultClient }).
+			WithConfigFunc(func() *models.Config {
+				return &models.Config{Providers: []any{func() *http.Client { return http.DefaultClient }}}
+			}).
+			Validate()
+
+		require.NotNil(t, err)
+		require.Regexp(t, `type \*http.Client is provided more than once, registered at .*runner_test.go:\d+`, err.Error())
+	})
+	t.Run("should not report providers of a config which replaces another config", func(t *testing.T) {
+		config := func() *models.Config {
+			return &models.Config{Providers: []any{func() *http.Client { return http.DefaultClient }}}
+		}
+
+		err := NewCucumberRunner(nil).
+			RegisterStep("^hello$", func() {}).
+			WithConfigFunc(config).
+			WithConfigFunc(config).
+			Validate()
+
+		require.Nil(t, err)
+	})
+	t.Run("should report parameter types the executor rejected with their registration site", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		executor := NewMockExecutor(controller)
+		executor.EXPECT().RegisterStep("^hello$", gomock.Any()).Times(1)
+		executor.EXPECT().RegisterParameterType("int", `\d+`, gomock.Any()).
+			Return(fmt.Errorf("parameter type {int} is built in")).Times(1)
+
+		err := NewCucumberRunner(executor).
+			RegisterParameterType("int", `\d+`, func(text string) (any, error) { return text, nil }).
+			RegisterStep("^hello$", func() {}).
+			Validate()
+
+		require.NotNil(t, err)
+		require.Regexp(t, `parameter type \{int\} is built in, registered at .*runner_test.go:\d+`, err.Error())
+	})
+	t.Run("should register steps after the parameter types registered after them", func(t *testing.T) {
+		feature := filepath.Join(t.TempDir(), "a.feature")
+		require.Nil(t, os.WriteFile(feature, []byte("Feature: Shop\n  Scenario: Pay\n    Given I pay 5 EUR\n"),
+			0o644))
+		paid := ""
+
+		result, err := NewCucumberRunner(nil).
+			WithFeaturePaths(feature).
+			RegisterStep(`^I pay {money}$`, func(amount string) { paid = amount }).
+			RegisterParameterType("money", `\d+ (?:EUR|USD)`, func(text string) (any, error) { return text, nil }).
+			Run()
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusPassed, result.Scenarios[0].Status)
+		require.Equal(t, "5 EUR", paid)
+	})
+	t.Run("should report steps using parameter types which are not registered", func(t *testing.T) {
+		err := NewCucumberRunner(nil).
+			RegisterStep(`^I pay {money}$`, func(string) {}).
+			Validate()
+
+		require.NotNil(t, err)
+		require.Regexp(t, `step \^I pay \{money\}\$ uses parameter type \{money\}, which is not registered, `+
+			`registered at .*runner_test.go:\d+`, err.Error())
+	})
+}
+
 func Test_Name(t *testing.T) {
 	compile := regexp.MustCompile("there are \\d apples")
 	submatch := compile.FindStringSubmatch("there are 5 apples")
 	fmt.Println(submatch)
 }
+
+func TestCucumberRunner_Repl(t *testing.T) {
+	newRunner := func() *CucumberRunner {
+		return NewCucumberRunner(nil).
+			RegisterStep(`^I have {int} apples$`, func(ctx context.Context, count int) {
+				models.DataFrom(ctx).Set("apples", count)
+			}).
+			RegisterStep(`^I have {int} pears$`, func(int) {}).
+			RegisterStep(`^I eat (\d+) apples$`, func(int) error { return errors.New("too many apples") })
+	}
+
+	t.Run("should run the typed steps in one scenario", func(t *testing.T) {
+		out := &strings.Builder{}
+		path := filepath.Join(t.TempDir(), "repl.feature")
+
+		err := newRunner().Repl(strings.NewReader("Given I have 3 apples\n:data\nWhen I eat 2 apples\n"+
+			"I sell apples\n:save "+path+"\n:quit\nI have 1 pears\n"), out)
+
+		require.Nil(t, err)
+		require.Contains(t, out.String(), "cacik> ok\ncacik> apples\ncacik> step \"I eat 2 apples\" failed: "+
+			"too many apples\ncacik> step \"I sell apples\" undefined")
+		require.NotContains(t, out.String(), "I have 1 pears")
+		content, err := os.ReadFile(path)
+		require.Nil(t, err)
+		require.Contains(t, string(content), "    Given I have 3 apples\n    When I eat 2 apples\n")
+	})
+	t.Run("should list the steps the start of a step can be completed to", func(t *testing.T) {
+		out := &strings.Builder{}
+
+		err := newRunner().Repl(strings.NewReader(":steps Given I have\n:steps I eat 3\n"), out)
+
+		require.Nil(t, err)
+		require.Contains(t, out.String(), "cacik> ^I have {int} apples$\n^I have {int} pears$\n"+
+			"cacik> ^I eat (\\d+) apples$\ncacik> ")
+	})
+	t.Run("should write the logs and attachments of the steps", func(t *testing.T) {
+		out := &strings.Builder{}
+
+		err := newRunner().
+			RegisterStep(`^I take a photo$`, func(ctx context.Context) {
+				models.LoggerFrom(ctx).Log("smile")
+				models.Attach(ctx, "photo", "image/png", []byte("png"))
+			}).
+			Repl(strings.NewReader("I take a photo\n"), out)
+
+		require.Nil(t, err)
+		require.Contains(t, out.String(), "cacik> smile\nattached photo (image/png, 3 bytes)\nok\n")
+	})
+}
+
+func TestDebugger(t *testing.T) {
+	t.Run("should print the paused step and wait until it is continued", func(t *testing.T) {
+		out := &strings.Builder{}
+		debugger := NewDebugger(out)
+		ctx := models.ContextWithData(context.Background(), models.NewData())
+		models.DataFrom(ctx).Set("apples", 3)
+		done := make(chan struct{})
+
+		go func() {
+			debugger.Pause(ctx, "I eat 1 apple")
+			close(done)
+		}()
+		server := httptest.NewServer(debugger)
+		defer server.Close()
+		response, err := http.Post(server.URL+ContinuePath, "", nil)
+
+		require.Nil(t, err)
+		require.Equal(t, http.StatusNoContent, response.StatusCode)
+		<-done
+		require.Equal(t, "paused before step \"I eat 1 apple\"\ndata: apples\n", out.String())
+	})
+	t.Run("should continue a paused step for every line", func(t *testing.T) {
+		debugger := NewDebugger(io.Discard)
+		done := make(chan struct{})
+
+		go func() {
+			debugger.Pause(context.Background(), "I have 3 apples")
+			debugger.Pause(context.Background(), "I eat 1 apple")
+			close(done)
+		}()
+		debugger.ContinueOnLines(strings.NewReader("\n\n"))
+
+		<-done
+	})
+	t.Run("should reject other methods than POST", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		NewDebugger(io.Discard).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ContinuePath, nil))
+
+		require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+	t.Run("should free the address of the debugger when it is stopped", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.Nil(t, err)
+		address := listener.Addr().String()
+		require.Nil(t, listener.Close())
+		t.Setenv(DebugVariable, address)
+
+		for i := 0; i < 2; i++ {
+			debugger, stop, err := debuggerFromEnvironment()
+			require.Nil(t, err)
+			require.NotNil(t, debugger)
+			stop()
+		}
+		_, err = http.Post("http://"+address+ContinuePath, "", nil)
+		require.NotNil(t, err)
+	})
+}
+
+func TestMultiReporter(t *testing.T) {
+	t.Run("should pass every event to the reporters in order", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+		first, second := NewMockReporter(controller), NewMockReporter(controller)
+		run := &models.RunResult{}
+		scenario := &models.ScenarioResult{Name: "passing"}
+		feature := &models.FeatureResult{Uri: "a.feature", Scenarios: []*models.ScenarioResult{scenario}}
+		gomock.InOrder(
+			first.EXPECT().RunStarted(run), second.EXPECT().RunStarted(run),
+			first.EXPECT().ScenarioFinished(scenario), second.EXPECT().ScenarioFinished(scenario),
+			first.EXPECT().FeatureFinished(feature), second.EXPECT().FeatureFinished(feature),
+			first.EXPECT().RunFinished(run), second.EXPECT().RunFinished(run),
+		)
+
+		reporter := NewMultiReporter(first, second)
+		reporter.RunStarted(run)
+		reporter.ScenarioFinished(scenario)
+		reporter.FeatureFinished(feature)
+		reporter.RunFinished(run)
+	})
+}
+
+// scenarioRecorder records the names of the finished scenarios
+type scenarioRecorder struct {
+	reporter.NoopReporter
+	names *[]string
+}
+
+func (r *scenarioRecorder) ScenarioFinished(scenario *models.ScenarioResult) {
+	*r.names = append(*r.names, scenario.Name)
+}
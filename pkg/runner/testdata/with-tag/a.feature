@@ -1,4 +1,4 @@
-@billing
+@billing @test
 Feature: Verify billing
 
   @important
@@ -0,0 +1,50 @@
+package steps
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	// TableShouldBePattern is the pattern of TableShouldBe.
+	// Register it with runner.RegisterStep(steps.TableShouldBePattern, steps.TableShouldBe).
+	TableShouldBePattern = `^the (\w+) table should be:$`
+
+	tableProviderKeyPrefix = "cacik.table."
+)
+
+type (
+	TableProvider func(ctx context.Context) (models.Table, error)
+)
+
+// ProvideTable registers the provider of the table with the name for the scenario the context belongs to.
+// TableShouldBe compares the table of the provider with the table attached to the step.
+func ProvideTable(ctx context.Context, name string, provider TableProvider) {
+	models.DataFrom(ctx).Set(tableProviderKeyPrefix+name, provider)
+}
+
+// TableShouldBe implements `Then the <name> table should be:` followed by the expected table. It fails with a
+// diff of the tables if the table of the provider registered with ProvideTable differs from the expected one.
+func TableShouldBe(ctx context.Context, name string, expected models.Table) error {
+	value, ok := models.DataFrom(ctx).Get(tableProviderKeyPrefix + name)
+	if !ok {
+		return fmt.Errorf("no table is provided with name %s, use steps.ProvideTable in an earlier step", name)
+	}
+	provider, ok := value.(TableProvider)
+	if !ok {
+		return fmt.Errorf("value stored for table %s is %T, not a table provider", name, value)
+	}
+
+	actual, err := provider(ctx)
+	if err != nil {
+		return fmt.Errorf("could not provide table %s, error=%w", name, err)
+	}
+
+	if diff, equal := models.DiffTables(expected, actual); !equal {
+		return fmt.Errorf("%s table is different than expected:\n%s", name, diff)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package steps
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/denizgursoy/cacik/pkg/executor"
+	"github.com/denizgursoy/cacik/pkg/gherkin_parser"
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ordersFeature = `Feature: Orders
+
+  Scenario: List orders
+    Given there are orders
+    Then the orders table should be:
+      | id | product |
+      | 1  | apple   |
+      | 2  | pear    |
+`
+)
+
+func executeOrders(t *testing.T, orders [][]string) *models.ScenarioResult {
+	document, err := gherkin_parser.ParseGherkinFile(strings.NewReader(ordersFeature))
+	require.Nil(t, err)
+
+	stepExecutor := executor.NewStepExecutor()
+	require.Nil(t, stepExecutor.RegisterStep(`^there are orders$`, func(ctx context.Context) {
+		ProvideTable(ctx, "orders", func(ctx context.Context) (models.Table, error) {
+			return models.NewTable(orders), nil
+		})
+	}))
+	require.Nil(t, stepExecutor.RegisterStep(TableShouldBePattern, TableShouldBe))
+
+	results, err := stepExecutor.Execute(document)
+	require.Nil(t, err)
+
+	return results[0]
+}
+
+func TestTableShouldBe(t *testing.T) {
+	t.Run("should pass if provided table is equal to the expected", func(t *testing.T) {
+		result := executeOrders(t, [][]string{{"id", "product"}, {"1", "apple"}, {"2", "pear"}})
+
+		require.Equal(t, models.StatusPassed, result.Status)
+	})
+	t.Run("should fail with the diff of the tables", func(t *testing.T) {
+		result := executeOrders(t, [][]string{{"id", "product"}, {"1", "apple"}, {"2", "plum"}, {"3", "fig"}})
+
+		require.Equal(t, models.StatusFailed, result.Status)
+		require.Equal(t, "orders table is different than expected:\n"+
+			"  | id | product |\n"+
+			"  | 1  | apple   |\n"+
+			"- | 2  | pear    |\n"+
+			"+ | 2  | plum    |\n"+
+			"+ | 3  | fig     |\n", result.Steps[1].Error)
+	})
+}
@@ -0,0 +1,109 @@
+package steps
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/converter"
+	"github.com/denizgursoy/cacik/pkg/models"
+)
+
+const (
+	// WaitForPattern is the pattern of WaitFor.
+	// Register it with runner.RegisterStep(steps.WaitForPattern, steps.WaitFor).
+	WaitForPattern = `^I wait for {duration}$`
+	// WaitUntilPattern is the pattern of WaitUntil.
+	// Register it with runner.RegisterStep(steps.WaitUntilPattern, steps.WaitUntil).
+	WaitUntilPattern = `^I wait until (.+)$`
+
+	clockKey = "cacik.clock"
+)
+
+type (
+	// Clock is the clock the wait steps read the time from and sleep with
+	Clock interface {
+		Now() time.Time
+		// Sleep waits for the duration and returns the error of the context if it is done earlier
+		Sleep(ctx context.Context, duration time.Duration) error
+	}
+
+	// realClock reads the time from the clock of the settings and sleeps for real
+	realClock struct {
+		settings converter.Settings
+	}
+)
+
+var (
+	// RealClock reads the time from converter.Now and sleeps for real. The wait steps of scenarios without a clock
+	// set with UseClock read the time from the clock of the run instead, which is set by runner.WithClock.
+	RealClock Clock = realClock{}
+
+	// Jitter is the maximum random duration added to every wait, so scenarios executed at the same time do not
+	// continue at the same moment. Waits have no jitter by default.
+	Jitter time.Duration
+)
+
+// UseClock sets the clock of the wait steps for the scenario the context belongs to, such as a fake clock in unit
+// tests which does not sleep
+func UseClock(ctx context.Context, clock Clock) {
+	models.DataFrom(ctx).Set(clockKey, clock)
+}
+
+// ClockFrom returns the clock set with UseClock for the scenario, or a clock reading the time from the clock of
+// the run and sleeping for real
+func ClockFrom(ctx context.Context) Clock {
+	if value, ok := models.DataFrom(ctx).Get(clockKey); ok {
+		if clock, ok := value.(Clock); ok {
+			return clock
+		}
+	}
+
+	return realClock{settings: converter.SettingsFrom(ctx)}
+}
+
+// WaitFor implements `When I wait for <duration>`, such as `When I wait for 1m30s`
+func WaitFor(ctx context.Context, duration time.Duration) error {
+	return ClockFrom(ctx).Sleep(ctx, duration+jitter())
+}
+
+// WaitUntil implements `When I wait until <time>`, such as `When I wait until 14:30` or
+// `When I wait until 2024-03-02T14:30:00Z`. A time without a date is the time of the current day. It does not wait
+// if the time has passed.
+func WaitUntil(ctx context.Context, until time.Time) error {
+	clock := ClockFrom(ctx)
+	now := clock.Now()
+	if until.Year() == 0 && until.YearDay() == 1 {
+		until = time.Date(now.Year(), now.Month(), now.Day(), until.Hour(), until.Minute(), until.Second(),
+			until.Nanosecond(), now.Location())
+	}
+	if !until.After(now) {
+		return nil
+	}
+
+	return clock.Sleep(ctx, until.Sub(now)+jitter())
+}
+
+func jitter() time.Duration {
+	if Jitter <= 0 {
+		return 0
+	}
+
+	return rand.N(Jitter)
+}
+
+func (c realClock) Now() time.Time {
+	return c.settings.Time()
+}
+
+func (realClock) Sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,79 @@
+package steps
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock records the durations slept without sleeping
+type fakeClock struct {
+	now   time.Time
+	slept []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(_ context.Context, duration time.Duration) error {
+	c.slept = append(c.slept, duration)
+	c.now = c.now.Add(duration)
+
+	return nil
+}
+
+func scenarioContext(clock Clock) context.Context {
+	ctx := models.ContextWithData(context.Background(), models.NewData())
+	if clock != nil {
+		UseClock(ctx, clock)
+	}
+
+	return ctx
+}
+
+func TestWaitFor(t *testing.T) {
+	t.Run("should sleep with the clock of the scenario", func(t *testing.T) {
+		clock := &fakeClock{}
+
+		require.Nil(t, WaitFor(scenarioContext(clock), 90*time.Second))
+		require.Equal(t, []time.Duration{90 * time.Second}, clock.slept)
+	})
+	t.Run("should add jitter up to the maximum", func(t *testing.T) {
+		Jitter = time.Second
+		defer func() {
+			Jitter = 0
+		}()
+		clock := &fakeClock{}
+
+		require.Nil(t, WaitFor(scenarioContext(clock), time.Minute))
+		require.GreaterOrEqual(t, clock.slept[0], time.Minute)
+		require.Less(t, clock.slept[0], time.Minute+time.Second)
+	})
+	t.Run("should stop sleeping when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(scenarioContext(nil))
+		cancel()
+
+		require.ErrorIs(t, WaitFor(ctx, time.Hour), context.Canceled)
+	})
+}
+
+func TestWaitUntil(t *testing.T) {
+	now := time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	t.Run("should wait until the time of the current day", func(t *testing.T) {
+		clock := &fakeClock{now: now}
+
+		require.Nil(t, WaitUntil(scenarioContext(clock), time.Date(0, 1, 1, 9, 30, 0, 0, time.UTC)))
+		require.Equal(t, []time.Duration{30 * time.Minute}, clock.slept)
+	})
+	t.Run("should not wait if the time has passed", func(t *testing.T) {
+		clock := &fakeClock{now: now}
+
+		require.Nil(t, WaitUntil(scenarioContext(clock), now.Add(-time.Minute)))
+		require.Empty(t, clock.slept)
+	})
+}
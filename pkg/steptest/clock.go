@@ -0,0 +1,45 @@
+package steptest
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// Clock is a clock for the wait steps which does not sleep. Sleeping moves its time forward by the duration.
+	Clock struct {
+		mutex sync.Mutex
+		now   time.Time
+	}
+)
+
+// NewClock creates a clock starting at the time
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+// Sleep moves the time of the clock forward by the duration without waiting
+func (c *Clock) Sleep(ctx context.Context, duration time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.Advance(duration)
+
+	return nil
+}
+
+// Advance moves the time of the clock forward by the duration
+func (c *Clock) Advance(duration time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(duration)
+}
@@ -0,0 +1,147 @@
+// Package steptest executes feature files given as strings, so step definitions can be unit tested with go test.
+package steptest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/denizgursoy/cacik/pkg/reporter"
+	"github.com/denizgursoy/cacik/pkg/runner"
+	"github.com/denizgursoy/cacik/pkg/steps"
+)
+
+const (
+	// FeatureFile is the name of the file the feature is executed from, it is the Uri of the scenario results
+	FeatureFile = "steptest.feature"
+)
+
+type (
+	Harness struct {
+		config         *models.Config
+		clock          steps.Clock
+		parameterTypes []parameterType
+		steps          []step
+	}
+
+	step struct {
+		definition string
+		function   any
+	}
+
+	parameterType struct {
+		name      string
+		regex     string
+		transform func(string) (any, error)
+	}
+)
+
+func New() *Harness {
+	return &Harness{
+		steps: make([]step, 0),
+	}
+}
+
+// WithConfig sets the hooks of the run
+func (h *Harness) WithConfig(config *models.Config) *Harness {
+	h.config = config
+
+	return h
+}
+
+// WithClock sets the clock of the wait steps of every scenario, such as a Clock which does not sleep
+func (h *Harness) WithClock(clock steps.Clock) *Harness {
+	h.clock = clock
+
+	return h
+}
+
+// RegisterParameterType adds a custom parameter type, see runner.CucumberRunner.RegisterParameterType. Parameter
+// types are registered before the steps regardless of the order of the calls.
+func (h *Harness) RegisterParameterType(name, regex string, transform func(string) (any, error)) *Harness {
+	h.parameterTypes = append(h.parameterTypes, parameterType{name: name, regex: regex, transform: transform})
+
+	return h
+}
+
+func (h *Harness) RegisterStep(definition string, function any) *Harness {
+	h.steps = append(h.steps, step{definition: definition, function: function})
+
+	return h
+}
+
+// RegisterSteps registers the functions of a step package by their step definitions
+func (h *Harness) RegisterSteps(steps map[string]any) *Harness {
+	for definition, function := range steps {
+		h.RegisterStep(definition, function)
+	}
+
+	return h
+}
+
+// Run executes the scenarios of the feature and returns the result of the run. Failed scenarios do not cause an
+// error, they are reported in the result; an error is returned if the feature could not be parsed or a
+// BeforeAll or AfterAll hook failed, together with the result if the run started.
+func (h *Harness) Run(feature string) (*models.RunResult, error) {
+	directory, err := os.MkdirTemp("", "steptest")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(directory)
+
+	if err := os.WriteFile(filepath.Join(directory, FeatureFile), []byte(feature), 0o644); err != nil {
+		return nil, err
+	}
+
+	cucumberRunner := runner.NewCucumberRunner(nil).
+		WithFeaturesDirectories(directory).
+		WithReporter(reporter.NoopReporter{})
+	if config := h.runConfig(); config != nil {
+		cucumberRunner.WithConfigFunc(func() *models.Config {
+			return config
+		})
+	}
+	for _, parameterType := range h.parameterTypes {
+		cucumberRunner.RegisterParameterType(parameterType.name, parameterType.regex, parameterType.transform)
+	}
+	for _, step := range h.steps {
+		cucumberRunner.RegisterStep(step.definition, step.function)
+	}
+
+	result, err := cucumberRunner.Run()
+	var failed *runner.ScenariosFailedError
+	if errors.As(err, &failed) {
+		err = nil
+	}
+	if result == nil {
+		return nil, err
+	}
+	for _, scenario := range result.Scenarios {
+		scenario.Uri = FeatureFile
+	}
+
+	return result, err
+}
+
+// runConfig returns the config of the run, adding a hook setting the clock to a copy of the config
+func (h *Harness) runConfig() *models.Config {
+	if h.clock == nil {
+		return h.config
+	}
+
+	config := &models.Config{}
+	if h.config != nil {
+		*config = *h.config
+	}
+	config.Hooks = append([]*models.Hooks{{
+		BeforeScenario: func(ctx context.Context) error {
+			steps.UseClock(ctx, h.clock)
+
+			return nil
+		},
+	}}, config.Hooks...)
+
+	return config
+}
@@ -0,0 +1,103 @@
+package steptest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/denizgursoy/cacik/pkg/models"
+	"github.com/denizgursoy/cacik/pkg/steps"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	appleFeature = `Feature: Apples
+
+  Scenario: Eat apples
+    Given I have 3 apples
+    When I eat 1 apple
+`
+)
+
+func TestHarness_Run(t *testing.T) {
+	t.Run("should execute the feature with the registered steps", func(t *testing.T) {
+		apples := 0
+
+		result, err := New().
+			RegisterSteps(map[string]any{
+				`^I have {int} apples$`: func(count int) { apples = count },
+				`^I eat {int} apple$`:   func(count int) { apples -= count },
+			}).
+			Run(appleFeature)
+
+		require.Nil(t, err)
+		require.True(t, result.Passed())
+		require.Equal(t, 2, apples)
+		require.Equal(t, FeatureFile, result.Scenarios[0].Uri)
+	})
+	t.Run("should register parameter types before the steps", func(t *testing.T) {
+		apples := ""
+
+		result, err := New().
+			RegisterStep(`^I have {int} apples$`, func(count int) {}).
+			RegisterStep(`^I eat {amount} apple$`, func(amount string) { apples = amount }).
+			RegisterParameterType("amount", `\d+`, func(text string) (any, error) { return text + " piece", nil }).
+			Run(appleFeature)
+
+		require.Nil(t, err)
+		require.True(t, result.Passed())
+		require.Equal(t, "1 piece", apples)
+	})
+	t.Run("should wait with the clock without sleeping", func(t *testing.T) {
+		clock := NewClock(time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC))
+		var waitedUntil time.Time
+
+		result, err := New().
+			WithClock(clock).
+			RegisterStep(steps.WaitForPattern, steps.WaitFor).
+			RegisterStep(steps.WaitUntilPattern, steps.WaitUntil).
+			RegisterStep(`^I look at the clock$`, func(ctx context.Context) {
+				waitedUntil = steps.ClockFrom(ctx).Now()
+			}).
+			Run(`Feature: Waiting
+
+  Scenario: Wait
+    When I wait for 1h
+    And I wait until 14:30
+    Then I look at the clock
+`)
+
+		require.Nil(t, err)
+		require.True(t, result.Passed())
+		require.Equal(t, time.Date(2024, 3, 2, 14, 30, 0, 0, time.UTC), waitedUntil)
+	})
+	t.Run("should report failed scenarios in the result", func(t *testing.T) {
+		result, err := New().
+			RegisterStep(`^I have {int} apples$`, func(count int) error { return errors.New("no apples") }).
+			RegisterStep(`^I eat {int} apple$`, func(count int) {}).
+			Run(appleFeature)
+
+		require.Nil(t, err)
+		require.Equal(t, models.StatusFailed, result.Scenarios[0].Status)
+		require.Equal(t, "no apples", result.Scenarios[0].Steps[0].Error)
+	})
+	t.Run("should return error for an invalid feature", func(t *testing.T) {
+		_, err := New().RegisterStep(`^I have {int} apples$`, func(count int) {}).Run("Scenario: no feature")
+
+		require.NotNil(t, err)
+	})
+	t.Run("should return the error of a hook with the failed scenarios", func(t *testing.T) {
+		result, err := New().
+			WithConfig(&models.Config{AfterAll: func(context.Context) error { return errors.New("no cleanup") }}).
+			RegisterStep(`^I have {int} apples$`, func(count int) error { return errors.New("no apples") }).
+			RegisterStep(`^I eat {int} apple$`, func(count int) {}).
+			Run(appleFeature)
+
+		require.ErrorContains(t, err, "no cleanup")
+		require.NotContains(t, err.Error(), "scenario(s) failed")
+		require.NotNil(t, result)
+		require.Equal(t, models.StatusFailed, result.Scenarios[0].Status)
+		require.Equal(t, FeatureFile, result.Scenarios[0].Uri)
+	})
+}
@@ -0,0 +1,186 @@
+package tag_expression
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	and = "and"
+	or  = "or"
+	not = "not"
+)
+
+type (
+	// Expression is a parsed cucumber tag expression such as `@db and not (@slow or @wip)`
+	Expression interface {
+		Evaluate(tags []string) bool
+		String() string
+	}
+
+	tagExpression struct {
+		tag string
+	}
+
+	notExpression struct {
+		expression Expression
+	}
+
+	binaryExpression struct {
+		operator string
+		left     Expression
+		right    Expression
+	}
+
+	trueExpression struct{}
+
+	parser struct {
+		tokens   []string
+		position int
+	}
+)
+
+// Parse parses the tag expression. An empty expression matches every set of tags.
+func Parse(expression string) (Expression, error) {
+	tokens := tokenize(expression)
+	if len(tokens) == 0 {
+		return trueExpression{}, nil
+	}
+
+	p := &parser{tokens: tokens}
+	parsed, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag expression %q, error=%w", expression, err)
+	}
+	if p.position != len(p.tokens) {
+		return nil, fmt.Errorf("invalid tag expression %q, error=unexpected %q", expression, p.tokens[p.position])
+	}
+
+	return parsed, nil
+}
+
+func tokenize(expression string) []string {
+	expression = strings.ReplaceAll(expression, "(", " ( ")
+	expression = strings.ReplaceAll(expression, ")", " ) ")
+
+	return strings.Fields(expression)
+}
+
+func (p *parser) peek() string {
+	if p.position < len(p.tokens) {
+		return p.tokens[p.position]
+	}
+
+	return ""
+}
+
+func (p *parser) parseOr() (Expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == or {
+		p.position++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpression{operator: or, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expression, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == and {
+		p.position++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpression{operator: and, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expression, error) {
+	if p.peek() == not {
+		p.position++
+		expression, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return &notExpression{expression: expression}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expression, error) {
+	token := p.peek()
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case token == "(":
+		p.position++
+		expression, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.position++
+
+		return expression, nil
+	case strings.HasPrefix(token, "@") && len(token) > 1:
+		p.position++
+
+		return &tagExpression{tag: token}, nil
+	default:
+		return nil, fmt.Errorf("unexpected %q, tags must start with @", token)
+	}
+}
+
+func (t *tagExpression) Evaluate(tags []string) bool {
+	return slices.Contains(tags, t.tag)
+}
+
+func (t *tagExpression) String() string {
+	return t.tag
+}
+
+func (n *notExpression) Evaluate(tags []string) bool {
+	return !n.expression.Evaluate(tags)
+}
+
+func (n *notExpression) String() string {
+	return fmt.Sprintf("not ( %s )", n.expression.String())
+}
+
+func (b *binaryExpression) Evaluate(tags []string) bool {
+	if b.operator == and {
+		return b.left.Evaluate(tags) && b.right.Evaluate(tags)
+	}
+
+	return b.left.Evaluate(tags) || b.right.Evaluate(tags)
+}
+
+func (b *binaryExpression) String() string {
+	return fmt.Sprintf("( %s %s %s )", b.left.String(), b.operator, b.right.String())
+}
+
+func (trueExpression) Evaluate([]string) bool {
+	return true
+}
+
+func (trueExpression) String() string {
+	return "true"
+}
@@ -0,0 +1,38 @@
+package tag_expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should evaluate expressions with operator precedence", func(t *testing.T) {
+		expression, err := Parse("@db and not @slow or @smoke")
+		require.Nil(t, err)
+
+		require.True(t, expression.Evaluate([]string{"@db"}))
+		require.False(t, expression.Evaluate([]string{"@db", "@slow"}))
+		require.True(t, expression.Evaluate([]string{"@slow", "@smoke"}))
+		require.False(t, expression.Evaluate([]string{}))
+	})
+	t.Run("should evaluate parentheses", func(t *testing.T) {
+		expression, err := Parse("@db and (@slow or @smoke)")
+		require.Nil(t, err)
+
+		require.False(t, expression.Evaluate([]string{"@db"}))
+		require.True(t, expression.Evaluate([]string{"@db", "@smoke"}))
+	})
+	t.Run("should match every tag set if expression is empty", func(t *testing.T) {
+		expression, err := Parse("  ")
+		require.Nil(t, err)
+
+		require.True(t, expression.Evaluate(nil))
+	})
+	t.Run("should return error for invalid expressions", func(t *testing.T) {
+		for _, expression := range []string{"db", "@db and", "(@db", "@db @slow", "@db)"} {
+			_, err := Parse(expression)
+			require.NotNil(t, err, expression)
+		}
+	})
+}